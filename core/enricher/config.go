@@ -0,0 +1,84 @@
+//
+// Copyright (C) 2020 IBM Corporation.
+//
+// Authors:
+// Frederico Araujo <frederico.araujo@ibm.com>
+// Teryl Taylor <terylt@ibm.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+package enricher
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Configuration keys.
+const (
+	HandlersConfigKey       string = "handlers"
+	HandlerLibPathConfigKey string = "handlerlibpath"
+	HandlerConfigKeySuffix  string = ".config"
+	TimeoutConfigKey        string = "timeout"
+	InFlightConfigKey       string = "inflight"
+)
+
+// Config defines a configuration object for the enricher.
+type Config struct {
+	Handlers       []string
+	HandlerConfigs map[string]string
+	HandlerLibPath string
+	Timeout        time.Duration
+	InFlight       int
+}
+
+// CreateConfig creates a new config object from config dictionary.
+func CreateConfig(conf map[string]interface{}) (Config, error) {
+	var c Config = Config{Timeout: time.Second, InFlight: 1000, HandlerConfigs: make(map[string]string)} // default values
+
+	if v, ok := conf[HandlersConfigKey].(string); ok {
+		for _, h := range strings.Split(v, ",") {
+			if h = strings.TrimSpace(h); len(h) > 0 {
+				c.Handlers = append(c.Handlers, h)
+			}
+		}
+	}
+	if len(c.Handlers) == 0 {
+		return c, errors.New("Configuration tag 'handlers' missing from enricher plugin settings")
+	}
+	for _, h := range c.Handlers {
+		if v, ok := conf[h+HandlerConfigKeySuffix].(string); ok {
+			c.HandlerConfigs[h] = v
+		}
+	}
+	if v, ok := conf[HandlerLibPathConfigKey].(string); ok {
+		c.HandlerLibPath = v
+	}
+	if v, ok := conf[TimeoutConfigKey].(string); ok {
+		d, err := time.ParseDuration(v)
+		if err != nil || d <= 0 {
+			return c, errors.New("Configuration tag 'timeout' must be a positive duration")
+		}
+		c.Timeout = d
+	}
+	if v, ok := conf[InFlightConfigKey].(string); ok {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			return c, errors.New("Configuration tag 'inflight' must be a positive integer")
+		}
+		c.InFlight = n
+	}
+	return c, nil
+}
@@ -0,0 +1,198 @@
+//
+// Copyright (C) 2020 IBM Corporation.
+//
+// Authors:
+// Frederico Araujo <frederico.araujo@ibm.com>
+// Teryl Taylor <terylt@ibm.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+package enricher
+
+import (
+	"sync"
+	"time"
+
+	"github.com/sysflow-telemetry/sf-apis/go/logger"
+	"github.com/sysflow-telemetry/sf-apis/go/plugins"
+	"github.com/sysflow-telemetry/sf-processor/core/cache"
+	"github.com/sysflow-telemetry/sf-processor/core/flattener"
+	"github.com/sysflow-telemetry/sf-processor/core/policyengine/engine"
+)
+
+const (
+	pluginName string = "enricher"
+)
+
+// namedHandler is an enrichment handler of the handler chain.
+type namedHandler struct {
+	name string
+	hdl  engine.Handler
+}
+
+// pendingRecord is a record being enriched, which is output once done is closed.
+type pendingRecord struct {
+	r    *engine.Record
+	done chan struct{}
+}
+
+// Enricher defines a plugin annotating records with a chain of enrichment handlers.
+type Enricher struct {
+	config   Config
+	handlers []namedHandler
+	tables   *cache.SFTables
+	outCh    []chan *engine.Record
+}
+
+// NewEnricher creates a new plugin instance.
+func NewEnricher() plugins.SFProcessor {
+	return new(Enricher)
+}
+
+// GetName returns the plugin name.
+func (s *Enricher) GetName() string {
+	return pluginName
+}
+
+// Register registers plugin to plugin cache.
+func (s *Enricher) Register(pc plugins.SFPluginCache) {
+	pc.AddProcessor(pluginName, NewEnricher)
+}
+
+// Init initializes the plugin with a configuration map, and creates and initializes its handler chain.
+func (s *Enricher) Init(conf map[string]interface{}) error {
+	config, err := CreateConfig(conf)
+	if err != nil {
+		return err
+	}
+	s.config = config
+	s.tables = cache.GetInstance()
+	for _, name := range s.config.Handlers {
+		hdl, err := getHandler(name, s.config.HandlerLibPath)
+		if err != nil {
+			s.cleanupHandlers()
+			return err
+		}
+		if err = hdl.Init(s.config.HandlerConfigs[name]); err != nil {
+			s.cleanupHandlers()
+			logger.Error.Printf("Unable to initialize enrichment handler %s, %v", name, err)
+			return err
+		}
+		s.handlers = append(s.handlers, namedHandler{name: name, hdl: hdl})
+	}
+	return nil
+}
+
+// Process implements the main loop of the plugin. Records are read from a flattener channel, before policy
+// evaluation, or from a record channel, after policy evaluation. Up to the configured number of records are
+// enriched at once, and records are output in the order in which they were received.
+func (s *Enricher) Process(ch interface{}, wg *sync.WaitGroup) {
+	defer wg.Done()
+	inflight := make(chan *pendingRecord, s.config.InFlight)
+	outDone := make(chan struct{})
+	go func() {
+		for p := range inflight {
+			<-p.done
+			for _, c := range s.outCh {
+				c <- p.r
+			}
+		}
+		close(outDone)
+	}()
+	enrich := func(r *engine.Record) {
+		p := &pendingRecord{r: r, done: make(chan struct{})}
+		inflight <- p
+		s.enrich(p, 0)
+	}
+
+	switch cha := ch.(type) {
+	case *flattener.FlatChannel:
+		logger.Trace.Println("Starting enricher with capacity: ", cap(cha.In))
+		for fc := range cha.In {
+			enrich(engine.NewRecord(*fc, s.tables))
+		}
+	case *engine.RecordChannel:
+		logger.Trace.Println("Starting enricher with capacity: ", cap(cha.In))
+		for r := range cha.In {
+			enrich(r)
+		}
+	default:
+		logger.Error.Printf("Unsupported input channel type %T for plugin %s", ch, pluginName)
+	}
+	logger.Trace.Println("Input channel closed. Shutting down.")
+	close(inflight)
+	<-outDone
+}
+
+// enrich runs the handlers of the chain on record p, starting at handler i, one after the other.
+// Handlers that do not complete within the configured timeout are skipped, and their annotations dropped.
+// Each handler runs on a copy of the record, since a handler that timed out may still access it while the
+// record is processed downstream.
+func (s *Enricher) enrich(p *pendingRecord, i int) {
+	if i == len(s.handlers) {
+		close(p.done)
+		return
+	}
+	h := s.handlers[i]
+	var once sync.Once
+	timer := time.AfterFunc(s.config.Timeout, func() {
+		once.Do(func() {
+			logger.Warn.Printf("Enrichment handler %s timed out after %v\n", h.name, s.config.Timeout)
+			s.enrich(p, i+1)
+		})
+	})
+	callback := func(o interface{}) {
+		once.Do(func() {
+			timer.Stop()
+			if o != nil {
+				p.r.Ctx.SetEnrichment(h.name, o)
+			}
+			s.enrich(p, i+1)
+		})
+	}
+	if err := h.hdl.ProcessAsync(p.r.Copy(), callback); err != nil {
+		once.Do(func() {
+			timer.Stop()
+			logger.Error.Printf("Error running enrichment handler %s: %v\n", h.name, err)
+			s.enrich(p, i+1)
+		})
+	}
+}
+
+// SetOutChan sets the output channel of the plugin.
+func (s *Enricher) SetOutChan(ch []interface{}) {
+	for _, c := range ch {
+		s.outCh = append(s.outCh, (c.(*engine.RecordChannel)).In)
+	}
+}
+
+// cleanupHandlers cleans up the handlers of the chain.
+func (s *Enricher) cleanupHandlers() {
+	for _, h := range s.handlers {
+		if err := h.hdl.Cleanup(); err != nil {
+			logger.Error.Printf("Error cleaning up enrichment handler %s: %v\n", h.name, err)
+		}
+	}
+	s.handlers = nil
+}
+
+// Cleanup clean up the plugin resources.
+func (s *Enricher) Cleanup() {
+	logger.Trace.Println("Exiting ", pluginName)
+	s.cleanupHandlers()
+	if s.outCh != nil {
+		for _, c := range s.outCh {
+			close(c)
+		}
+	}
+}
@@ -0,0 +1,172 @@
+//
+// Copyright (C) 2020 IBM Corporation.
+//
+// Authors:
+// Frederico Araujo <frederico.araujo@ibm.com>
+// Teryl Taylor <terylt@ibm.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+package enricher_test
+
+import (
+	"errors"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/sysflow-telemetry/sf-apis/go/logger"
+	"github.com/sysflow-telemetry/sf-apis/go/sfgo"
+	"github.com/sysflow-telemetry/sf-processor/core/enricher"
+	"github.com/sysflow-telemetry/sf-processor/core/flattener"
+	"github.com/sysflow-telemetry/sf-processor/core/policyengine/engine"
+)
+
+func TestMain(m *testing.M) {
+	logger.InitLoggers(logger.TRACE)
+	os.Exit(m.Run())
+}
+
+// testHandler annotates records with their pid after a delay, or fails.
+type testHandler struct {
+	delay func(pid int64) time.Duration
+	fail  bool
+}
+
+func (h *testHandler) Init(confPath string) error { return nil }
+
+func (h *testHandler) ProcessSync(r *engine.Record) (interface{}, error) {
+	return r.GetInt(sfgo.PROC_OID_HPID_INT, sfgo.SYSFLOW_SRC), nil
+}
+
+func (h *testHandler) ProcessAsync(r *engine.Record, callback func(o interface{})) error {
+	if h.fail {
+		return errors.New("failed")
+	}
+	pid := r.GetInt(sfgo.PROC_OID_HPID_INT, sfgo.SYSFLOW_SRC)
+	time.AfterFunc(h.delay(pid), func() { callback(pid) })
+	return nil
+}
+
+func (h *testHandler) Cleanup() error { return nil }
+
+func newFlatRecord(pid int64) *sfgo.FlatRecord {
+	fr := &sfgo.FlatRecord{
+		Sources: []sfgo.Source{sfgo.SYSFLOW_SRC},
+		Ints:    [][]int64{make([]int64, sfgo.INT_ARRAY_SIZE)},
+		Strs:    [][]string{make([]string, sfgo.STR_ARRAY_SIZE)},
+	}
+	fr.Ints[0][sfgo.PROC_OID_HPID_INT] = pid
+	return fr
+}
+
+func run(t *testing.T, conf map[string]interface{}, n int) []*engine.Record {
+	p := enricher.NewEnricher()
+	assert.NoError(t, p.Init(conf))
+	in := &flattener.FlatChannel{In: make(chan *sfgo.FlatRecord, n)}
+	out := &engine.RecordChannel{In: make(chan *engine.Record, n)}
+	p.SetOutChan([]interface{}{out})
+	for i := 0; i < n; i++ {
+		in.In <- newFlatRecord(int64(i))
+	}
+	close(in.In)
+	var wg sync.WaitGroup
+	wg.Add(1)
+	p.Process(in, &wg)
+	p.Cleanup()
+	var recs []*engine.Record
+	for r := range out.In {
+		recs = append(recs, r)
+	}
+	return recs
+}
+
+func TestEnricher(t *testing.T) {
+	enricher.RegisterHandler("slow", func() engine.Handler {
+		return &testHandler{delay: func(pid int64) time.Duration { return time.Duration(10-pid) * time.Millisecond }}
+	})
+	enricher.RegisterHandler("stuck", func() engine.Handler {
+		return &testHandler{delay: func(pid int64) time.Duration {
+			if pid%2 == 0 {
+				return time.Hour
+			}
+			return 0
+		}}
+	})
+	enricher.RegisterHandler("failing", func() engine.Handler { return &testHandler{fail: true} })
+
+	_, err := enricher.CreateConfig(map[string]interface{}{})
+	assert.Error(t, err)
+	assert.Error(t, enricher.NewEnricher().Init(map[string]interface{}{"handlers": "unknown"}))
+
+	// records are output in order, annotated by each handler of the chain that completed in time
+	recs := run(t, map[string]interface{}{"handlers": "slow, stuck, failing", "timeout": "50ms", "inflight": "4"}, 10)
+	assert.Len(t, recs, 10)
+	for i, r := range recs {
+		assert.Equal(t, int64(i), r.GetInt(sfgo.PROC_OID_HPID_INT, sfgo.SYSFLOW_SRC))
+		assert.Equal(t, int64(i), r.Ctx.GetEnrichment("slow"))
+		if i%2 == 0 {
+			assert.Nil(t, r.Ctx.GetEnrichment("stuck"))
+		} else {
+			assert.Equal(t, int64(i), r.Ctx.GetEnrichment("stuck"))
+		}
+		assert.Nil(t, r.Ctx.GetEnrichment("failing"))
+		assert.Len(t, r.Ctx.GetEnrichments(), 2-(i+1)%2)
+	}
+}
+
+// lateHandler reads and writes records once released, after the enricher timed out waiting for them.
+type lateHandler struct {
+	release chan struct{}
+	wg      sync.WaitGroup
+}
+
+func (h *lateHandler) Init(confPath string) error { return nil }
+
+func (h *lateHandler) ProcessSync(r *engine.Record) (interface{}, error) { return nil, nil }
+
+func (h *lateHandler) ProcessAsync(r *engine.Record, callback func(o interface{})) error {
+	h.wg.Add(1)
+	go func() {
+		defer h.wg.Done()
+		<-h.release
+		r.Fr.Ints[0][sfgo.PROC_OID_HPID_INT]++
+		r.Ctx.SetEnrichment("late", r.GetInt(sfgo.PROC_OID_HPID_INT, sfgo.SYSFLOW_SRC))
+		callback(r.GetInt(sfgo.PROC_OID_HPID_INT, sfgo.SYSFLOW_SRC))
+	}()
+	return nil
+}
+
+func (h *lateHandler) Cleanup() error { return nil }
+
+func TestLateHandler(t *testing.T) {
+	late := &lateHandler{release: make(chan struct{})}
+	enricher.RegisterHandler("late", func() engine.Handler { return late })
+
+	// handlers that time out only access their copy of the record, while it's processed downstream
+	recs := run(t, map[string]interface{}{"handlers": "late", "timeout": "1ms", "inflight": "4"}, 10)
+	assert.Len(t, recs, 10)
+	close(late.release)
+	for i, r := range recs {
+		r.Fr.Ints[0][sfgo.PROC_OID_HPID_INT] = int64(-i)
+		r.Ctx.SetEnrichment("downstream", i)
+	}
+	late.wg.Wait()
+	for i, r := range recs {
+		assert.Equal(t, int64(-i), r.GetInt(sfgo.PROC_OID_HPID_INT, sfgo.SYSFLOW_SRC))
+		assert.Nil(t, r.Ctx.GetEnrichment("late"))
+		assert.Equal(t, i, r.Ctx.GetEnrichment("downstream"))
+	}
+}
@@ -0,0 +1,81 @@
+//
+// Copyright (C) 2020 IBM Corporation.
+//
+// Authors:
+// Frederico Araujo <frederico.araujo@ibm.com>
+// Teryl Taylor <terylt@ibm.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+package enricher
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"plugin"
+	"sync"
+
+	"github.com/sysflow-telemetry/sf-processor/core/policyengine/engine"
+)
+
+// cHandlerSym is the symbol exported by shared objects to load enrichment handlers dynamically.
+const cHandlerSym string = "Handler"
+
+// HandlerFactory defines a factory type for enrichment handlers.
+type HandlerFactory func() engine.Handler
+
+// handlers is the registry of built-in enrichment handlers, keyed by handler name.
+var handlers = make(map[string]HandlerFactory)
+
+// handlersMutex guards the registry of enrichment handlers.
+var handlersMutex sync.RWMutex
+
+// RegisterHandler registers factory as the factory of enrichment handler name, so that enricher plugins can
+// reference name in their handler chain. Registering an existing handler replaces its factory.
+func RegisterHandler(name string, factory HandlerFactory) {
+	handlersMutex.Lock()
+	defer handlersMutex.Unlock()
+	handlers[name] = factory
+}
+
+// getHandler creates enrichment handler name, from the registered handlers, or from the shared object
+// name.so in directory path, which exports the handler as symbol Handler.
+func getHandler(name string, path string) (engine.Handler, error) {
+	handlersMutex.RLock()
+	factory, ok := handlers[name]
+	handlersMutex.RUnlock()
+	if ok {
+		return factory(), nil
+	}
+	if len(path) == 0 {
+		return nil, fmt.Errorf("enrichment handler '%s' not found in registered handlers, and no attribute 'handlerlibpath' for dynamic library defined", name)
+	}
+	dynPlugin := filepath.Join(path, name+".so")
+	if _, err := os.Stat(dynPlugin); err != nil {
+		return nil, errors.New("error trying load enrichment handler at: " + dynPlugin)
+	}
+	plug, err := plugin.Open(dynPlugin)
+	if err != nil {
+		return nil, err
+	}
+	sym, err := plug.Lookup(cHandlerSym)
+	if err != nil {
+		return nil, err
+	}
+	if hdl, ok := sym.(engine.Handler); ok {
+		return hdl, nil
+	}
+	return nil, fmt.Errorf("unable to dynamically load enrichment handler '%s' from library %s", name, dynPlugin)
+}
@@ -37,6 +37,7 @@ const (
 	JSONSchemaVersionKey   string = "jsonschemaversion"
 	BuildNumberKey         string = "buildnumber"
 	ClusterIDKey           string = "cluster.id"
+	OmitAbsentConfigKey    string = "omitabsent"
 )
 
 // Config defines a configuration object for the exporter.
@@ -52,6 +53,7 @@ type Config struct {
 	JSONSchemaVersion string
 	BuildNumber       string
 	ClusterID         string
+	OmitAbsent        bool
 	FileConfig
 	SyslogConfig
 	ESConfig
@@ -106,6 +108,9 @@ func CreateConfig(conf map[string]interface{}) (c Config, err error) {
 	if v, ok := conf[ClusterIDKey].(string); ok {
 		c.ClusterID = v
 	}
+	if v, ok := conf[OmitAbsentConfigKey].(string); ok && v == "true" {
+		c.OmitAbsent = true
+	}
 
 	// parse specialized configs
 	c.FileConfig, err = CreateFileConfig(c, conf)
@@ -43,10 +43,12 @@ type Event struct {
 
 	Tags string `json:"Tags"`
 
+	Labels string `json:"Labels"`
+
 	Trace string `json:"Trace"`
 }
 
-const EventAvroCRC64Fingerprint = "\f\xa9\xfe\x11\x8aaM\xca"
+const EventAvroCRC64Fingerprint = "\xfb\xdf\xc7\xf3\x1e\xea\x15\x89"
 
 func NewEvent() *Event {
 	return &Event{}
@@ -143,6 +145,10 @@ func writeEvent(r *Event, w io.Writer) error {
 	if err != nil {
 		return err
 	}
+	err = vm.WriteString(r.Labels, w)
+	if err != nil {
+		return err
+	}
 	err = vm.WriteString(r.Trace, w)
 	if err != nil {
 		return err
@@ -155,7 +161,7 @@ func (r *Event) Serialize(w io.Writer) error {
 }
 
 func (r *Event) Schema() string {
-	return "{\"fields\":[{\"name\":\"ts\",\"type\":\"long\"},{\"name\":\"description\",\"type\":\"string\"},{\"name\":\"severity\",\"type\":\"string\"},{\"name\":\"clusterID\",\"type\":\"string\"},{\"name\":\"nodeID\",\"type\":\"string\"},{\"name\":\"nodeIP\",\"type\":\"string\"},{\"name\":\"containerID\",\"type\":\"string\"},{\"name\":\"recordType\",\"type\":\"string\"},{\"name\":\"opFlags\",\"type\":\"string\"},{\"name\":\"pProcCmd\",\"type\":\"string\"},{\"name\":\"pProcPID\",\"type\":\"long\"},{\"name\":\"procCmd\",\"type\":\"string\"},{\"name\":\"procPID\",\"type\":\"long\"},{\"name\":\"Resource\",\"type\":\"string\"},{\"name\":\"Tags\",\"type\":\"string\"},{\"name\":\"Trace\",\"type\":\"string\"}],\"name\":\"event.Event\",\"type\":\"record\"}"
+	return "{\"fields\":[{\"name\":\"ts\",\"type\":\"long\"},{\"name\":\"description\",\"type\":\"string\"},{\"name\":\"severity\",\"type\":\"string\"},{\"name\":\"clusterID\",\"type\":\"string\"},{\"name\":\"nodeID\",\"type\":\"string\"},{\"name\":\"nodeIP\",\"type\":\"string\"},{\"name\":\"containerID\",\"type\":\"string\"},{\"name\":\"recordType\",\"type\":\"string\"},{\"name\":\"opFlags\",\"type\":\"string\"},{\"name\":\"pProcCmd\",\"type\":\"string\"},{\"name\":\"pProcPID\",\"type\":\"long\"},{\"name\":\"procCmd\",\"type\":\"string\"},{\"name\":\"procPID\",\"type\":\"long\"},{\"name\":\"Resource\",\"type\":\"string\"},{\"name\":\"Tags\",\"type\":\"string\"},{\"name\":\"Labels\",\"type\":\"string\"},{\"name\":\"Trace\",\"type\":\"string\"}],\"name\":\"event.Event\",\"type\":\"record\"}"
 }
 
 func (r *Event) SchemaName() string {
@@ -204,6 +210,8 @@ func (r *Event) Get(i int) types.Field {
 	case 14:
 		return &types.String{Target: &r.Tags}
 	case 15:
+		return &types.String{Target: &r.Labels}
+	case 16:
 		return &types.String{Target: &r.Trace}
 	}
 	panic("Unknown field index")
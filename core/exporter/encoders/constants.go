@@ -51,4 +51,14 @@ const (
 	DESC_ATTR         = "desc"
 	PRIORITY_ATTR     = "priority"
 	TAGS_ATTR         = "tags"
+	OUTPUT_ATTR       = "output"
+	SEQUENCE_ATTR     = "sequence"
+	HASHES_ATTR       = "hashes"
+	MD5_ATTR          = "md5"
+	SHA1_ATTR         = "sha1"
+	SHA256_ATTR       = "sha256"
+	SIZE_ATTR         = "size"
+	ENRICHMENTS_ATTR  = "enrichments"
+	BUNDLE_ATTR       = "policybundle"
+	DIGEST_ATTR       = "digest"
 )
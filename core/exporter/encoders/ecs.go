@@ -58,6 +58,9 @@ type ECSRecord struct {
 	Process     JsonData `json:"process"`
 	User        JsonData `json:"user"`
 	Tags        []string `json:"tags,omitempty"`
+	Labels      JsonData `json:"labels,omitempty"`
+	Rule        JsonData `json:"rule,omitempty"`
+	Message     string   `json:"message,omitempty"`
 }
 
 // ECSEncoder implements an ECS encoder for telemetry records.
@@ -114,21 +117,37 @@ func (t *ECSEncoder) encode(rec *engine.Record) *ECSRecord {
 	case sfgo.TyPEStr:
 		ecs.encodeProcessEvent(rec)
 	}
+	ecs.encodeHashes(rec)
+	ecs.encodeLabels(rec)
+	if t.config.OmitAbsent {
+		ecs.omitAbsentParent(rec)
+	}
 
 	// encode tags and policy information
 	rules := rec.Ctx.GetRules()
 	if len(rules) > 0 {
 		reasons := make([]string, 0)
+		outputs := make([]string, 0)
 		tags := make([]string, 0)
 		priority := int(engine.Low)
 		for _, r := range rules {
 			reasons = append(reasons, r.Name)
+			if output := rec.Ctx.GetOutput(r); len(output) > 0 {
+				outputs = append(outputs, output)
+			}
 			tags = append(tags, extracTags(r.Tags)...)
 			priority = utils.Max(priority, int(r.Priority))
 		}
 		ecs.Event[ECS_EVENT_REASON] = strings.Join(reasons, ", ")
 		ecs.Event[ECS_EVENT_SEVERITY] = priority
 		ecs.Tags = tags
+		ecs.Message = strings.Join(outputs, ECS_MESSAGE_SEP)
+	}
+	if b := rec.Ctx.GetBundle(); b != nil {
+		ecs.Rule = JsonData{
+			ECS_RULE_VERSION: b.Version,
+			ECS_RULE_RULESET: b.Digest,
+		}
 	}
 	return ecs
 }
@@ -277,6 +296,47 @@ func (ecs *ECSRecord) encodeProcessEvent(rec *engine.Record) {
 	ecs.Event = encodeEvent(rec, category, eventType, action)
 }
 
+// encodeHashes adds the hashes computed by the hash action to the file of file records,
+// or to the process of process events.
+func (ecs *ECSRecord) encodeHashes(rec *engine.Record) {
+	hs := rec.Ctx.GetHashes()
+	if len(hs.SHA256) == 0 {
+		return
+	}
+	hashes := JsonData{
+		ECS_HASH_MD5:    hs.MD5,
+		ECS_HASH_SHA1:   hs.SHA1,
+		ECS_HASH_SHA256: hs.SHA256,
+	}
+	if ecs.File != nil {
+		ecs.File[ECS_HASH] = hashes
+	} else {
+		ecs.Process[ECS_HASH] = hashes
+	}
+}
+
+// encodeLabels adds the tags attached to the record by tag actions as ECS labels. Tags of the form key:value
+// are added as label key with value value, and other tags as labels with empty values. Values of tags
+// sharing the same key are joined.
+func (ecs *ECSRecord) encodeLabels(rec *engine.Record) {
+	tags := rec.Ctx.GetTags()
+	if len(tags) == 0 {
+		return
+	}
+	ecs.Labels = make(JsonData, len(tags))
+	for _, tag := range tags {
+		kv := strings.SplitN(tag, ECS_LABEL_SEP, 2)
+		var v string
+		if len(kv) == 2 {
+			v = kv[1]
+		}
+		if prev, ok := ecs.Labels[kv[0]]; ok {
+			v = prev.(string) + engine.LISTSEP + v
+		}
+		ecs.Labels[kv[0]] = v
+	}
+}
+
 // encodeContainer creates an ECS container field.
 func encodeContainer(rec *engine.Record) JsonData {
 	var container JsonData
@@ -339,6 +399,21 @@ func encodeProcess(rec *engine.Record) JsonData {
 	return process
 }
 
+// omitAbsentParent removes the parent process from the ECS process field if the record's process has no parent,
+// and the parent's attributes obtained from the process cache if the parent isn't cached.
+func (ecs *ECSRecord) omitAbsentParent(rec *engine.Record) {
+	if !engine.Mapper.MapPresence(engine.SF_PPROC_PID)(rec) {
+		delete(ecs.Process, ECS_PROC_PARENT)
+		return
+	}
+	if !engine.Mapper.MapPresence(engine.SF_PPROC_EXE)(rec) {
+		parent := ecs.Process[ECS_PROC_PARENT].(JsonData)
+		for _, k := range []string{ECS_PROC_EXE, ECS_PROC_ARGS, ECS_PROC_CMDLINE, ECS_PROC_NAME} {
+			delete(parent, k)
+		}
+	}
+}
+
 // encodeEvent creates the central ECS event field and sets the classification attributes
 func encodeEvent(rec *engine.Record, category string, eventType string, action string) JsonData {
 	start := engine.Mapper.MapInt(engine.SF_TS)(rec)
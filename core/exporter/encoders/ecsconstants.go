@@ -25,6 +25,9 @@ const ECS_VERSION = "1.7.0"
 // Agent type
 const ECS_AGENT_TYPE = "SysFlow"
 
+// Message separator
+const ECS_MESSAGE_SEP = "; "
+
 // ECS attributes used in JsonData
 const (
 	ECS_CONTAINER_ID      = "id"
@@ -55,6 +58,11 @@ const (
 	ECS_FILE_TARGET = "target_path"
 	ECS_FILE_TYPE   = "type"
 
+	ECS_HASH        = "hash"
+	ECS_HASH_MD5    = "md5"
+	ECS_HASH_SHA1   = "sha1"
+	ECS_HASH_SHA256 = "sha256"
+
 	ECS_GROUP      = "group"
 	ECS_GROUP_ID   = "id"
 	ECS_GROUP_NAME = "name"
@@ -93,7 +101,11 @@ const (
 	ECS_THREAT_FRAMEWORK    = "framework"
 	ECS_THREAT_TECHNIQUE_ID = "id"
 
-	ECS_TAGS = "tags"
+	ECS_RULE_VERSION = "version"
+	ECS_RULE_RULESET = "ruleset"
+
+	ECS_TAGS      = "tags"
+	ECS_LABEL_SEP = ":"
 )
 
 // ECS kind values
@@ -20,8 +20,9 @@
 package encoders
 
 import (
+	"encoding/json"
 	"path/filepath"
-	"reflect"
+	"sort"
 	"unicode/utf8"
 
 	"github.com/mailru/easyjson/jwriter"
@@ -73,15 +74,141 @@ func (t *JSONEncoder) encode(rec *engine.Record) (commons.EncodedData, error) {
 	t.writer.RawString(VERSION_STR)
 	t.writer.RawString(t.config.JSONSchemaVersion)
 	t.writer.RawByte(COMMA)
+	t.writeRecord(rec)
+	if hs := rec.Ctx.GetHashes(); len(hs.SHA256) > 0 {
+		t.writer.RawString(HASHES)
+		t.writer.String(hs.MD5)
+		t.writer.RawString(SHA1)
+		t.writer.String(hs.SHA1)
+		t.writer.RawString(SHA256)
+		t.writer.String(hs.SHA256)
+		t.writer.RawString(SIZE)
+		t.writer.Int(hs.Size)
+		t.writer.RawByte(END_SQUIGGLE)
+	}
+	if es := rec.Ctx.GetEnrichments(); len(es) > 0 {
+		t.writeEnrichments(es)
+	}
+	if tags := rec.Ctx.GetTags(); len(tags) > 0 {
+		t.writer.RawString(TAGS)
+		for i, tag := range tags {
+			if i > 0 {
+				t.writer.RawByte(COMMA)
+			}
+			t.writer.String(tag)
+		}
+		t.writer.RawByte(END_SQUARE)
+	}
+	rules := rec.Ctx.GetRules()
+	numRules := len(rules)
+	if numRules > 0 {
+		t.writer.RawString(POLICIES)
+		for id, r := range rules {
+			t.writer.RawString(ID_TAG)
+			t.writer.String(r.Name)
+			t.writer.RawString(DESC)
+			t.writer.String(r.Desc)
+			t.writer.RawString(PRIORITY)
+			t.writer.Int64(int64(r.Priority))
+			if output := rec.Ctx.GetOutput(r); len(output) > 0 {
+				t.writer.RawString(OUTPUT)
+				t.writer.String(output)
+			}
+			numTags := len(r.Tags)
+			currentTag := 0
+			if numTags > 0 {
+				t.writer.RawString(TAGS)
+				for _, tag := range r.Tags {
+					switch tag := tag.(type) {
+					case []string:
+						tags := tag
+						numTags := numTags + len(tags) - 1
+						for _, s := range tags {
+							t.writer.String(s)
+							if currentTag < (numTags - 1) {
+								t.writer.RawByte(COMMA)
+							}
+							currentTag += 1
+						}
+					default:
+						t.writer.String(tag.(string))
+						if currentTag < (numTags - 1) {
+							t.writer.RawByte(COMMA)
+						}
+						currentTag += 1
+					}
+				}
+				t.writer.RawByte(END_SQUARE)
+			}
+			if seq := rec.Ctx.GetSequence(r); len(seq) > 0 {
+				t.writer.RawString(SEQUENCE)
+				for i, sr := range seq {
+					t.writer.RawByte(BEGIN_SQUIGGLE)
+					t.writeRecord(sr)
+					t.writer.RawByte(END_SQUIGGLE)
+					if i < (len(seq) - 1) {
+						t.writer.RawByte(COMMA)
+					}
+				}
+				t.writer.RawByte(END_SQUARE)
+			}
+			t.writer.RawByte(END_SQUIGGLE)
+			if id < (numRules - 1) {
+				t.writer.RawByte(COMMA)
+			}
+		}
+		t.writer.RawByte(END_SQUARE)
+	}
+	if b := rec.Ctx.GetBundle(); b != nil {
+		t.writer.RawString(BUNDLE)
+		t.writer.String(b.Version)
+		t.writer.RawString(DIGEST)
+		t.writer.String(b.Digest)
+		t.writer.RawByte(END_SQUIGGLE)
+	}
+	t.writer.RawByte(END_SQUIGGLE)
+
+	// BuildBytes returns writer data as a single byte slice. It tries to reuse buf.
+	//return t.writer.BuildBytes(t.buf)
+	return t.writer.BuildBytes()
+}
+
+// writeEnrichments writes the annotations of enrichment handlers, sorted by handler name.
+// Annotations that cannot be marshaled to JSON are written as null.
+func (t *JSONEncoder) writeEnrichments(es map[string]interface{}) {
+	names := make([]string, 0, len(es))
+	for name := range es {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	t.writer.RawString(ENRICHMENTS)
+	for i, name := range names {
+		if i > 0 {
+			t.writer.RawByte(COMMA)
+		}
+		t.writer.String(name)
+		t.writer.RawByte(COLON)
+		if b, err := json.Marshal(es[name]); err == nil {
+			t.writer.Raw(b, nil)
+		} else {
+			t.writer.RawString("null")
+		}
+	}
+	t.writer.RawByte(END_SQUIGGLE)
+}
+
+// writeRecord writes the attributes of a telemetry record, grouped by section.
+func (t *JSONEncoder) writeRecord(rec *engine.Record) {
 	state := BEGIN_STATE
-	pprocID := engine.Mapper.MapInt(engine.SF_PPROC_PID)(rec)
 	sftype := engine.Mapper.MapStr(engine.SF_TYPE)(rec)
-	pprocExists := !reflect.ValueOf(pprocID).IsZero()
-	ct := engine.Mapper.MapStr(engine.SF_CONTAINER_ID)(rec)
-	ctExists := !reflect.ValueOf(ct).IsZero()
+	pprocExists := engine.Mapper.MapPresence(engine.SF_PPROC_PID)(rec)
+	ctExists := engine.Mapper.MapPresence(engine.SF_CONTAINER_ID)(rec)
 	existed := true
 
 	for _, fv := range t.fieldCache {
+		if t.config.OmitAbsent && fv.Entry.Present != nil && !fv.Entry.Present(rec) {
+			continue
+		}
 		numFields := len(fv.FieldSects)
 		if numFields == 2 {
 			t.writeAttribute(fv, 1, rec)
@@ -216,60 +343,6 @@ func (t *JSONEncoder) encode(rec *engine.Record) (commons.EncodedData, error) {
 		}
 	}
 	t.writer.RawByte(END_SQUIGGLE)
-	/* // Need to add hash support
-	hashset := rec.Ctx.GetHashes()
-	if !reflect.ValueOf(hashset.MD5).IsZero() {
-		r.Hashes = &hashset
-	} */
-	rules := rec.Ctx.GetRules()
-	numRules := len(rules)
-	if numRules > 0 {
-		t.writer.RawString(POLICIES)
-		for id, r := range rules {
-			t.writer.RawString(ID_TAG)
-			t.writer.String(r.Name)
-			t.writer.RawString(DESC)
-			t.writer.String(r.Desc)
-			t.writer.RawString(PRIORITY)
-			t.writer.Int64(int64(r.Priority))
-			numTags := len(r.Tags)
-			currentTag := 0
-			if numTags > 0 {
-				t.writer.RawString(TAGS)
-				for _, tag := range r.Tags {
-					switch tag := tag.(type) {
-					case []string:
-						tags := tag
-						numTags := numTags + len(tags) - 1
-						for _, s := range tags {
-							t.writer.String(s)
-							if currentTag < (numTags - 1) {
-								t.writer.RawByte(COMMA)
-							}
-							currentTag += 1
-						}
-					default:
-						t.writer.String(tag.(string))
-						if currentTag < (numTags - 1) {
-							t.writer.RawByte(COMMA)
-						}
-						currentTag += 1
-					}
-				}
-				t.writer.RawByte(END_SQUARE)
-			}
-			t.writer.RawByte(END_SQUIGGLE)
-			if id < (numRules - 1) {
-				t.writer.RawByte(COMMA)
-			}
-		}
-		t.writer.RawByte(END_SQUARE)
-	}
-	t.writer.RawByte(END_SQUIGGLE)
-
-	// BuildBytes returns writer data as a single byte slice. It tries to reuse buf.
-	//return t.writer.BuildBytes(t.buf)
-	return t.writer.BuildBytes()
 }
 
 func (t *JSONEncoder) writeAttribute(fv *engine.FieldValue, fieldId int, rec *engine.Record) {
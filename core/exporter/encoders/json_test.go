@@ -0,0 +1,63 @@
+package encoders_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/sysflow-telemetry/sf-apis/go/sfgo"
+	"github.com/sysflow-telemetry/sf-processor/core/cache"
+	"github.com/sysflow-telemetry/sf-processor/core/exporter/commons"
+	"github.com/sysflow-telemetry/sf-processor/core/exporter/encoders"
+	"github.com/sysflow-telemetry/sf-processor/core/policyengine/engine"
+)
+
+func encodeJSON(t *testing.T, config commons.Config, r *engine.Record) map[string]interface{} {
+	data, err := encoders.NewJSONEncoder(config).Encode([]*engine.Record{r})
+	assert.NoError(t, err)
+	var m map[string]interface{}
+	assert.NoError(t, json.Unmarshal(data[0].([]byte), &m))
+	return m
+}
+
+func TestJSONOmitAbsent(t *testing.T) {
+	fr := sfgo.FlatRecord{
+		Sources: []sfgo.Source{sfgo.SYSFLOW_SRC},
+		Ints:    [][]int64{make([]int64, sfgo.INT_ARRAY_SIZE)},
+		Strs:    [][]string{make([]string, sfgo.STR_ARRAY_SIZE)},
+	}
+	fr.Ints[0][sfgo.SF_REC_TYPE] = sfgo.PROC_EVT
+	fr.Ints[0][sfgo.PROC_OID_HPID_INT] = 42
+	fr.Strs[0][sfgo.PROC_EXE_STR] = "/bin/sh"
+	r := engine.NewRecord(fr, cache.GetInstance())
+
+	m := encodeJSON(t, commons.Config{JSONSchemaVersion: "4"}, r)
+	assert.Contains(t, m, "endts")
+	assert.Contains(t, m["proc"], "apid")
+
+	m = encodeJSON(t, commons.Config{JSONSchemaVersion: "4", OmitAbsent: true}, r)
+	assert.NotContains(t, m, "endts")
+	assert.Contains(t, m, "ret")
+	assert.NotContains(t, m, "container")
+	assert.NotContains(t, m["proc"], "apid")
+	assert.Equal(t, float64(0), m["proc"].(map[string]interface{})["uid"])
+	assert.Equal(t, "/bin/sh", m["proc"].(map[string]interface{})["exe"])
+}
+
+func TestJSONPolicyBundle(t *testing.T) {
+	fr := sfgo.FlatRecord{
+		Sources: []sfgo.Source{sfgo.SYSFLOW_SRC},
+		Ints:    [][]int64{make([]int64, sfgo.INT_ARRAY_SIZE)},
+		Strs:    [][]string{make([]string, sfgo.STR_ARRAY_SIZE)},
+	}
+	fr.Ints[0][sfgo.SF_REC_TYPE] = sfgo.PROC_EVT
+	r := engine.NewRecord(fr, cache.GetInstance())
+
+	m := encodeJSON(t, commons.Config{JSONSchemaVersion: "4"}, r)
+	assert.NotContains(t, m, "policybundle")
+
+	r.Ctx.AddRule(engine.Rule{Name: "r1"})
+	r.Ctx.SetBundle(engine.PolicyBundle{Version: "1.2.0", Digest: "abc123"})
+	m = encodeJSON(t, commons.Config{JSONSchemaVersion: "4"}, r)
+	assert.Equal(t, map[string]interface{}{"version": "1.2.0", "digest": "abc123"}, m["policybundle"])
+}
@@ -32,12 +32,14 @@ const (
 	QUOTE_COMMA        = "\","
 	OBSERVATIONS       = "\"" + OBSERVATIONS_ATTR + "\":["
 	COMMA              = ','
+	COLON              = ':'
 	END_SQ_SQUIGGLE    = "]}"
 	DOUBLE_QUOTE       = '"'
 	QUOTE_COLON        = "\":"
 	QUOTE_COLON_OSUIG  = "\":{"
 	END_SQUIGGLE_COMMA = "},"
 	END_SQUIGGLE       = '}'
+	BEGIN_SQUIGGLE     = '{'
 	END_SQUARE         = ']'
 	BEGIN_SQUARE       = '['
 	SPACE              = ' '
@@ -46,6 +48,15 @@ const (
 	DESC               = ",\"" + DESC_ATTR + "\":"
 	PRIORITY           = ",\"" + PRIORITY_ATTR + "\":"
 	TAGS               = ",\"" + TAGS_ATTR + "\":["
+	OUTPUT             = ",\"" + OUTPUT_ATTR + "\":"
+	SEQUENCE           = ",\"" + SEQUENCE_ATTR + "\":["
+	HASHES             = ",\"" + HASHES_ATTR + "\":{\"" + MD5_ATTR + "\":"
+	SHA1               = ",\"" + SHA1_ATTR + "\":"
+	SHA256             = ",\"" + SHA256_ATTR + "\":"
+	SIZE               = ",\"" + SIZE_ATTR + "\":"
+	ENRICHMENTS        = ",\"" + ENRICHMENTS_ATTR + "\":{"
+	BUNDLE             = ",\"" + BUNDLE_ATTR + "\":{\"" + VERSION_ATTR + "\":"
+	DIGEST             = ",\"" + DIGEST_ATTR + "\":"
 	PERIOD             = '.'
 	EMPTY_STRING	   = "\"\""
 )
@@ -353,6 +353,9 @@ func (oe *OccurrenceEncoder) createOccurrence(e *Event, ep *EventPool) *Occurren
 	oc.Severity = severity
 	polStr := fmt.Sprintf(policiesStrFmt, strings.Join(rnames, listSep))
 	tagsStr := fmt.Sprintf(tagsStrFmt, strings.Join(tags, listSep))
+	if labels := e.Record.Ctx.GetTags(); len(labels) > 0 {
+		tagsStr += fmt.Sprintf(labelsStrFmt, strings.Join(labels, listSep))
+	}
 	var detStr string
 	switch e.Record.GetInt(sfgo.SF_REC_TYPE, sfgo.SYSFLOW_SRC) {
 	case sfgo.PROC_EVT:
@@ -376,6 +379,11 @@ func (oe *OccurrenceEncoder) createOccurrence(e *Event, ep *EventPool) *Occurren
 	encDetStr := strings.ReplaceAll(detStr, "/", fwdSlash)
 	oc.ShortDescr = encDetStr
 	oc.LongDescr = fmt.Sprintf(detailsStrFmt, encDetStr, polStr, tagsStr)
+	if outputs := oe.summarizeOutputs(e.Record); len(outputs) > 0 {
+		alertsStr := fmt.Sprintf(alertsStrFmt, strings.ReplaceAll(strings.Join(outputs, alertsSep), "/", fwdSlash))
+		oc.ShortDescr = strings.ReplaceAll(outputs[0], "/", fwdSlash)
+		oc.LongDescr = fmt.Sprintf(alertsDetailsStrFmt, alertsStr, encDetStr, polStr, tagsStr)
+	}
 	oc.AlertQuery = fmt.Sprintf(sqlQueryStrFmt, oe.config.FindingsS3Region, oe.config.FindingsS3Bucket,
 		e.getExportFilePath(oe.config.ClusterID), oe.config.FindingsS3Region, oe.config.FindingsS3Bucket)
 	return oc
@@ -398,6 +406,16 @@ func (oe *OccurrenceEncoder) summarizePolicy(r *engine.Record) (rnames []string,
 	return
 }
 
+// summarizeOutputs extracts the rendered outputs of rules applied to a record.
+func (oe *OccurrenceEncoder) summarizeOutputs(r *engine.Record) (outputs []string) {
+	for _, rule := range r.Ctx.GetRules() {
+		if output := r.Ctx.GetOutput(rule); len(output) > 0 {
+			outputs = append(outputs, output)
+		}
+	}
+	return
+}
+
 // encodeEvent maps a record into an event that can be associated with an occurrence.
 func (oe *OccurrenceEncoder) encodeEvent(r *engine.Record) *Event {
 	rnames, tags, severity := oe.summarizePolicy(r)
@@ -417,6 +435,7 @@ func (oe *OccurrenceEncoder) encodeEvent(r *engine.Record) *Event {
 	e.ProcPID = engine.Mapper.MapInt(engine.SF_PROC_PID)(r)
 	e.Resource = oe.formatResource(r)
 	e.Tags = strings.Join(tags, listSep)
+	e.Labels = strings.Join(r.Ctx.GetTags(), listSep)
 	e.Trace = engine.Mapper.MapStr(engine.SF_TRACENAME)(r)
 	return e
 }
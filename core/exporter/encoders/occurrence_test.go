@@ -24,6 +24,7 @@ func TestEventSerialization(t *testing.T) {
 		e := &encoders.Event{Event: event.NewEvent()}
 		e.Ts = time.Now().Unix()
 		e.Description = fmt.Sprintf("event %d", i)
+		e.Labels = fmt.Sprintf("team:%d", i)
 		err := e.Serialize(fw)
 		assert.NoError(t, err)
 	}
@@ -39,6 +40,7 @@ func TestEventSerialization(t *testing.T) {
 		}
 	}
 	assert.Equal(t, count, len(events))
+	assert.Equal(t, "team:1", events[1].Labels)
 	fr.Close()
 	os.Remove(path)
 }
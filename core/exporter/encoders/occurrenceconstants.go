@@ -31,13 +31,18 @@ const (
 
 	policiesStrFmt = "<b>Policies</b><br>%s"
 	tagsStrFmt     = "<b>Tags</b><br>%s"
+	labelsStrFmt   = "<br><br><b>Labels</b><br>%s"
 	detailsStrFmt  = "%s<br><br>%s<br><br>%s"
 	noteIDStrFmt   = "%s-%d"
 	connStrFmt     = "%s:%d-%s:%d"
 
+	alertsStrFmt        = "<b>Alerts</b><br>%s"
+	alertsDetailsStrFmt = "%s<br><br>%s<br><br>%s<br><br>%s"
+
 	sqlQueryStrFmt = "SELECT * FROM cos://%s/%s/%s STORED AS AVRO LIMIT 500 INTO cos://%s/%s/sql-query"
 
-	listSep = ","
+	listSep   = ","
+	alertsSep = "<br>"
 
 	hostFileName = "host"
 )
@@ -33,6 +33,19 @@ const (
 	channelName string = "flattenerchan"
 )
 
+// PresenceIdx is the index of the entity presence bitmap in the SysFlow integer attributes of flat records.
+// The bitmap follows the SysFlow attributes, so that the layout of the record is unchanged.
+const PresenceIdx sfgo.Attribute = sfgo.INT_ARRAY_SIZE
+
+// Presence bits of the entities of flat records.
+const (
+	ProcPresent int64 = 1 << iota
+	PProcPresent
+	ContPresent
+	FilePresent
+	SecFilePresent
+)
+
 // FlatChannel defines a multi-source flat channel
 type FlatChannel struct {
 	In chan *sfgo.FlatRecord
@@ -164,6 +177,7 @@ func (s *Flattener) HandleFileEvt(sf *sfgo.SysFlow, hdr *sfgo.SFHeader, cont *sf
 		fr.Ints[sfgo.SYSFLOW_IDX][sfgo.SEC_FILE_TS_INT] = file2.Ts
 		fr.Ints[sfgo.SYSFLOW_IDX][sfgo.SEC_FILE_RESTYPE_INT] = int64(file2.Restype)
 		fr.Strs[sfgo.SYSFLOW_IDX][sfgo.SEC_FILE_OID_STR] = getOIDStr(file2.Oid[:])
+		fr.Ints[sfgo.SYSFLOW_IDX][PresenceIdx] |= SecFilePresent
 		fr.Strs[sfgo.SYSFLOW_IDX][sfgo.SEC_FILE_PATH_STR] = strings.TrimSpace(file2.Path)
 		if file2.ContainerId != nil && file2.ContainerId.UnionType == sfgo.UnionNullStringTypeEnumString {
 			fr.Strs[sfgo.SYSFLOW_IDX][sfgo.SEC_FILE_CONTAINERID_STRING_STR] = file2.ContainerId.String
@@ -226,6 +240,7 @@ func (s *Flattener) fillEntities(hdr *sfgo.SFHeader, cont *sfgo.Container, proc
 		fr.Strs[sfgo.SYSFLOW_IDX][sfgo.SFHE_IP_STR] = sfgo.Zeros.String
 	}
 	if cont != nil {
+		fr.Ints[sfgo.SYSFLOW_IDX][PresenceIdx] |= ContPresent
 		fr.Strs[sfgo.SYSFLOW_IDX][sfgo.CONT_ID_STR] = cont.Id
 		fr.Strs[sfgo.SYSFLOW_IDX][sfgo.CONT_NAME_STR] = strings.TrimSpace(cont.Name)
 		fr.Strs[sfgo.SYSFLOW_IDX][sfgo.CONT_IMAGE_STR] = strings.TrimSpace(cont.Image)
@@ -246,10 +261,12 @@ func (s *Flattener) fillEntities(hdr *sfgo.SFHeader, cont *sfgo.Container, proc
 
 	}
 	if proc != nil {
+		fr.Ints[sfgo.SYSFLOW_IDX][PresenceIdx] |= ProcPresent
 		fr.Ints[sfgo.SYSFLOW_IDX][sfgo.PROC_STATE_INT] = int64(proc.State)
 		fr.Ints[sfgo.SYSFLOW_IDX][sfgo.PROC_OID_CREATETS_INT] = int64(proc.Oid.CreateTS)
 		fr.Ints[sfgo.SYSFLOW_IDX][sfgo.PROC_OID_HPID_INT] = int64(proc.Oid.Hpid)
 		if proc.Poid != nil && proc.Poid.UnionType == sfgo.UnionNullOIDTypeEnumOID {
+			fr.Ints[sfgo.SYSFLOW_IDX][PresenceIdx] |= PProcPresent
 			fr.Ints[sfgo.SYSFLOW_IDX][sfgo.PROC_POID_CREATETS_INT] = proc.Poid.OID.CreateTS
 			fr.Ints[sfgo.SYSFLOW_IDX][sfgo.PROC_POID_HPID_INT] = proc.Poid.OID.Hpid
 		} else {
@@ -297,6 +314,7 @@ func (s *Flattener) fillEntities(hdr *sfgo.SFHeader, cont *sfgo.Container, proc
 		fr.Strs[sfgo.SYSFLOW_IDX][sfgo.PROC_CONTAINERID_STRING_STR] = sfgo.Zeros.String
 	}
 	if file != nil {
+		fr.Ints[sfgo.SYSFLOW_IDX][PresenceIdx] |= FilePresent
 		fr.Ints[sfgo.SYSFLOW_IDX][sfgo.FILE_STATE_INT] = int64(file.State)
 		fr.Ints[sfgo.SYSFLOW_IDX][sfgo.FILE_TS_INT] = file.Ts
 		fr.Ints[sfgo.SYSFLOW_IDX][sfgo.FILE_RESTYPE_INT] = int64(file.Restype)
@@ -328,7 +346,7 @@ func newFlatRecord() *sfgo.FlatRecord {
 	fr.Strs = make([][]string, 1)
 	fr.Sources[sfgo.SYSFLOW_IDX] = sfgo.SYSFLOW_SRC
 
-	fr.Ints[sfgo.SYSFLOW_IDX] = make([]int64, sfgo.INT_ARRAY_SIZE)
+	fr.Ints[sfgo.SYSFLOW_IDX] = make([]int64, PresenceIdx+1)
 	fr.Strs[sfgo.SYSFLOW_IDX] = make([]string, sfgo.STR_ARRAY_SIZE)
 	return fr
 }
@@ -44,7 +44,10 @@ require (
 	github.com/steakknife/hamming v0.0.0-20180906055917-c99c65617cd3 // indirect
 	github.com/stretchr/testify v1.7.0
 	github.com/sysflow-telemetry/sf-apis/go v0.0.0-20210611191016-bbdbd17a2eaf
+	golang.org/x/sys v0.0.0-20210315160823-c6e025ad8005
+	google.golang.org/grpc v1.21.0
 	gopkg.in/linkedin/goavro.v1 v1.0.5 // indirect
+	gopkg.in/yaml.v3 v3.0.0-20210107192922-496545a6307b
 )
 
 //replace github.com/sysflow-telemetry/sf-apis/go => ../../sf-apis/go
@@ -0,0 +1,164 @@
+//
+// Copyright (C) 2020 IBM Corporation.
+//
+// Authors:
+// Frederico Araujo <frederico.araujo@ibm.com>
+// Teryl Taylor <terylt@ibm.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+package actions
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sysflow-telemetry/sf-apis/go/logger"
+	"github.com/sysflow-telemetry/sf-processor/core/exporter/commons"
+	"github.com/sysflow-telemetry/sf-processor/core/exporter/encoders"
+	"github.com/sysflow-telemetry/sf-processor/core/policyengine/engine"
+)
+
+// Exec is the name of the action running an external command on rule matches.
+const Exec engine.Action = "exec"
+
+// ExecActuator runs a configured command for each record matching a rule, with the JSON alert of the
+// record on its standard input. Commands run in the background, and are not run when the concurrency
+// or rate limits are reached.
+type ExecActuator struct {
+	config  engine.ExecConfig
+	mutex   sync.Mutex
+	encoder encoders.Encoder
+	slots   chan struct{}
+	limiter *rateLimiter
+}
+
+// NewExecActuator creates a new exec actuator.
+func NewExecActuator(conf engine.Config) (engine.Actuator, error) {
+	if len(conf.ExecCommand) == 0 {
+		return nil, errors.New("Configuration tag 'exec.command' missing from policy engine plugin settings")
+	}
+	return &ExecActuator{
+		config:  conf.ExecConfig,
+		encoder: encoders.NewJSONEncoder(commons.Config{JSONSchemaVersion: conf.JSONSchemaVersion, EventBuffer: 1}),
+		slots:   make(chan struct{}, conf.ExecConcurrency),
+		limiter: newRateLimiter(conf.ExecRateLimit),
+	}, nil
+}
+
+// Handle starts the command for record r, which matches rule.
+func (s *ExecActuator) Handle(rule engine.Rule, r *engine.Record) error {
+	select {
+	case s.slots <- struct{}{}:
+	default:
+		return fmt.Errorf("concurrency limit of %d commands reached, command not run", cap(s.slots))
+	}
+	if !s.limiter.allow() {
+		<-s.slots
+		return fmt.Errorf("rate limit of %g commands per second reached, command not run", s.limiter.rate)
+	}
+	alert, err := s.encode(rule, r)
+	if err != nil {
+		<-s.slots
+		return err
+	}
+	go func() {
+		defer func() { <-s.slots }()
+		s.run(rule, alert)
+	}()
+	return nil
+}
+
+// encode encodes the JSON alert of record r for rule. The alert only reports rule, even if other rules matched r.
+func (s *ExecActuator) encode(rule engine.Rule, r *engine.Record) ([]byte, error) {
+	ar := engine.NewRecord(r.Fr, r.Cr)
+	ar.Ctx.AddRule(rule)
+	if output := r.Ctx.GetOutput(rule); len(output) > 0 {
+		ar.Ctx.SetOutput(rule, output)
+	}
+	if seq := r.Ctx.GetSequence(rule); len(seq) > 0 {
+		ar.Ctx.SetSequence(rule.Name, seq)
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	data, err := s.encoder.Encode([]*engine.Record{ar})
+	if err != nil {
+		return nil, err
+	}
+	return data[0].([]byte), nil
+}
+
+// run runs the command with alert on its standard input, until it exits or times out.
+// The output of the command is discarded.
+func (s *ExecActuator) run(rule engine.Rule, alert []byte) {
+	ctx, cancel := context.WithTimeout(context.Background(), s.config.ExecTimeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, s.config.ExecCommand[0], s.config.ExecCommand[1:]...)
+	cmd.Stdin = bytes.NewReader(alert)
+	err := cmd.Run()
+	command := strings.Join(s.config.ExecCommand, " ")
+	if ctx.Err() == context.DeadlineExceeded {
+		logger.Warn.Printf("Command %s for rule %s timed out after %v\n", command, rule.Name, s.config.ExecTimeout)
+	} else if err != nil {
+		logger.Error.Printf("Command %s for rule %s failed: %v\n", command, rule.Name, err)
+	} else {
+		logger.Trace.Printf("Command %s for rule %s completed\n", command, rule.Name)
+	}
+}
+
+// rateLimiter is a token bucket limiting the rate of commands, with a burst of one second of commands.
+type rateLimiter struct {
+	mutex  sync.Mutex
+	rate   float64
+	tokens float64
+	last   time.Time
+}
+
+// newRateLimiter creates a new rate limiter for rate commands per second. A rate of 0 disables rate limiting.
+func newRateLimiter(rate float64) *rateLimiter {
+	return &rateLimiter{rate: rate, tokens: burst(rate), last: time.Now()}
+}
+
+// burst returns the maximum number of commands started at once for rate.
+func burst(rate float64) float64 {
+	if rate < 1 {
+		return 1
+	}
+	return rate
+}
+
+// allow checks whether a command can be started, and consumes a token if so.
+func (l *rateLimiter) allow() bool {
+	if l.rate == 0 {
+		return true
+	}
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	now := time.Now()
+	l.tokens += now.Sub(l.last).Seconds() * l.rate
+	if b := burst(l.rate); l.tokens > b {
+		l.tokens = b
+	}
+	l.last = now
+	if l.tokens < 1 {
+		return false
+	}
+	l.tokens--
+	return true
+}
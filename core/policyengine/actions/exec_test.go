@@ -0,0 +1,112 @@
+//
+// Copyright (C) 2020 IBM Corporation.
+//
+// Authors:
+// Frederico Araujo <frederico.araujo@ibm.com>
+// Teryl Taylor <terylt@ibm.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+package actions_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/sysflow-telemetry/sf-apis/go/logger"
+	"github.com/sysflow-telemetry/sf-apis/go/sfgo"
+	"github.com/sysflow-telemetry/sf-processor/core/cache"
+	"github.com/sysflow-telemetry/sf-processor/core/policyengine/actions"
+	"github.com/sysflow-telemetry/sf-processor/core/policyengine/engine"
+)
+
+func TestMain(m *testing.M) {
+	logger.InitLoggers(logger.TRACE)
+	os.Exit(m.Run())
+}
+
+func newProcRecord(exe string) *engine.Record {
+	fr := sfgo.FlatRecord{
+		Sources: []sfgo.Source{sfgo.SYSFLOW_SRC},
+		Ints:    [][]int64{make([]int64, sfgo.INT_ARRAY_SIZE)},
+		Strs:    [][]string{make([]string, sfgo.STR_ARRAY_SIZE)},
+	}
+	fr.Strs[0][sfgo.PROC_EXE_STR] = exe
+	return engine.NewRecord(fr, cache.GetInstance())
+}
+
+func TestExec(t *testing.T) {
+	dir, err := ioutil.TempDir("", "exec")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	script := filepath.Join(dir, "hook.sh")
+	assert.NoError(t, ioutil.WriteFile(script, []byte("#!/bin/sh\ncat > \"$1/$$.tmp\" && mv \"$1/$$.tmp\" \"$1/$$.json\"\n"), 0755))
+	policy := filepath.Join(dir, "policy.yaml")
+	assert.NoError(t, ioutil.WriteFile(policy, []byte("- rule: Sh\n  desc: sh\n  condition: sf.proc.exe = /bin/sh\n  action: [exec]\n  priority: high\n"), 0644))
+
+	_, err = actions.NewExecActuator(engine.Config{})
+	assert.Error(t, err)
+
+	engine.RegisterAction(actions.Exec, actions.NewExecActuator)
+	conf, err := engine.CreateConfig(map[string]interface{}{
+		"policies":          policy,
+		"jsonschemaversion": "4",
+		"exec.command":      script + " " + dir,
+		"exec.concurrency":  "1",
+		"exec.ratelimit":    "0",
+	})
+	assert.NoError(t, err)
+	pi := engine.NewPolicyInterpreter(conf)
+	assert.NoError(t, pi.Compile(policy))
+
+	// the second command is not run while the first one holds the only concurrency slot
+	pi.Process(true, false, newProcRecord("/bin/sh"))
+	pi.Process(true, false, newProcRecord("/bin/sh"))
+	var files []string
+	for i := 0; i < 100 && len(files) == 0; i++ {
+		time.Sleep(50 * time.Millisecond)
+		files, _ = filepath.Glob(filepath.Join(dir, "*.json"))
+	}
+	assert.Equal(t, 1, len(files))
+	data, err := ioutil.ReadFile(files[0])
+	assert.NoError(t, err)
+	var alert map[string]interface{}
+	assert.NoError(t, json.Unmarshal(data, &alert))
+	assert.Equal(t, "/bin/sh", alert["proc"].(map[string]interface{})["exe"])
+	policies := alert["policies"].([]interface{})
+	assert.Equal(t, 1, len(policies))
+	assert.Equal(t, "Sh", policies[0].(map[string]interface{})["id"])
+}
+
+func TestExecRateLimit(t *testing.T) {
+	conf, err := engine.CreateConfig(map[string]interface{}{
+		"policies":         ".",
+		"exec.command":     "true",
+		"exec.concurrency": "10",
+		"exec.ratelimit":   "2",
+	})
+	assert.NoError(t, err)
+	act, err := actions.NewExecActuator(conf)
+	assert.NoError(t, err)
+	rule := engine.Rule{Name: "R", Actions: []engine.Action{actions.Exec}}
+	assert.NoError(t, act.Handle(rule, newProcRecord("/bin/sh")))
+	assert.NoError(t, act.Handle(rule, newProcRecord("/bin/sh")))
+	assert.Error(t, act.Handle(rule, newProcRecord("/bin/sh")))
+	time.Sleep(600 * time.Millisecond)
+	assert.NoError(t, act.Handle(rule, newProcRecord("/bin/sh")))
+}
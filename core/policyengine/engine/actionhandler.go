@@ -19,32 +19,203 @@
 //
 package engine
 
+import (
+	"fmt"
+	"sync"
+
+	"github.com/sysflow-telemetry/sf-apis/go/logger"
+)
+
+// Actuator defines an interface for rule actions, which are run on the records matching the rules that define them.
+// Actuators are called by the goroutines evaluating records, so they must be safe for concurrent use and should not block.
+type Actuator interface {
+	Handle(rule Rule, r *Record) error
+}
+
+// AsyncActuator defines an interface for actuators that complete asynchronously, such as actions doing I/O.
+// HandleAsync must call done once the action completes, and should not block. Records are output once all their
+// asynchronous actions complete, in the order in which they were evaluated when evaluated with ProcessOrdered.
+type AsyncActuator interface {
+	Actuator
+	HandleAsync(rule Rule, r *Record, done func())
+}
+
+// ActuatorFactory defines a factory type for actuators.
+type ActuatorFactory func(conf Config) (Actuator, error)
+
+// nopActuator is the actuator of actions that only require the rule to be recorded in the record context.
+type nopActuator struct{}
+
+// Handle handles the action on record r.
+func (nopActuator) Handle(rule Rule, r *Record) error {
+	return nil
+}
+
+// newNopActuator creates a new no-op actuator.
+func newNopActuator(conf Config) (Actuator, error) {
+	return nopActuator{}, nil
+}
+
+// actions is the registry of action factories, keyed by action name.
+var actions = map[Action]ActuatorFactory{
+	Alert: newNopActuator,
+	Tag:   newTagActuator,
+	Hash:  newHashActuator,
+}
+
+// actionsMutex guards the registry of action factories.
+var actionsMutex sync.RWMutex
+
+// RegisterAction registers factory as the actuator factory of action a, so that policies can reference a
+// in rule actions. Actions must be registered before the policies using them are compiled.
+// Registering an existing action replaces its factory.
+func RegisterAction(a Action, factory ActuatorFactory) {
+	actionsMutex.Lock()
+	defer actionsMutex.Unlock()
+	actions[a] = factory
+}
+
+// IsRegisteredAction checks whether action a is registered.
+func IsRegisteredAction(a Action) bool {
+	actionsMutex.RLock()
+	defer actionsMutex.RUnlock()
+	_, ok := actions[a]
+	return ok
+}
+
 // ActionHandler type
 type ActionHandler struct {
-	conf Config
+	conf      Config
+	mutex     *sync.Mutex
+	actuators map[Action]Actuator
 }
 
 // NewActionHandler creates a new handler.
 func NewActionHandler(conf Config) ActionHandler {
-	return ActionHandler{conf}
+	return ActionHandler{conf: conf, mutex: new(sync.Mutex), actuators: make(map[Action]Actuator)}
+}
+
+// getActuator returns the actuator of action a, creating it on first use.
+// Actuators are shared by all the rules using an action, so that their state persists when the interpreter
+// recompiles its policies.
+// Handlers not created with NewActionHandler create new actuators instead.
+func (s ActionHandler) getActuator(a Action) (Actuator, error) {
+	if s.mutex == nil {
+		return newActuator(a, s.conf)
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if act, ok := s.actuators[a]; ok {
+		return act, nil
+	}
+	act, err := newActuator(a, s.conf)
+	if err != nil {
+		return nil, err
+	}
+	s.actuators[a] = act
+	return act, nil
 }
 
-// HandleActionAsync handles actions defined in rule.
-func (s ActionHandler) HandleActionAsync(rule Rule, r *Record, out func(r *Record)) {
-	s.HandleAction(rule, r)
-	//out(r)
+// newActuator creates a new actuator for action a.
+func newActuator(a Action, conf Config) (Actuator, error) {
+	actionsMutex.RLock()
+	factory, ok := actions[a]
+	actionsMutex.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("action %s is not registered", a)
+	}
+	act, err := factory(conf)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create action %s: %v", a, err)
+	}
+	return act, nil
+}
+
+// bind binds rules to the actuators of their actions.
+func (s ActionHandler) bind(rules []Rule) error {
+	for i := range rules {
+		actuators := make([]Actuator, 0, len(rules[i].Actions))
+		for _, a := range rules[i].Actions {
+			act, err := s.getActuator(a)
+			if err != nil {
+				return fmt.Errorf("rule %s: %v", rules[i].Name, err)
+			}
+			actuators = append(actuators, act)
+		}
+		rules[i].actuators = actuators
+	}
+	return nil
+}
+
+// asyncAction is an asynchronous action pending on a record.
+type asyncAction struct {
+	rule Rule
+	act  AsyncActuator
+}
+
+// pendingRecords tracks the records waiting for their asynchronous actions to complete.
+var pendingRecords sync.WaitGroup
+
+// WaitForActions waits until the records with pending asynchronous actions have been output.
+func WaitForActions() {
+	pendingRecords.Wait()
+}
+
+// HandleActionAsync handles actions defined in rule, and returns pending with the asynchronous actions
+// of rule appended. Asynchronous actions are run by runAsync once all rules matching r are handled.
+func (s ActionHandler) HandleActionAsync(rule Rule, r *Record, pending []asyncAction) []asyncAction {
+	if rule.output != nil {
+		r.Ctx.SetOutput(rule, rule.output.render(r))
+	}
+	if len(rule.Actions) == 0 {
+		return pending
+	}
+	r.Ctx.AddRule(rule)
+	for i, act := range rule.actuators {
+		if a, ok := act.(AsyncActuator); ok {
+			pending = append(pending, asyncAction{rule: rule, act: a})
+		} else if err := act.Handle(rule, r); err != nil {
+			logger.Error.Printf("Error running action %s of rule %s: %v\n", rule.Actions[i], rule.Name, err)
+		}
+	}
+	return pending
+}
+
+// runAsync runs the asynchronous actions of record r one after the other, so that they do not access r
+// concurrently, and outputs r once they complete. It calls done once r is output.
+func runAsync(actions []asyncAction, r *Record, out func(r *Record), done func()) {
+	if len(actions) == 0 {
+		out(r)
+		done()
+		return
+	}
+	pendingRecords.Add(1)
+	var next func(i int)
+	next = func(i int) {
+		if i == len(actions) {
+			out(r)
+			pendingRecords.Done()
+			done()
+			return
+		}
+		actions[i].act.HandleAsync(actions[i].rule, r, func() { next(i + 1) })
+	}
+	next(0)
 }
 
-// HandleAction handles actions defined in rule.
+// HandleAction handles actions defined in rule, running asynchronous actions synchronously.
+// Rules with actions are recorded in the record context before their actions are run.
 func (s ActionHandler) HandleAction(rule Rule, r *Record) {
-	for _, a := range rule.Actions {
-		switch a {
-		case Tag:
-			fallthrough
-		case Alert:
-			fallthrough
-		default:
-			r.Ctx.AddRule(rule)
+	if rule.output != nil {
+		r.Ctx.SetOutput(rule, rule.output.render(r))
+	}
+	if len(rule.Actions) == 0 {
+		return
+	}
+	r.Ctx.AddRule(rule)
+	for i, act := range rule.actuators {
+		if err := act.Handle(rule, r); err != nil {
+			logger.Error.Printf("Error running action %s of rule %s: %v\n", rule.Actions[i], rule.Name, err)
 		}
 	}
 }
@@ -0,0 +1,184 @@
+//
+// Copyright (C) 2020 IBM Corporation.
+//
+// Authors:
+// Frederico Araujo <frederico.araujo@ibm.com>
+// Teryl Taylor <terylt@ibm.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+package engine
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Aggregate state bounds.
+const (
+	// aggregateBuckets is the number of buckets of an aggregate's sliding window.
+	aggregateBuckets = 16
+	// maxAggregateKeys is the maximum number of keys for which an aggregate keeps state.
+	maxAggregateKeys = 1 << 16
+)
+
+// aggregateBucket holds the total of an aggregate for a slot of its window.
+type aggregateBucket struct {
+	slot  int64
+	total float64
+}
+
+// aggregateState holds the sliding window of an aggregate for a key.
+type aggregateState struct {
+	last    int64
+	buckets [aggregateBuckets]aggregateBucket
+}
+
+// aggregate is a compiled aggregate term, such as count() > 50 within 10s by sf.proc.exe. It holds for records
+// whose key has a running total that compares with the threshold, over a sliding window of record timestamps.
+// Windows slide by slots of a bucket's length, which is a fraction of the window.
+type aggregate struct {
+	value  func(*Record) float64
+	cmp    func(float64) bool
+	width  int64
+	keys   []func(*Record) string
+	ts     func(*Record) int64
+	mu     sync.Mutex
+	states map[string]*aggregateState
+	swept  int64
+}
+
+// newAggregate creates an aggregate of function fn, applied to attribute attr, compared with threshold by
+// comparison operator op, over window and grouped by the values of attributes by.
+func newAggregate(fn string, attr string, op string, threshold float64, window time.Duration, by []string) (*aggregate, error) {
+	a := &aggregate{ts: Mapper.MapInt(SF_TS), states: make(map[string]*aggregateState)}
+	switch fn {
+	case "count":
+		if len(attr) > 0 {
+			return nil, fmt.Errorf("aggregate count takes no attribute")
+		}
+		a.value = func(r *Record) float64 { return 1 }
+	case "sum":
+		if len(attr) == 0 {
+			return nil, fmt.Errorf("aggregate sum takes an attribute")
+		}
+		if _, ok := Mapper.Mappers[attr]; !ok {
+			return nil, fmt.Errorf("unknown attribute %s in aggregate sum", attr)
+		}
+		if valueTypeOf(attr) != IntValue {
+			return nil, fmt.Errorf("aggregate sum of non-integer attribute %s", attr)
+		}
+		m := Mapper.MapInt(attr)
+		a.value = func(r *Record) float64 { return float64(m(r)) }
+	default:
+		return nil, fmt.Errorf("unknown aggregate function %s", fn)
+	}
+	switch op {
+	case ">":
+		a.cmp = func(v float64) bool { return v > threshold }
+	case ">=":
+		a.cmp = func(v float64) bool { return v >= threshold }
+	case "<":
+		a.cmp = func(v float64) bool { return v < threshold }
+	case "<=":
+		a.cmp = func(v float64) bool { return v <= threshold }
+	case "=":
+		a.cmp = func(v float64) bool { return v == threshold }
+	case "!=":
+		a.cmp = func(v float64) bool { return v != threshold }
+	default:
+		return nil, fmt.Errorf("unsupported comparison %s of aggregate %s", op, fn)
+	}
+	if window < aggregateBuckets {
+		return nil, fmt.Errorf("invalid aggregate window %v", window)
+	}
+	a.width = int64(window) / aggregateBuckets
+	for _, f := range by {
+		if _, ok := Mapper.Mappers[f]; !ok {
+			return nil, fmt.Errorf("unknown attribute %s in aggregate key", f)
+		}
+		a.keys = append(a.keys, keyMapper(f))
+	}
+	return a, nil
+}
+
+// criterion returns a criterion that adds the value of a record to the total of its key, and holds if the total
+// compares with the aggregate's threshold. Records of new keys are not aggregated once maxAggregateKeys is reached.
+func (a *aggregate) criterion() Criterion {
+	p := func(r *Record) bool {
+		vals := make([]string, len(a.keys))
+		for i, k := range a.keys {
+			vals[i] = k(r)
+		}
+		key := strings.Join(vals, keySep)
+		slot := a.ts(r) / a.width
+		a.mu.Lock()
+		defer a.mu.Unlock()
+		a.sweep(slot)
+		s, ok := a.states[key]
+		if !ok {
+			if len(a.states) >= maxAggregateKeys {
+				return false
+			}
+			s = &aggregateState{last: slot}
+			a.states[key] = s
+		}
+		if slot > s.last {
+			s.last = slot
+		}
+		if slot > s.last-aggregateBuckets {
+			b := &s.buckets[slot%aggregateBuckets]
+			if b.slot != slot {
+				b.slot = slot
+				b.total = 0
+			}
+			b.total += a.value(r)
+		}
+		total := 0.0
+		for _, b := range s.buckets {
+			if b.slot > s.last-aggregateBuckets {
+				total += b.total
+			}
+		}
+		return a.cmp(total)
+	}
+	return Criterion{p}
+}
+
+// sweep drops the state of keys with no records in the window, at most once per window.
+func (a *aggregate) sweep(slot int64) {
+	if slot-a.swept < aggregateBuckets {
+		return
+	}
+	for k, s := range a.states {
+		if s.last <= slot-aggregateBuckets {
+			delete(a.states, k)
+		}
+	}
+	a.swept = slot
+}
+
+// evalAll returns a criterion that evaluates all criteria, without short-circuiting, and holds if all of them hold.
+// This is used for aggregates, which must see every record satisfying the rest of a condition.
+func evalAll(criteria []Criterion) Criterion {
+	p := func(r *Record) bool {
+		all := true
+		for _, c := range criteria {
+			all = c.Eval(r) && all
+		}
+		return all
+	}
+	return Criterion{p}
+}
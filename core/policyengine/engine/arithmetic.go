@@ -0,0 +1,271 @@
+//
+// Copyright (C) 2020 IBM Corporation.
+//
+// Authors:
+// Frederico Araujo <frederico.araujo@ibm.com>
+// Teryl Taylor <terylt@ibm.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+package engine
+
+import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// operand is a typed value computed from records by an arithmetic expression of a condition.
+// Literals have no type of their own, and take the type of the operands they're used with.
+type operand struct {
+	text  string
+	vtype ValueType
+	lit   bool
+	ints  IntFieldMap
+	strs  StrFieldMap
+	bools BoolFieldMap
+}
+
+// attrOperand creates the operand of attribute or literal attr.
+func attrOperand(attr string) operand {
+	return operand{text: attr, vtype: valueTypeOf(attr), lit: isLiteral(attr),
+		ints: Mapper.MapInt(attr), strs: Mapper.MapStr(attr), bools: Mapper.MapBool(attr)}
+}
+
+// intOperand creates an integer operand computed by m.
+func intOperand(text string, m IntFieldMap) operand {
+	s := func(r *Record) string { return strconv.FormatInt(m(r), 10) }
+	return operand{text: text, vtype: IntValue, ints: m, strs: s}
+}
+
+// strOperand creates a string operand computed by m.
+func strOperand(text string, m StrFieldMap) operand {
+	return operand{text: text, vtype: StrValue, strs: m}
+}
+
+// is indicates whether operand o can be used as a value of type t. Literals must parse as values of type t.
+func (o operand) is(t ValueType) bool {
+	if !o.lit {
+		return o.vtype == t
+	}
+	var err error
+	switch t {
+	case IntValue:
+		_, err = strconv.ParseInt(trimBoundingQuotes(o.text), 10, 64)
+	case BoolValue:
+		_, err = strconv.ParseBool(trimBoundingQuotes(o.text))
+	}
+	return err == nil
+}
+
+// describe describes operand o, which can't be used as a value of type t, in error messages.
+func (o operand) describe(t ValueType) string {
+	if o.lit {
+		return fmt.Sprintf("non-%s value %s", t, o.text)
+	}
+	return fmt.Sprintf("%s value %s", o.vtype, o.text)
+}
+
+// lower returns a string field map of the lowercased values of operand o. Literals are lowercased once.
+func (o operand) lower() StrFieldMap {
+	m := o.strs
+	if o.lit {
+		v := strings.ToLower(m(nil))
+		return func(r *Record) string { return v }
+	}
+	return func(r *Record) string { return strings.ToLower(m(r)) }
+}
+
+// arithOps defines the arithmetic operators over integers. Division and remainder by zero are zero.
+var arithOps = map[string]func(int64, int64) int64{
+	"+": func(l int64, r int64) int64 { return l + r },
+	"-": func(l int64, r int64) int64 { return l - r },
+	"*": func(l int64, r int64) int64 { return l * r },
+	"/": func(l int64, r int64) int64 {
+		if r == 0 {
+			return 0
+		}
+		return l / r
+	},
+	"%": func(l int64, r int64) int64 {
+		if r == 0 {
+			return 0
+		}
+		return l % r
+	},
+}
+
+// arith creates the operand applying arithmetic operator op to integer operands l and r.
+func arith(op string, l operand, r operand) (operand, error) {
+	f, ok := arithOps[op]
+	if !ok {
+		return operand{}, fmt.Errorf("unsupported arithmetic operator %s", op)
+	}
+	for _, o := range []operand{l, r} {
+		if !o.is(IntValue) {
+			return operand{}, fmt.Errorf("cannot apply %s to %s", op, o.describe(IntValue))
+		}
+	}
+	ml, mr := l.ints, r.ints
+	return intOperand(l.text+" "+op+" "+r.text, func(r *Record) int64 { return f(ml(r), mr(r)) }), nil
+}
+
+// function is a built-in function of condition expressions, which computes an operand from string arguments.
+type function struct {
+	arity int
+	call  func(text string, args []StrFieldMap) operand
+}
+
+// functions defines the built-in functions of condition expressions by name.
+var functions = map[string]function{
+	"len": {1, func(text string, args []StrFieldMap) operand {
+		m := args[0]
+		return intOperand(text, func(r *Record) int64 { return int64(len(m(r))) })
+	}},
+	"lower":    strFunction(strings.ToLower),
+	"upper":    strFunction(strings.ToUpper),
+	"basename": strFunction(filepath.Base),
+	"dirname":  strFunction(filepath.Dir),
+}
+
+// strFunction creates a function mapping a string argument to a string with f.
+func strFunction(f func(string) string) function {
+	return function{1, func(text string, args []StrFieldMap) operand {
+		m := args[0]
+		return strOperand(text, func(r *Record) string { return f(m(r)) })
+	}}
+}
+
+// call creates the operand applying function name to string operands args.
+func call(text string, name string, args []operand) (operand, error) {
+	f, ok := functions[name]
+	if !ok {
+		return operand{}, fmt.Errorf("unknown function %s", name)
+	}
+	if len(args) != f.arity {
+		return operand{}, fmt.Errorf("function %s takes %d argument(s), got %d", name, f.arity, len(args))
+	}
+	ms := make([]StrFieldMap, len(args))
+	for i, a := range args {
+		if !a.is(StrValue) {
+			return operand{}, fmt.Errorf("function %s takes string arguments, got %s", name, a.describe(StrValue))
+		}
+		ms[i] = a.strs
+	}
+	return f.call(text, ms), nil
+}
+
+// compare creates a criterion comparing operands l and r by binary operator op.
+// Operands are compared for equality by type, and ordered if they are integers.
+func compare(op string, l operand, r operand) (Criterion, error) {
+	switch op {
+	case "=":
+		return eqOperands(l, r)
+	case "!=":
+		c, err := eqOperands(l, r)
+		return c.Not(), err
+	case "<", "<=", ">", ">=":
+		return orderOperands(op, l, r)
+	case "contains":
+		return strCriterion(l.strs, r.strs, ops.contains), nil
+	case "icontains":
+		return strCriterion(l.lower(), r.lower(), ops.contains), nil
+	case "ieq":
+		return strCriterion(l.lower(), r.lower(), ops.eq), nil
+	case "startswith":
+		return strCriterion(l.strs, r.strs, ops.startswith), nil
+	case "istartswith":
+		return strCriterion(l.lower(), r.lower(), ops.startswith), nil
+	case "endswith":
+		return strCriterion(l.strs, r.strs, ops.endswith), nil
+	case "iendswith":
+		return strCriterion(l.lower(), r.lower(), ops.endswith), nil
+	case "matches", "imatches":
+		if !r.lit {
+			return False, fmt.Errorf("cannot match %s with non-literal regular expression %s", l.text, r.text)
+		}
+		pattern := trimBoundingQuotes(r.text)
+		if op == "imatches" {
+			pattern = "(?i)" + pattern
+		}
+		c, err := matches(l.strs, pattern, false)
+		if err != nil {
+			return False, fmt.Errorf("invalid regular expression %s: %v", r.text, err)
+		}
+		return c, nil
+	}
+	return False, fmt.Errorf("unsupported comparison %s", op)
+}
+
+// eqOperands creates a criterion for the equality of operands l and r. Literals are compared as strings
+// with one another, and by type with other operands.
+func eqOperands(l operand, r operand) (Criterion, error) {
+	t := StrValue
+	switch {
+	case !l.lit && !r.lit:
+		if l.vtype != r.vtype {
+			return False, fmt.Errorf("cannot compare %s value %s with %s value %s", l.vtype, l.text, r.vtype, r.text)
+		}
+		t = l.vtype
+	case !l.lit || !r.lit:
+		o, lit := l, r
+		if l.lit {
+			o, lit = r, l
+		}
+		if !lit.is(o.vtype) {
+			return False, fmt.Errorf("cannot compare %s value %s with %s", o.vtype, o.text, lit.describe(o.vtype))
+		}
+		t = o.vtype
+	}
+	var p Predicate
+	switch t {
+	case IntValue:
+		ml, mr := l.ints, r.ints
+		p = func(r *Record) bool { return ml(r) == mr(r) }
+	case BoolValue:
+		ml, mr := l.bools, r.bools
+		p = func(r *Record) bool { return ml(r) == mr(r) }
+	default:
+		return strCriterion(l.strs, r.strs, ops.eq), nil
+	}
+	return Criterion{p}, nil
+}
+
+// orderOperands creates a criterion ordering integer operands l and r by operator op.
+func orderOperands(op string, l operand, r operand) (Criterion, error) {
+	for _, o := range []operand{l, r} {
+		if !o.is(IntValue) {
+			return False, fmt.Errorf("cannot order %s, only int values are ordered", o.describe(IntValue))
+		}
+	}
+	ml, mr := l.ints, r.ints
+	var p Predicate
+	switch op {
+	case "<":
+		p = func(r *Record) bool { return ml(r) < mr(r) }
+	case "<=":
+		p = func(r *Record) bool { return ml(r) <= mr(r) }
+	case ">":
+		p = func(r *Record) bool { return ml(r) > mr(r) }
+	default:
+		p = func(r *Record) bool { return ml(r) >= mr(r) }
+	}
+	return Criterion{p}, nil
+}
+
+// strCriterion creates a criterion comparing the string values of ml and mr with operator op.
+func strCriterion(ml StrFieldMap, mr StrFieldMap, op operator) Criterion {
+	p := func(r *Record) bool { return eval(ml(r), mr(r), op) }
+	return Criterion{p}
+}
@@ -0,0 +1,47 @@
+//
+// Copyright (C) 2020 IBM Corporation.
+//
+// Authors:
+// Frederico Araujo <frederico.araujo@ibm.com>
+// Teryl Taylor <terylt@ibm.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+package engine
+
+import (
+	"strings"
+)
+
+// Configuration keys of the bundle policy monitor.
+const (
+	BundleKeysConfigKey string = "bundle.keys"
+)
+
+// BundleConfig holds the configuration of the bundle policy monitor.
+type BundleConfig struct {
+	BundleKeys []string
+}
+
+// CreateBundleConfig creates a new bundle policy monitor config object from config dictionary.
+func CreateBundleConfig(conf map[string]interface{}) (c BundleConfig, err error) {
+	// parse config map
+	if v, ok := conf[BundleKeysConfigKey].(string); ok {
+		for _, k := range strings.Split(v, ",") {
+			if k = strings.TrimSpace(k); k != "" {
+				c.BundleKeys = append(c.BundleKeys, k)
+			}
+		}
+	}
+	return
+}
@@ -0,0 +1,137 @@
+//
+// Copyright (C) 2020 IBM Corporation.
+//
+// Authors:
+// Frederico Araujo <frederico.araujo@ibm.com>
+// Teryl Taylor <terylt@ibm.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+package engine
+
+import (
+	"fmt"
+	"net"
+	"strings"
+)
+
+// prefixNode is a node of a binary prefix tree over IP address bits.
+type prefixNode struct {
+	children [2]*prefixNode
+	terminal bool
+}
+
+// IPNets stores a set of IP networks as binary prefix trees, one per address family.
+type IPNets struct {
+	v4 *prefixNode
+	v6 *prefixNode
+}
+
+// ParseIPNets parses a list of CIDR subnets (e.g., 10.0.0.0/8, fe80::/10), possibly quoted, into a set of IP networks.
+// Plain IP addresses are interpreted as single-host networks, and IPv4-mapped IPv6 subnets
+// (e.g., ::ffff:10.0.0.0/104) as the IPv4 subnets they map.
+func ParseIPNets(cidrs []string) (*IPNets, error) {
+	nets := &IPNets{v4: &prefixNode{}, v6: &prefixNode{}}
+	for _, c := range cidrs {
+		c = trimBoundingQuotes(strings.TrimSpace(c))
+		if !strings.Contains(c, "/") {
+			ip := net.ParseIP(c)
+			if ip == nil {
+				return nil, fmt.Errorf("invalid IP address %s", c)
+			}
+			nets.insert(ip, 8*len(normalizeIP(ip)))
+			continue
+		}
+		_, ipnet, err := net.ParseCIDR(c)
+		if err != nil {
+			return nil, fmt.Errorf("invalid CIDR subnet %s", c)
+		}
+		ones, _ := ipnet.Mask.Size()
+		if len(ipnet.IP) == net.IPv6len && ipnet.IP.To4() != nil {
+			ones -= 8 * (net.IPv6len - net.IPv4len)
+		}
+		nets.insert(ipnet.IP, ones)
+	}
+	return nets, nil
+}
+
+// Contains checks whether ip belongs to one of the networks in the set.
+func (n *IPNets) Contains(ip net.IP) bool {
+	ip = normalizeIP(ip)
+	node := n.root(ip)
+	if node == nil {
+		return false
+	}
+	for i := 0; i < 8*len(ip); i++ {
+		if node.terminal {
+			return true
+		}
+		if node = node.children[bit(ip, i)]; node == nil {
+			return false
+		}
+	}
+	return node.terminal
+}
+
+// ContainsIPv4 checks whether the IPv4 address ip, whose most significant byte is the
+// first byte of the address, belongs to one of the networks in the set.
+func (n *IPNets) ContainsIPv4(ip uint32) bool {
+	node := n.v4
+	for i := uint(0); i < 8*net.IPv4len; i++ {
+		if node.terminal {
+			return true
+		}
+		if node = node.children[ip>>(31-i)&1]; node == nil {
+			return false
+		}
+	}
+	return node.terminal
+}
+
+// insert adds the network with prefix length ones to the set.
+func (n *IPNets) insert(ip net.IP, ones int) {
+	ip = normalizeIP(ip)
+	node := n.root(ip)
+	for i := 0; i < ones; i++ {
+		b := bit(ip, i)
+		if node.children[b] == nil {
+			node.children[b] = &prefixNode{}
+		}
+		node = node.children[b]
+	}
+	node.terminal = true
+}
+
+// root returns the prefix tree for the address family of ip.
+func (n *IPNets) root(ip net.IP) *prefixNode {
+	switch len(ip) {
+	case net.IPv4len:
+		return n.v4
+	case net.IPv6len:
+		return n.v6
+	}
+	return nil
+}
+
+// normalizeIP returns the 4-byte representation of IPv4 addresses.
+func normalizeIP(ip net.IP) net.IP {
+	if ip4 := ip.To4(); ip4 != nil {
+		return ip4
+	}
+	return ip
+}
+
+// bit returns the i-th most significant bit of ip.
+func bit(ip net.IP, i int) int {
+	return int(ip[i/8]>>(7-uint(i%8))) & 1
+}
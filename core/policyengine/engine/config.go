@@ -21,6 +21,8 @@ package engine
 
 import (
 	"errors"
+	"strconv"
+	"time"
 )
 
 // Configuration keys.
@@ -31,6 +33,10 @@ const (
 	JSONSchemaVersionKey string = "jsonschemaversion"
 	BuildNumberKey       string = "buildnumber"
 	MonitorKey           string = "monitor"
+	WorkersConfigKey     string = "workers"
+	ShardKeyConfigKey    string = "shardkey"
+	StatsConfigKey       string = "stats"
+	StatsIntervalKey     string = "statsinterval"
 )
 
 // Config defines a configuration object for the engine.
@@ -41,11 +47,18 @@ type Config struct {
 	JSONSchemaVersion string
 	BuildNumber       string
 	Monitor           MonitorType
+	Workers           int
+	ShardKey          ShardKey
+	Stats             bool
+	StatsInterval     time.Duration
+	ExecConfig
+	HashConfig
+	BundleConfig
 }
 
 // CreateConfig creates a new config object from config dictionary.
-func CreateConfig(conf map[string]interface{}) (Config, error) {
-	var c Config = Config{Mode: AlertMode} // default values
+func CreateConfig(conf map[string]interface{}) (c Config, err error) {
+	c = Config{Mode: AlertMode} // default values
 
 	if v, ok := conf[PoliciesConfigKey].(string); ok {
 		c.PoliciesPath = v
@@ -68,13 +81,62 @@ func CreateConfig(conf map[string]interface{}) (Config, error) {
 	if v, ok := conf[MonitorKey].(string); ok {
 		if v == "local" {
 			c.Monitor = LocalType
+		} else if v == "bundle" {
+			c.Monitor = BundleType
 		} else if v == "none" {
 			c.Monitor = NoneType
 		} else {
-			return c, errors.New("Configuration tag 'monitor' must be set to 'none', 'local'")
+			return c, errors.New("Configuration tag 'monitor' must be set to 'none', 'local', 'bundle'")
 		}
 	}
-	return c, nil
+	c.Workers = 1
+	if v, ok := conf[WorkersConfigKey].(string); ok {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			return c, errors.New("Configuration tag 'workers' must be a positive integer")
+		}
+		c.Workers = n
+	}
+	c.ShardKey = ContainerShardKey
+	if v, ok := conf[ShardKeyConfigKey].(string); ok {
+		if v == ContainerShardKey.String() {
+			c.ShardKey = ContainerShardKey
+		} else if v == OIDShardKey.String() {
+			c.ShardKey = OIDShardKey
+		} else {
+			return c, errors.New("Configuration tag 'shardkey' must be set to 'container', 'oid'")
+		}
+	}
+	if v, ok := conf[StatsConfigKey].(string); ok {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return c, errors.New("Configuration tag 'stats' must be set to 'true', 'false'")
+		}
+		c.Stats = b
+	}
+	c.StatsInterval = time.Minute
+	if v, ok := conf[StatsIntervalKey].(string); ok {
+		d, err := time.ParseDuration(v)
+		if err != nil || d < 0 {
+			return c, errors.New("Configuration tag 'statsinterval' must be a non-negative duration")
+		}
+		c.StatsInterval = d
+	}
+
+	// parse specialized configs
+	if c.ExecConfig, err = CreateExecConfig(conf); err != nil {
+		return c, err
+	}
+	if c.HashConfig, err = CreateHashConfig(conf); err != nil {
+		return c, err
+	}
+	if c.BundleConfig, err = CreateBundleConfig(conf); err != nil {
+		return c, err
+	}
+	if c.Monitor == BundleType && len(c.BundleKeys) == 0 {
+		return c, errors.New("Configuration tag 'bundle.keys' must be set when 'monitor' is set to 'bundle'")
+	}
+	return
 }
 
 // Mode type.
@@ -109,8 +171,22 @@ type MonitorType uint32
 const (
 	NoneType MonitorType = iota
 	LocalType
+	BundleType
 )
 
 func (s MonitorType) String() string {
-	return [...]string{"none", "local"}[s]
+	return [...]string{"none", "local", "bundle"}[s]
+}
+
+// ShardKey type.
+type ShardKey uint32
+
+// ShardKey config options. Records are assigned to workers by container ID or by process OID.
+const (
+	ContainerShardKey ShardKey = iota
+	OIDShardKey
+)
+
+func (s ShardKey) String() string {
+	return [...]string{"container", "oid"}[s]
 }
@@ -134,7 +134,7 @@ const (
 	EXT_PROC_SIGNED_INT              = "ext.proc.signed"
 )
 
-//extension file attributes
+// extension file attributes
 const (
 	EXT_FILE_SHA1_HASH_STR        = "ext.file.sha1"
 	EXT_FILE_MD5_HASH_STR         = "ext.file.md5"
@@ -209,7 +209,12 @@ const (
 	FALCO_EVT_PATH              = "evt.arg.path"
 	FALCO_EVT_NEWPATH           = "evt.arg.newpath"
 	FALCO_EVT_OLDPATH           = "evt.arg.oldpath"
+	FALCO_EVT_FILENAME          = "evt.arg.filename"
+	FALCO_EVT_TARGET            = "evt.arg.target"
+	FALCO_EVT_FLAGS             = "evt.arg.flags"
 	FALCO_FD_TYPECHAR           = "fd.typechar"
+	FALCO_FD_TYPE               = "fd.type"
+	FALCO_FD_SOCKFAMILY         = "fd.sockfamily"
 	FALCO_FD_DIRECTORY          = "fd.directory"
 	FALCO_FD_NAME               = "fd.name"
 	FALCO_FD_FILENAME           = "fd.filename"
@@ -239,6 +244,7 @@ const (
 	FALCO_PROC_ARGS             = "proc.args"
 	FALCO_PROC_CREATE_TIME      = "proc.createtime"
 	FALCO_PROC_CMDLINE          = "proc.cmdline"
+	FALCO_PROC_DURATION         = "proc.duration"
 	FALCO_PROC_ANAME            = "proc.aname"
 	FALCO_PROC_APID             = "proc.apid"
 	FALCO_PROC_PPID             = "proc.ppid"
@@ -0,0 +1,203 @@
+//
+// Copyright (C) 2020 IBM Corporation.
+//
+// Authors:
+// Frederico Araujo <frederico.araujo@ibm.com>
+// Teryl Taylor <terylt@ibm.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+package engine
+
+import (
+	"strings"
+
+	"github.com/sysflow-telemetry/sf-apis/go/logger"
+	"github.com/sysflow-telemetry/sf-apis/go/sfgo"
+	"github.com/sysflow-telemetry/sf-processor/core/policyengine/lang/parser"
+)
+
+// numRecordTypes is the number of record types, including the unknown record type.
+const numRecordTypes = int(sfgo.TyUnknow) + 1
+
+// recordTypes is a set of record types, with one bit per record type.
+type recordTypes uint16
+
+// allRecordTypes is the set of all record types.
+const allRecordTypes recordTypes = 1<<numRecordTypes - 1
+
+// opFlagTypes maps operation flags to the types of the records they are set on.
+// File and network flows share the same set of flow flags, e.g., connections on
+// unix-domain sockets are file flows, so flow flags are mapped to both flow types.
+var opFlagTypes = map[string]recordTypes{
+	sfgo.OpFlagClone:    typeBit(sfgo.TyPE),
+	sfgo.OpFlagExec:     typeBit(sfgo.TyPE),
+	sfgo.OpFlagExit:     typeBit(sfgo.TyPE),
+	sfgo.OpFlagSetuid:   typeBit(sfgo.TyPE),
+	sfgo.OpFlagMkdir:    typeBit(sfgo.TyFE),
+	sfgo.OpFlagRmdir:    typeBit(sfgo.TyFE),
+	sfgo.OpFlagLink:     typeBit(sfgo.TyFE),
+	sfgo.OpFlagSymlink:  typeBit(sfgo.TyFE),
+	sfgo.OpFlagUnlink:   typeBit(sfgo.TyFE),
+	sfgo.OpFlagRename:   typeBit(sfgo.TyFE),
+	sfgo.OpFlagOpen:     flowTypes,
+	sfgo.OpFlagSetns:    flowTypes,
+	sfgo.OpFlagMmap:     flowTypes,
+	sfgo.OpFlagTruncate: flowTypes,
+	sfgo.OpFlagDigest:   flowTypes,
+	sfgo.OpFlagAccept:   flowTypes,
+	sfgo.OpFlagConnect:  flowTypes,
+	sfgo.OpFlagShutdown: flowTypes,
+	sfgo.OpFlagWrite:    flowTypes,
+	sfgo.OpFlagSend:     flowTypes,
+	sfgo.OpFlagRead:     flowTypes,
+	sfgo.OpFlagReceive:  flowTypes,
+	sfgo.OpFlagClose:    flowTypes,
+}
+
+// flowTypes is the set of file and network flow record types.
+const flowTypes recordTypes = 1<<uint(sfgo.TyFF) | 1<<uint(sfgo.TyNF)
+
+// typeBit returns the set containing record type t.
+func typeBit(t sfgo.RecordType) recordTypes {
+	return 1 << uint(t)
+}
+
+// typesOf returns the types of records whose sf.type is rtype.
+func typesOf(rtype string) recordTypes {
+	if rtype == sfgo.TyUnknownStr {
+		return typeBit(sfgo.TyUnknow)
+	}
+	if t, err := sfgo.ParseRecordTypeStr(rtype); err == nil {
+		return typeBit(t)
+	}
+	return 0
+}
+
+// recordTypeOf returns the type of record r.
+func recordTypeOf(r *Record) sfgo.RecordType {
+	rtype, _ := sfgo.ParseRecordType(r.GetInt(sfgo.SF_REC_TYPE, sfgo.SYSFLOW_SRC))
+	return rtype
+}
+
+// prefilterTypes returns the record types a rule with prefilter applies to.
+func prefilterTypes(prefilter []string) recordTypes {
+	if len(prefilter) == 0 {
+		return allRecordTypes
+	}
+	var types recordTypes
+	for _, pf := range prefilter {
+		types |= typesOf(pf)
+	}
+	return types
+}
+
+// dispatchTable holds the enabled rules of a policy set that apply to each record type, in definition order.
+type dispatchTable [numRecordTypes][]*Rule
+
+// newDispatchTable creates the dispatch table of rules.
+func newDispatchTable(rules []Rule) (d dispatchTable) {
+	for i := range rules {
+		rule := &rules[i]
+		if !rule.Enabled {
+			continue
+		}
+		if rule.types == 0 {
+			logger.Warn.Printf("Rule %s does not apply to any record type\n", rule.Name)
+		}
+		for t := range d {
+			if rule.types&typeBit(sfgo.RecordType(t)) != 0 {
+				d[t] = append(d[t], rule)
+			}
+		}
+	}
+	return
+}
+
+// candidates returns the enabled rules that apply to the type of record r.
+func (d *dispatchTable) candidates(r *Record) []*Rule {
+	return d[recordTypeOf(r)]
+}
+
+// recordTypes returns the types of records that can satisfy condition ctx, based on its sf.type and
+// evt.type (or sf.opflags) terms. Terms that don't restrict record types are assumed to hold for all types.
+func (listener *sfplListener) recordTypes(ctx parser.IExpressionContext) recordTypes {
+	orCtx := ctx.GetChild(0).(parser.IOr_expressionContext)
+	var types recordTypes
+	for _, andCtx := range orCtx.GetChildren() {
+		if andCtx.GetChildCount() > 0 {
+			andTypes := allRecordTypes
+			for _, termCtx := range andCtx.GetChildren() {
+				if t, ok := termCtx.(parser.ITermContext); ok {
+					andTypes &= listener.termRecordTypes(t)
+				}
+			}
+			types |= andTypes
+		}
+	}
+	return types
+}
+
+// termRecordTypes returns the types of records that can satisfy term ctx.
+func (listener *sfplListener) termRecordTypes(ctx parser.ITermContext) recordTypes {
+	termCtx := ctx.(*parser.TermContext)
+	if termCtx.Variable() != nil {
+		if m, ok := listener.macroCtxs[termCtx.GetText()]; ok {
+			return listener.recordTypes(m)
+		}
+	} else if termCtx.Expression() != nil {
+		return listener.recordTypes(termCtx.Expression())
+	} else if opCtx, ok := termCtx.Binary_operator().(*parser.Binary_operatorContext); ok && termCtx.Atom(0) != nil {
+		lop := termCtx.Atom(0).GetText()
+		rop := termCtx.Atom(1).GetText()
+		if _, ok := Mapper.Mappers[rop]; ok {
+			return allRecordTypes
+		}
+		if opCtx.EQ() != nil {
+			return valueRecordTypes(lop, []string{rop})
+		} else if opCtx.NEQ() != nil && lop == SF_TYPE {
+			return allRecordTypes &^ valueRecordTypes(lop, []string{rop})
+		}
+	} else if termCtx.IN() != nil {
+		lop := termCtx.Atom(0).GetText()
+		return valueRecordTypes(lop, listener.extractListFromAtoms(termCtx.AllAtom()[1:]))
+	}
+	return allRecordTypes
+}
+
+// valueRecordTypes returns the types of records for which attribute attr equals any of values.
+// Values holding lists are equal to any of their items, as in predicate evaluation.
+func valueRecordTypes(attr string, values []string) recordTypes {
+	var items []string
+	for _, v := range values {
+		items = append(items, strings.Split(trimBoundingQuotes(v), LISTSEP)...)
+	}
+	var types recordTypes
+	switch attr {
+	case SF_TYPE:
+		for _, v := range items {
+			types |= typesOf(v)
+		}
+	case SF_OPFLAGS, FALCO_EVT_TYPE:
+		for _, v := range items {
+			t, ok := opFlagTypes[v]
+			if !ok {
+				return allRecordTypes
+			}
+			types |= t
+		}
+	default:
+		return allRecordTypes
+	}
+	return types
+}
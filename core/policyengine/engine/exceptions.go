@@ -0,0 +1,228 @@
+//
+// Copyright (C) 2020 IBM Corporation.
+//
+// Authors:
+// Frederico Araujo <frederico.araujo@ibm.com>
+// Teryl Taylor <terylt@ibm.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+package engine
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// keySep separates the values of a tuple in exception index keys.
+const keySep = "\x00"
+
+// exception is a named rule exception. Records whose fields compare with any of the exception's value tuples
+// are not matched by the rule. Tuples are indexed by the values of the fields compared for equality,
+// so that only the remaining comparisons of the tuples found in the index are evaluated.
+type exception struct {
+	name   string
+	fields []string
+	comps  []string
+	keys   []func(*Record) string
+	index  map[string][]Criterion
+}
+
+// newException creates an exception for fields, compared by comps. Fields are compared for equality by default.
+func newException(name string, fields []string, comps []string) (*exception, error) {
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("no fields")
+	}
+	if len(comps) == 0 {
+		comps = make([]string, len(fields))
+		for i := range comps {
+			comps[i] = "="
+		}
+	}
+	if len(comps) != len(fields) {
+		return nil, fmt.Errorf("%d comps for %d fields", len(comps), len(fields))
+	}
+	e := &exception{name: name, fields: fields, comps: comps, index: make(map[string][]Criterion)}
+	for i, f := range fields {
+		if _, ok := Mapper.Mappers[f]; !ok {
+			return nil, fmt.Errorf("unknown field %s", f)
+		}
+		if comps[i] == "=" {
+			e.keys = append(e.keys, keyMapper(f))
+		}
+	}
+	return e, nil
+}
+
+// add compiles and indexes a tuple of values, which holds a list of values for each field.
+// Fields compared for equality match any of their values.
+func (e *exception) add(tuple [][]string) error {
+	if len(tuple) != len(e.fields) {
+		return fmt.Errorf("%d values for %d fields", len(tuple), len(e.fields))
+	}
+	var keys [][]string
+	var rest []Criterion
+	for i, f := range e.fields {
+		if e.comps[i] != "=" {
+			c, err := compareException(f, e.comps[i], tuple[i])
+			if err != nil {
+				return err
+			}
+			rest = append(rest, c)
+			continue
+		}
+		t := valueTypeOf(f)
+		var vals []string
+		for _, v := range tuple[i] {
+			k, err := keyLiteral(f, t, v)
+			if err != nil {
+				return err
+			}
+			vals = append(vals, strings.Split(k, LISTSEP)...)
+		}
+		keys = append(keys, vals)
+	}
+	c := All(rest)
+	for _, k := range product(keys) {
+		e.index[k] = append(e.index[k], c)
+	}
+	return nil
+}
+
+// criterion returns a criterion that holds for the records matched by the exception.
+func (e *exception) criterion() Criterion {
+	p := func(r *Record) bool {
+		vals := make([]string, len(e.keys))
+		split := false
+		for i, k := range e.keys {
+			vals[i] = k(r)
+			split = split || strings.Contains(vals[i], LISTSEP)
+		}
+		keys := []string{strings.Join(vals, keySep)}
+		if split {
+			sets := make([][]string, len(vals))
+			for i, v := range vals {
+				sets[i] = strings.Split(v, LISTSEP)
+			}
+			keys = product(sets)
+		}
+		for _, k := range keys {
+			for _, c := range e.index[k] {
+				if c.Eval(r) {
+					return true
+				}
+			}
+		}
+		return false
+	}
+	return Criterion{p}
+}
+
+// keyMapper returns a mapper of attr to the string form of its typed value, which is used in index keys.
+func keyMapper(attr string) func(*Record) string {
+	switch valueTypeOf(attr) {
+	case IntValue:
+		m := Mapper.MapInt(attr)
+		return func(r *Record) string { return strconv.FormatInt(m(r), 10) }
+	case BoolValue:
+		m := Mapper.MapBool(attr)
+		return func(r *Record) string { return strconv.FormatBool(m(r)) }
+	}
+	return Mapper.MapStr(attr)
+}
+
+// keyLiteral returns the string form of literal lit in index keys, given the value type t of attribute attr.
+func keyLiteral(attr string, t ValueType, lit string) (string, error) {
+	if err := checkLiteral(attr, t, lit); err != nil {
+		return "", err
+	}
+	lit = trimBoundingQuotes(lit)
+	switch t {
+	case IntValue:
+		i, _ := strconv.ParseInt(lit, 10, 64)
+		return strconv.FormatInt(i, 10), nil
+	case BoolValue:
+		b, _ := strconv.ParseBool(lit)
+		return strconv.FormatBool(b), nil
+	}
+	return lit, nil
+}
+
+// compareException creates a criterion comparing attr with vals by comparison operator comp.
+// List operators take any number of values, all other operators a single value.
+func compareException(attr string, comp string, vals []string) (Criterion, error) {
+	switch comp {
+	case "in":
+		return In(attr, vals)
+	case "iin":
+		return IIn(attr, vals)
+	case "pmatch":
+		return PMatch(attr, vals), nil
+	case "in_cidr":
+		return InCIDR(attr, vals)
+	}
+	if len(vals) != 1 {
+		return False, fmt.Errorf("comparison %s of field %s takes a single value, got %d", comp, attr, len(vals))
+	}
+	v := vals[0]
+	switch comp {
+	case "!=":
+		return NEq(attr, v)
+	case "<":
+		return Lt(attr, v)
+	case "<=":
+		return Le(attr, v)
+	case ">":
+		return Gt(attr, v)
+	case ">=":
+		return Ge(attr, v)
+	case "contains":
+		return Contains(attr, v), nil
+	case "icontains":
+		return IContains(attr, v), nil
+	case "ieq":
+		return IEq(attr, v), nil
+	case "startswith":
+		return StartsWith(attr, v), nil
+	case "istartswith":
+		return IStartsWith(attr, v), nil
+	case "endswith":
+		return EndsWith(attr, v), nil
+	case "iendswith":
+		return IEndsWith(attr, v), nil
+	case "matches":
+		return Matches(attr, v)
+	case "imatches":
+		return IMatches(attr, v)
+	}
+	return False, fmt.Errorf("unsupported comparison %s of field %s", comp, attr)
+}
+
+// product returns the keys of all combinations of one value of each set.
+func product(sets [][]string) []string {
+	keys := []string{""}
+	for i, set := range sets {
+		next := make([]string, 0, len(keys)*len(set))
+		for _, k := range keys {
+			for _, v := range set {
+				if i > 0 {
+					v = k + keySep + v
+				}
+				next = append(next, v)
+			}
+		}
+		keys = next
+	}
+	return keys
+}
@@ -0,0 +1,79 @@
+//
+// Copyright (C) 2020 IBM Corporation.
+//
+// Authors:
+// Frederico Araujo <frederico.araujo@ibm.com>
+// Teryl Taylor <terylt@ibm.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+package engine
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Configuration keys of the exec action.
+const (
+	ExecCommandConfigKey     string = "exec.command"
+	ExecTimeoutConfigKey     string = "exec.timeout"
+	ExecConcurrencyConfigKey string = "exec.concurrency"
+	ExecRateLimitConfigKey   string = "exec.ratelimit"
+)
+
+// ExecConfig holds the configuration of the exec action.
+type ExecConfig struct {
+	ExecCommand     []string
+	ExecTimeout     time.Duration
+	ExecConcurrency int
+	ExecRateLimit   float64
+}
+
+// CreateExecConfig creates a new exec action config object from config dictionary.
+func CreateExecConfig(conf map[string]interface{}) (c ExecConfig, err error) {
+	// default values
+	c = ExecConfig{
+		ExecTimeout:     10 * time.Second,
+		ExecConcurrency: 4,
+		ExecRateLimit:   10}
+
+	// parse config map
+	if v, ok := conf[ExecCommandConfigKey].(string); ok {
+		c.ExecCommand = strings.Fields(v)
+	}
+	if v, ok := conf[ExecTimeoutConfigKey].(string); ok {
+		d, err := time.ParseDuration(v)
+		if err != nil || d <= 0 {
+			return c, errors.New("Configuration tag 'exec.timeout' must be a positive duration")
+		}
+		c.ExecTimeout = d
+	}
+	if v, ok := conf[ExecConcurrencyConfigKey].(string); ok {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			return c, errors.New("Configuration tag 'exec.concurrency' must be a positive integer")
+		}
+		c.ExecConcurrency = n
+	}
+	if v, ok := conf[ExecRateLimitConfigKey].(string); ok {
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil || f < 0 {
+			return c, errors.New("Configuration tag 'exec.ratelimit' must be a non-negative number")
+		}
+		c.ExecRateLimit = f
+	}
+	return
+}
@@ -29,6 +29,7 @@ import (
 	"github.com/cespare/xxhash"
 	"github.com/sysflow-telemetry/sf-apis/go/logger"
 	"github.com/sysflow-telemetry/sf-apis/go/sfgo"
+	"github.com/sysflow-telemetry/sf-processor/core/flattener"
 )
 
 // FieldMap is a functional type denoting a SysFlow attribute mapper.
@@ -49,6 +50,32 @@ const (
 	MapSpecialBool MappingType = 7
 )
 
+// ValueType defines the type of values compared by predicates.
+type ValueType uint8
+
+// Value types
+const (
+	StrValue  ValueType = 0
+	IntValue  ValueType = 1
+	BoolValue ValueType = 2
+)
+
+// String returns the string representation of a value type.
+func (t ValueType) String() string {
+	return [...]string{"string", "int", "bool"}[t]
+}
+
+// valueType returns the type of the values of a mapped attribute, as compared by predicates.
+func (t MappingType) valueType() ValueType {
+	switch t {
+	case MapIntVal, MapSpecialInt:
+		return IntValue
+	case MapBoolVal, MapSpecialBool:
+		return BoolValue
+	}
+	return StrValue
+}
+
 // SectionType defines a section type
 type SectionType uint8
 
@@ -71,6 +98,9 @@ const (
 	PARENT_IDS sfgo.Attribute = (2 << 30) - 2
 )
 
+// maxAncestorDepth is the depth of the farthest ancestor whose name can be queried by index, as in proc.aname[8].
+const maxAncestorDepth = 8
+
 // FieldEntry is an object that stores metadata for each field in the exported map.
 type FieldEntry struct {
 	Map       FieldMap
@@ -79,6 +109,8 @@ type FieldEntry struct {
 	Source    sfgo.Source
 	Section   SectionType
 	AuxAttr   RecAttribute
+	Present   BoolFieldMap
+	IPs       IPFieldMap
 }
 
 // IntFieldMap is a functional type denoting a numerical attribute mapper.
@@ -87,6 +119,13 @@ type IntFieldMap func(r *Record) int64
 // StrFieldMap is a functional type denoting a string attribute mapper.
 type StrFieldMap func(r *Record) string
 
+// BoolFieldMap is a functional type denoting a boolean attribute mapper.
+type BoolFieldMap func(r *Record) bool
+
+// IPFieldMap is a functional type denoting an IPv4 address attribute mapper.
+// Addresses are mapped to integers whose most significant byte is the first byte of the address.
+type IPFieldMap func(r *Record) []uint32
+
 // VoidFieldMap is a functional type denoting a void attribute mapper.
 type VoidFieldMap func(r *Record)
 
@@ -95,6 +134,23 @@ type FieldMapper struct {
 	Mappers map[string]*FieldEntry
 }
 
+// fieldPrefixes lists the name prefixes of SysFlow, extended, and Falco attributes.
+var fieldPrefixes = []string{"sf.", "ext.", "evt.", "fd.", "proc.", "user.", "group.", "container.", "thread."}
+
+// IsUnknownField checks whether attr is named like an attribute but has no field map.
+// Such identifiers would otherwise be treated as literals in policy conditions.
+func (m FieldMapper) IsUnknownField(attr string) bool {
+	if _, ok := m.Mappers[attr]; ok {
+		return false
+	}
+	for _, p := range fieldPrefixes {
+		if strings.HasPrefix(attr, p) {
+			return true
+		}
+	}
+	return false
+}
+
 // Map retrieves a field map based on a SysFlow attribute.
 func (m FieldMapper) Map(attr string) FieldMap {
 	if mapper, ok := m.Mappers[attr]; ok {
@@ -104,29 +160,85 @@ func (m FieldMapper) Map(attr string) FieldMap {
 }
 
 // MapInt retrieves a numerical field map based on a SysFlow attribute.
+// Literals are parsed once, when the field map is created.
 func (m FieldMapper) MapInt(attr string) IntFieldMap {
-	return func(r *Record) int64 {
-		if v, ok := m.Map(attr)(r).(int64); ok {
-			return v
-		} else if v, err := strconv.ParseInt(attr, 10, 64); err == nil {
-			return v
+	if mapper, ok := m.Mappers[attr]; ok {
+		return func(r *Record) int64 {
+			switch v := mapper.Map(r).(type) {
+			case int64:
+				return v
+			case int32:
+				return int64(v)
+			case bool:
+				if v {
+					return 1
+				}
+			}
+			return sfgo.Zeros.Int64
 		}
-		return sfgo.Zeros.Int64
 	}
+	v, _ := strconv.ParseInt(trimBoundingQuotes(attr), 10, 64)
+	return func(r *Record) int64 { return v }
+}
+
+// MapBool retrieves a boolean field map based on a SysFlow attribute.
+// Literals are parsed once, when the field map is created.
+func (m FieldMapper) MapBool(attr string) BoolFieldMap {
+	if mapper, ok := m.Mappers[attr]; ok {
+		return func(r *Record) bool {
+			switch v := mapper.Map(r).(type) {
+			case bool:
+				return v
+			case int64:
+				return v != 0
+			case int32:
+				return v != 0
+			}
+			return false
+		}
+	}
+	v, _ := strconv.ParseBool(trimBoundingQuotes(attr))
+	return func(r *Record) bool { return v }
 }
 
 // MapStr retrieves a string field map based on a SysFlow attribute.
 func (m FieldMapper) MapStr(attr string) StrFieldMap {
-	return func(r *Record) string {
-		if v, ok := m.Map(attr)(r).(string); ok {
-			return trimBoundingQuotes(v)
-		} else if v, ok := m.Map(attr)(r).(int64); ok {
-			return strconv.FormatInt(v, 10)
-		} else if v, ok := m.Map(attr)(r).(bool); ok {
-			return strconv.FormatBool(v)
+	if mapper, ok := m.Mappers[attr]; ok {
+		return func(r *Record) string {
+			switch v := mapper.Map(r).(type) {
+			case string:
+				return trimBoundingQuotes(v)
+			case int64:
+				return strconv.FormatInt(v, 10)
+			case int32:
+				return strconv.FormatInt(int64(v), 10)
+			case bool:
+				return strconv.FormatBool(v)
+			}
+			return sfgo.Zeros.String
 		}
-		return sfgo.Zeros.String
 	}
+	v := trimBoundingQuotes(attr)
+	return func(r *Record) string { return v }
+}
+
+// MapPresence retrieves a field map indicating whether a SysFlow attribute has a value in a record,
+// i.e., whether the record has the entity the attribute belongs to, and the attribute applies to its type.
+// Entity presence is looked up in the presence bitmap the flattener records in flat records.
+// Zero values of present attributes (e.g., uid 0) are values. Literals are always present.
+func (m FieldMapper) MapPresence(attr string) BoolFieldMap {
+	if mapper, ok := m.Mappers[attr]; ok && mapper.Present != nil {
+		return mapper.Present
+	}
+	return func(r *Record) bool { return true }
+}
+
+// MapIPs retrieves the IPv4 address field map of a SysFlow attribute, or nil if attr is not an IP address attribute.
+func (m FieldMapper) MapIPs(attr string) IPFieldMap {
+	if mapper, ok := m.Mappers[attr]; ok {
+		return mapper.IPs
+	}
+	return nil
 }
 
 // Fields defines a sorted array of all exported field mapper keys.
@@ -203,77 +315,79 @@ func getMappers() map[string]*FieldEntry {
 //		FlatIndex: index in the flat record structure
 //		Type: mapping function return type; if "MapSpecial*", the function modifies the input data
 // 		Source: source field in the flat record structure
+//		Present: presence function; if nil, the attribute is present in all records
+//		IPs: IPv4 address mapping function of IP address attributes
 func getExportedMappers() map[string]*FieldEntry {
 	return map[string]*FieldEntry{
 		// SysFlow
 		SF_TYPE:                 &FieldEntry{Map: mapRecType(sfgo.SYSFLOW_SRC), FlatIndex: sfgo.SF_REC_TYPE, Type: MapSpecialStr, Source: sfgo.SYSFLOW_SRC},
 		SF_OPFLAGS:              &FieldEntry{Map: mapOpFlags(sfgo.SYSFLOW_SRC), FlatIndex: sfgo.EV_PROC_OPFLAGS_INT, Type: MapArrayStr, Source: sfgo.SYSFLOW_SRC},
-		SF_RET:                  &FieldEntry{Map: mapRet(sfgo.SYSFLOW_SRC), FlatIndex: sfgo.SF_REC_TYPE, Type: MapSpecialInt, Source: sfgo.SYSFLOW_SRC},
+		SF_RET:                  &FieldEntry{Map: mapRet(sfgo.SYSFLOW_SRC), FlatIndex: sfgo.SF_REC_TYPE, Type: MapSpecialInt, Source: sfgo.SYSFLOW_SRC, Present: isRecType(sfgo.SYSFLOW_SRC, sfgo.PROC_EVT, sfgo.FILE_EVT)},
 		SF_TS:                   &FieldEntry{Map: mapInt(sfgo.SYSFLOW_SRC, sfgo.TS_INT), FlatIndex: sfgo.TS_INT, Type: MapIntVal, Source: sfgo.SYSFLOW_SRC},
-		SF_ENDTS:                &FieldEntry{Map: mapEndTs(sfgo.SYSFLOW_SRC), FlatIndex: sfgo.FL_FILE_ENDTS_INT, Type: MapSpecialInt, Source: sfgo.SYSFLOW_SRC},
-		SF_PROC_OID:             &FieldEntry{Map: mapOID(sfgo.SYSFLOW_SRC, sfgo.PROC_OID_HPID_INT, sfgo.PROC_OID_CREATETS_INT), FlatIndex: sfgo.PROC_OID_HPID_INT, Type: MapSpecialStr, Source: sfgo.SYSFLOW_SRC, Section: SectProc},
-		SF_PROC_PID:             &FieldEntry{Map: mapInt(sfgo.SYSFLOW_SRC, sfgo.PROC_OID_HPID_INT), FlatIndex: sfgo.PROC_OID_HPID_INT, Type: MapIntVal, Source: sfgo.SYSFLOW_SRC, Section: SectProc},
-		SF_PROC_NAME:            &FieldEntry{Map: mapName(sfgo.SYSFLOW_SRC, sfgo.PROC_EXE_STR), FlatIndex: sfgo.PROC_EXE_STR, Type: MapSpecialStr, Source: sfgo.SYSFLOW_SRC, Section: SectProc},
-		SF_PROC_EXE:             &FieldEntry{Map: mapStr(sfgo.SYSFLOW_SRC, sfgo.PROC_EXE_STR), FlatIndex: sfgo.PROC_EXE_STR, Type: MapStrVal, Source: sfgo.SYSFLOW_SRC, Section: SectProc},
-		SF_PROC_ARGS:            &FieldEntry{Map: mapStr(sfgo.SYSFLOW_SRC, sfgo.PROC_EXEARGS_STR), FlatIndex: sfgo.PROC_EXEARGS_STR, Type: MapStrVal, Source: sfgo.SYSFLOW_SRC, Section: SectProc},
-		SF_PROC_UID:             &FieldEntry{Map: mapInt(sfgo.SYSFLOW_SRC, sfgo.PROC_UID_INT), FlatIndex: sfgo.PROC_UID_INT, Type: MapIntVal, Source: sfgo.SYSFLOW_SRC, Section: SectProc},
-		SF_PROC_USER:            &FieldEntry{Map: mapStr(sfgo.SYSFLOW_SRC, sfgo.PROC_USERNAME_STR), FlatIndex: sfgo.PROC_USERNAME_STR, Type: MapStrVal, Source: sfgo.SYSFLOW_SRC, Section: SectProc},
+		SF_ENDTS:                &FieldEntry{Map: mapEndTs(sfgo.SYSFLOW_SRC), FlatIndex: sfgo.FL_FILE_ENDTS_INT, Type: MapSpecialInt, Source: sfgo.SYSFLOW_SRC, Present: isRecType(sfgo.SYSFLOW_SRC, sfgo.FILE_FLOW, sfgo.NET_FLOW)},
+		SF_PROC_OID:             &FieldEntry{Map: mapOID(sfgo.SYSFLOW_SRC, sfgo.PROC_OID_HPID_INT, sfgo.PROC_OID_CREATETS_INT), FlatIndex: sfgo.PROC_OID_HPID_INT, Type: MapSpecialStr, Source: sfgo.SYSFLOW_SRC, Section: SectProc, Present: hasProc(sfgo.SYSFLOW_SRC)},
+		SF_PROC_PID:             &FieldEntry{Map: mapInt(sfgo.SYSFLOW_SRC, sfgo.PROC_OID_HPID_INT), FlatIndex: sfgo.PROC_OID_HPID_INT, Type: MapIntVal, Source: sfgo.SYSFLOW_SRC, Section: SectProc, Present: hasProc(sfgo.SYSFLOW_SRC)},
+		SF_PROC_NAME:            &FieldEntry{Map: mapName(sfgo.SYSFLOW_SRC, sfgo.PROC_EXE_STR), FlatIndex: sfgo.PROC_EXE_STR, Type: MapSpecialStr, Source: sfgo.SYSFLOW_SRC, Section: SectProc, Present: hasProc(sfgo.SYSFLOW_SRC)},
+		SF_PROC_EXE:             &FieldEntry{Map: mapStr(sfgo.SYSFLOW_SRC, sfgo.PROC_EXE_STR), FlatIndex: sfgo.PROC_EXE_STR, Type: MapStrVal, Source: sfgo.SYSFLOW_SRC, Section: SectProc, Present: hasProc(sfgo.SYSFLOW_SRC)},
+		SF_PROC_ARGS:            &FieldEntry{Map: mapStr(sfgo.SYSFLOW_SRC, sfgo.PROC_EXEARGS_STR), FlatIndex: sfgo.PROC_EXEARGS_STR, Type: MapStrVal, Source: sfgo.SYSFLOW_SRC, Section: SectProc, Present: hasProc(sfgo.SYSFLOW_SRC)},
+		SF_PROC_UID:             &FieldEntry{Map: mapInt(sfgo.SYSFLOW_SRC, sfgo.PROC_UID_INT), FlatIndex: sfgo.PROC_UID_INT, Type: MapIntVal, Source: sfgo.SYSFLOW_SRC, Section: SectProc, Present: hasProc(sfgo.SYSFLOW_SRC)},
+		SF_PROC_USER:            &FieldEntry{Map: mapStr(sfgo.SYSFLOW_SRC, sfgo.PROC_USERNAME_STR), FlatIndex: sfgo.PROC_USERNAME_STR, Type: MapStrVal, Source: sfgo.SYSFLOW_SRC, Section: SectProc, Present: hasProc(sfgo.SYSFLOW_SRC)},
 		SF_PROC_TID:             &FieldEntry{Map: mapInt(sfgo.SYSFLOW_SRC, sfgo.TID_INT), FlatIndex: sfgo.TID_INT, Type: MapIntVal, Source: sfgo.SYSFLOW_SRC, Section: SectProc},
-		SF_PROC_GID:             &FieldEntry{Map: mapInt(sfgo.SYSFLOW_SRC, sfgo.PROC_GID_INT), FlatIndex: sfgo.PROC_GID_INT, Type: MapIntVal, Source: sfgo.SYSFLOW_SRC, Section: SectProc},
-		SF_PROC_GROUP:           &FieldEntry{Map: mapStr(sfgo.SYSFLOW_SRC, sfgo.PROC_GROUPNAME_STR), FlatIndex: sfgo.PROC_GROUPNAME_STR, Type: MapStrVal, Source: sfgo.SYSFLOW_SRC, Section: SectProc},
-		SF_PROC_CREATETS:        &FieldEntry{Map: mapInt(sfgo.SYSFLOW_SRC, sfgo.PROC_OID_CREATETS_INT), FlatIndex: sfgo.PROC_OID_CREATETS_INT, Type: MapIntVal, Source: sfgo.SYSFLOW_SRC, Section: SectProc},
-		SF_PROC_TTY:             &FieldEntry{Map: mapInt(sfgo.SYSFLOW_SRC, sfgo.PROC_TTY_INT), FlatIndex: sfgo.PROC_TTY_INT, Type: MapBoolVal, Source: sfgo.SYSFLOW_SRC, Section: SectProc},
-		SF_PROC_ENTRY:           &FieldEntry{Map: mapInt(sfgo.SYSFLOW_SRC, sfgo.PROC_ENTRY_INT), FlatIndex: sfgo.PROC_ENTRY_INT, Type: MapBoolVal, Source: sfgo.SYSFLOW_SRC, Section: SectProc},
-		SF_PROC_CMDLINE:         &FieldEntry{Map: mapJoin(sfgo.SYSFLOW_SRC, sfgo.PROC_EXE_STR, sfgo.PROC_EXEARGS_STR), FlatIndex: sfgo.PROC_EXE_STR, Type: MapSpecialStr, Source: sfgo.SYSFLOW_SRC, Section: SectProc},
-		SF_PROC_ANAME:           &FieldEntry{Map: mapCachedValue(sfgo.SYSFLOW_SRC, ProcAName), FlatIndex: A_IDS, Type: MapArrayStr, Source: sfgo.SYSFLOW_SRC, Section: SectProc, AuxAttr: ProcAName},
-		SF_PROC_AEXE:            &FieldEntry{Map: mapCachedValue(sfgo.SYSFLOW_SRC, ProcAExe), FlatIndex: A_IDS, Type: MapArrayStr, Source: sfgo.SYSFLOW_SRC, Section: SectProc, AuxAttr: ProcAExe},
-		SF_PROC_ACMDLINE:        &FieldEntry{Map: mapCachedValue(sfgo.SYSFLOW_SRC, ProcACmdLine), FlatIndex: A_IDS, Type: MapArrayStr, Source: sfgo.SYSFLOW_SRC, Section: SectProc, AuxAttr: ProcACmdLine},
-		SF_PROC_APID:            &FieldEntry{Map: mapCachedValue(sfgo.SYSFLOW_SRC, ProcAPID), FlatIndex: A_IDS, Type: MapArrayInt, Source: sfgo.SYSFLOW_SRC, Section: SectProc, AuxAttr: ProcAPID},
-		SF_PPROC_OID:            &FieldEntry{Map: mapOID(sfgo.SYSFLOW_SRC, sfgo.PROC_POID_HPID_INT, sfgo.PROC_POID_CREATETS_INT), FlatIndex: sfgo.PROC_POID_HPID_INT, Type: MapSpecialStr, Source: sfgo.SYSFLOW_SRC, Section: SectPProc},
-		SF_PPROC_PID:            &FieldEntry{Map: mapInt(sfgo.SYSFLOW_SRC, sfgo.PROC_POID_HPID_INT), FlatIndex: sfgo.PROC_POID_HPID_INT, Type: MapIntVal, Source: sfgo.SYSFLOW_SRC, Section: SectPProc},
-		SF_PPROC_NAME:           &FieldEntry{Map: mapCachedValue(sfgo.SYSFLOW_SRC, PProcName), FlatIndex: PARENT_IDS, Type: MapSpecialStr, Source: sfgo.SYSFLOW_SRC, Section: SectPProc, AuxAttr: PProcName},
-		SF_PPROC_EXE:            &FieldEntry{Map: mapCachedValue(sfgo.SYSFLOW_SRC, PProcExe), FlatIndex: PARENT_IDS, Type: MapSpecialStr, Source: sfgo.SYSFLOW_SRC, Section: SectPProc, AuxAttr: PProcExe},
-		SF_PPROC_ARGS:           &FieldEntry{Map: mapCachedValue(sfgo.SYSFLOW_SRC, PProcArgs), FlatIndex: PARENT_IDS, Type: MapSpecialStr, Source: sfgo.SYSFLOW_SRC, Section: SectPProc, AuxAttr: PProcArgs},
-		SF_PPROC_UID:            &FieldEntry{Map: mapCachedValue(sfgo.SYSFLOW_SRC, PProcUID), FlatIndex: PARENT_IDS, Type: MapSpecialInt, Source: sfgo.SYSFLOW_SRC, Section: SectPProc, AuxAttr: PProcUID},
-		SF_PPROC_USER:           &FieldEntry{Map: mapCachedValue(sfgo.SYSFLOW_SRC, PProcUser), FlatIndex: PARENT_IDS, Type: MapSpecialStr, Source: sfgo.SYSFLOW_SRC, Section: SectPProc, AuxAttr: PProcUser},
-		SF_PPROC_GID:            &FieldEntry{Map: mapCachedValue(sfgo.SYSFLOW_SRC, PProcGID), FlatIndex: PARENT_IDS, Type: MapSpecialInt, Source: sfgo.SYSFLOW_SRC, Section: SectPProc, AuxAttr: PProcGID},
-		SF_PPROC_GROUP:          &FieldEntry{Map: mapCachedValue(sfgo.SYSFLOW_SRC, PProcGroup), FlatIndex: PARENT_IDS, Type: MapSpecialStr, Source: sfgo.SYSFLOW_SRC, Section: SectPProc, AuxAttr: PProcGroup},
-		SF_PPROC_CREATETS:       &FieldEntry{Map: mapInt(sfgo.SYSFLOW_SRC, sfgo.PROC_POID_CREATETS_INT), FlatIndex: sfgo.PROC_POID_CREATETS_INT, Type: MapIntVal, Source: sfgo.SYSFLOW_SRC, Section: SectPProc},
-		SF_PPROC_TTY:            &FieldEntry{Map: mapCachedValue(sfgo.SYSFLOW_SRC, PProcTTY), FlatIndex: PARENT_IDS, Type: MapSpecialInt, Source: sfgo.SYSFLOW_SRC, Section: SectPProc, AuxAttr: PProcTTY},
-		SF_PPROC_ENTRY:          &FieldEntry{Map: mapCachedValue(sfgo.SYSFLOW_SRC, PProcEntry), FlatIndex: PARENT_IDS, Type: MapSpecialInt, Source: sfgo.SYSFLOW_SRC, Section: SectPProc, AuxAttr: PProcEntry},
-		SF_PPROC_CMDLINE:        &FieldEntry{Map: mapCachedValue(sfgo.SYSFLOW_SRC, PProcCmdLine), FlatIndex: PARENT_IDS, Type: MapSpecialStr, Source: sfgo.SYSFLOW_SRC, Section: SectPProc, AuxAttr: PProcCmdLine},
-		SF_FILE_NAME:            &FieldEntry{Map: mapName(sfgo.SYSFLOW_SRC, sfgo.FILE_PATH_STR), FlatIndex: sfgo.FILE_PATH_STR, Type: MapSpecialStr, Source: sfgo.SYSFLOW_SRC, Section: SectFile},
-		SF_FILE_PATH:            &FieldEntry{Map: mapPath(sfgo.SYSFLOW_SRC, sfgo.FILE_PATH_STR), FlatIndex: sfgo.FILE_PATH_STR, Type: MapSpecialStr, Source: sfgo.SYSFLOW_SRC, Section: SectFile},
-		SF_FILE_SYMLINK:         &FieldEntry{Map: mapSymlink(sfgo.SYSFLOW_SRC, sfgo.FILE_PATH_STR), FlatIndex: sfgo.FILE_PATH_STR, Type: MapSpecialStr, Source: sfgo.SYSFLOW_SRC, Section: SectFile},
-		SF_FILE_OID:             &FieldEntry{Map: mapStr(sfgo.SYSFLOW_SRC, sfgo.FILE_OID_STR), FlatIndex: sfgo.FILE_OID_STR, Type: MapStrVal, Source: sfgo.SYSFLOW_SRC, Section: SectFile},
-		SF_FILE_DIRECTORY:       &FieldEntry{Map: mapDir(sfgo.SYSFLOW_SRC, sfgo.FILE_PATH_STR), FlatIndex: sfgo.FILE_PATH_STR, Type: MapSpecialStr, Source: sfgo.SYSFLOW_SRC, Section: SectFile},
-		SF_FILE_NEWNAME:         &FieldEntry{Map: mapName(sfgo.SYSFLOW_SRC, sfgo.SEC_FILE_PATH_STR), FlatIndex: sfgo.SEC_FILE_PATH_STR, Type: MapSpecialStr, Source: sfgo.SYSFLOW_SRC, Section: SectFile},
-		SF_FILE_NEWPATH:         &FieldEntry{Map: mapPath(sfgo.SYSFLOW_SRC, sfgo.SEC_FILE_PATH_STR), FlatIndex: sfgo.SEC_FILE_PATH_STR, Type: MapStrVal, Source: sfgo.SYSFLOW_SRC, Section: SectFile},
-		SF_FILE_NEWSYMLINK:      &FieldEntry{Map: mapSymlink(sfgo.SYSFLOW_SRC, sfgo.SEC_FILE_PATH_STR), FlatIndex: sfgo.SEC_FILE_PATH_STR, Type: MapSpecialStr, Source: sfgo.SYSFLOW_SRC, Section: SectFile},
-		SF_FILE_NEWOID:          &FieldEntry{Map: mapStr(sfgo.SYSFLOW_SRC, sfgo.SEC_FILE_OID_STR), FlatIndex: sfgo.SEC_FILE_OID_STR, Type: MapStrVal, Source: sfgo.SYSFLOW_SRC, Section: SectFile},
-		SF_FILE_NEWDIRECTORY:    &FieldEntry{Map: mapDir(sfgo.SYSFLOW_SRC, sfgo.SEC_FILE_PATH_STR), FlatIndex: sfgo.SEC_FILE_PATH_STR, Type: MapSpecialStr, Source: sfgo.SYSFLOW_SRC, Section: SectFile},
-		SF_FILE_TYPE:            &FieldEntry{Map: mapFileType(sfgo.SYSFLOW_SRC, sfgo.FILE_RESTYPE_INT), FlatIndex: sfgo.FILE_RESTYPE_INT, Type: MapSpecialStr, Source: sfgo.SYSFLOW_SRC, Section: SectFile},
-		SF_FILE_IS_OPEN_WRITE:   &FieldEntry{Map: mapIsOpenWrite(sfgo.SYSFLOW_SRC, sfgo.FL_FILE_OPENFLAGS_INT), FlatIndex: sfgo.FL_FILE_OPENFLAGS_INT, Type: MapSpecialBool, Source: sfgo.SYSFLOW_SRC, Section: SectFile},
-		SF_FILE_IS_OPEN_READ:    &FieldEntry{Map: mapIsOpenRead(sfgo.SYSFLOW_SRC, sfgo.FL_FILE_OPENFLAGS_INT), FlatIndex: sfgo.FL_FILE_OPENFLAGS_INT, Type: MapSpecialBool, Source: sfgo.SYSFLOW_SRC, Section: SectFile},
-		SF_FILE_FD:              &FieldEntry{Map: mapInt(sfgo.SYSFLOW_SRC, sfgo.FL_FILE_FD_INT), FlatIndex: sfgo.FL_FILE_FD_INT, Type: MapIntVal, Source: sfgo.SYSFLOW_SRC, Section: SectFile},
-		SF_FILE_OPENFLAGS:       &FieldEntry{Map: mapOpenFlags(sfgo.SYSFLOW_SRC, sfgo.FL_FILE_OPENFLAGS_INT), FlatIndex: sfgo.FL_FILE_OPENFLAGS_INT, Type: MapArrayStr, Source: sfgo.SYSFLOW_SRC, Section: SectFile},
-		SF_NET_PROTO:            &FieldEntry{Map: mapInt(sfgo.SYSFLOW_SRC, sfgo.FL_NETW_PROTO_INT), FlatIndex: sfgo.FL_NETW_PROTO_INT, Type: MapIntVal, Source: sfgo.SYSFLOW_SRC, Section: SectNet},
-		SF_NET_SPORT:            &FieldEntry{Map: mapInt(sfgo.SYSFLOW_SRC, sfgo.FL_NETW_SPORT_INT), FlatIndex: sfgo.FL_NETW_SPORT_INT, Type: MapIntVal, Source: sfgo.SYSFLOW_SRC, Section: SectNet},
-		SF_NET_DPORT:            &FieldEntry{Map: mapInt(sfgo.SYSFLOW_SRC, sfgo.FL_NETW_DPORT_INT), FlatIndex: sfgo.FL_NETW_DPORT_INT, Type: MapIntVal, Source: sfgo.SYSFLOW_SRC, Section: SectNet},
-		SF_NET_PORT:             &FieldEntry{Map: mapPort(sfgo.SYSFLOW_SRC, sfgo.FL_NETW_SPORT_INT, sfgo.FL_NETW_DPORT_INT), FlatIndex: sfgo.FL_NETW_SPORT_INT, Type: MapArrayStr, Source: sfgo.SYSFLOW_SRC, Section: SectNet},
-		SF_NET_SIP:              &FieldEntry{Map: mapIP(sfgo.SYSFLOW_SRC, sfgo.FL_NETW_SIP_INT), FlatIndex: sfgo.FL_NETW_SIP_INT, Type: MapSpecialStr, Source: sfgo.SYSFLOW_SRC, Section: SectNet},
-		SF_NET_DIP:              &FieldEntry{Map: mapIP(sfgo.SYSFLOW_SRC, sfgo.FL_NETW_DIP_INT), FlatIndex: sfgo.FL_NETW_DIP_INT, Type: MapSpecialStr, Source: sfgo.SYSFLOW_SRC, Section: SectNet},
-		SF_NET_IP:               &FieldEntry{Map: mapIP(sfgo.SYSFLOW_SRC, sfgo.FL_NETW_SIP_INT, sfgo.FL_NETW_DIP_INT), FlatIndex: sfgo.FL_NETW_SIP_INT, Type: MapArrayStr, Source: sfgo.SYSFLOW_SRC, Section: SectNet},
-		SF_FLOW_RBYTES:          &FieldEntry{Map: mapSum(sfgo.SYSFLOW_SRC, sfgo.FL_FILE_NUMRRECVBYTES_INT, sfgo.FL_NETW_NUMRRECVBYTES_INT), FlatIndex: sfgo.FL_FILE_NUMRRECVBYTES_INT, Type: MapSpecialInt, Source: sfgo.SYSFLOW_SRC, Section: SectFlow},
-		SF_FLOW_ROPS:            &FieldEntry{Map: mapSum(sfgo.SYSFLOW_SRC, sfgo.FL_FILE_NUMRRECVOPS_INT, sfgo.FL_NETW_NUMRRECVOPS_INT), FlatIndex: sfgo.FL_FILE_NUMRRECVOPS_INT, Type: MapSpecialInt, Source: sfgo.SYSFLOW_SRC, Section: SectFlow},
-		SF_FLOW_WBYTES:          &FieldEntry{Map: mapSum(sfgo.SYSFLOW_SRC, sfgo.FL_FILE_NUMWSENDBYTES_INT, sfgo.FL_NETW_NUMWSENDBYTES_INT), FlatIndex: sfgo.FL_FILE_NUMWSENDBYTES_INT, Type: MapSpecialInt, Source: sfgo.SYSFLOW_SRC, Section: SectFlow},
-		SF_FLOW_WOPS:            &FieldEntry{Map: mapSum(sfgo.SYSFLOW_SRC, sfgo.FL_FILE_NUMWSENDOPS_INT, sfgo.FL_NETW_NUMWSENDOPS_INT), FlatIndex: sfgo.FL_FILE_NUMWSENDOPS_INT, Type: MapSpecialInt, Source: sfgo.SYSFLOW_SRC, Section: SectFlow},
-		SF_CONTAINER_ID:         &FieldEntry{Map: mapStr(sfgo.SYSFLOW_SRC, sfgo.CONT_ID_STR), FlatIndex: sfgo.CONT_ID_STR, Type: MapStrVal, Source: sfgo.SYSFLOW_SRC, Section: SectCont},
-		SF_CONTAINER_NAME:       &FieldEntry{Map: mapStr(sfgo.SYSFLOW_SRC, sfgo.CONT_NAME_STR), FlatIndex: sfgo.CONT_NAME_STR, Type: MapStrVal, Source: sfgo.SYSFLOW_SRC, Section: SectCont},
-		SF_CONTAINER_IMAGEID:    &FieldEntry{Map: mapStr(sfgo.SYSFLOW_SRC, sfgo.CONT_IMAGEID_STR), FlatIndex: sfgo.CONT_IMAGEID_STR, Type: MapStrVal, Source: sfgo.SYSFLOW_SRC, Section: SectCont},
-		SF_CONTAINER_IMAGE:      &FieldEntry{Map: mapStr(sfgo.SYSFLOW_SRC, sfgo.CONT_IMAGE_STR), FlatIndex: sfgo.CONT_IMAGE_STR, Type: MapStrVal, Source: sfgo.SYSFLOW_SRC, Section: SectCont},
-		SF_CONTAINER_TYPE:       &FieldEntry{Map: mapContType(sfgo.SYSFLOW_SRC, sfgo.CONT_TYPE_INT), FlatIndex: sfgo.CONT_TYPE_INT, Type: MapSpecialStr, Source: sfgo.SYSFLOW_SRC, Section: SectCont},
-		SF_CONTAINER_PRIVILEGED: &FieldEntry{Map: mapInt(sfgo.SYSFLOW_SRC, sfgo.CONT_PRIVILEGED_INT), FlatIndex: sfgo.CONT_PRIVILEGED_INT, Type: MapIntVal, Source: sfgo.SYSFLOW_SRC, Section: SectCont},
+		SF_PROC_GID:             &FieldEntry{Map: mapInt(sfgo.SYSFLOW_SRC, sfgo.PROC_GID_INT), FlatIndex: sfgo.PROC_GID_INT, Type: MapIntVal, Source: sfgo.SYSFLOW_SRC, Section: SectProc, Present: hasProc(sfgo.SYSFLOW_SRC)},
+		SF_PROC_GROUP:           &FieldEntry{Map: mapStr(sfgo.SYSFLOW_SRC, sfgo.PROC_GROUPNAME_STR), FlatIndex: sfgo.PROC_GROUPNAME_STR, Type: MapStrVal, Source: sfgo.SYSFLOW_SRC, Section: SectProc, Present: hasProc(sfgo.SYSFLOW_SRC)},
+		SF_PROC_CREATETS:        &FieldEntry{Map: mapInt(sfgo.SYSFLOW_SRC, sfgo.PROC_OID_CREATETS_INT), FlatIndex: sfgo.PROC_OID_CREATETS_INT, Type: MapIntVal, Source: sfgo.SYSFLOW_SRC, Section: SectProc, Present: hasProc(sfgo.SYSFLOW_SRC)},
+		SF_PROC_TTY:             &FieldEntry{Map: mapInt(sfgo.SYSFLOW_SRC, sfgo.PROC_TTY_INT), FlatIndex: sfgo.PROC_TTY_INT, Type: MapBoolVal, Source: sfgo.SYSFLOW_SRC, Section: SectProc, Present: hasProc(sfgo.SYSFLOW_SRC)},
+		SF_PROC_ENTRY:           &FieldEntry{Map: mapInt(sfgo.SYSFLOW_SRC, sfgo.PROC_ENTRY_INT), FlatIndex: sfgo.PROC_ENTRY_INT, Type: MapBoolVal, Source: sfgo.SYSFLOW_SRC, Section: SectProc, Present: hasProc(sfgo.SYSFLOW_SRC)},
+		SF_PROC_CMDLINE:         &FieldEntry{Map: mapJoin(sfgo.SYSFLOW_SRC, sfgo.PROC_EXE_STR, sfgo.PROC_EXEARGS_STR), FlatIndex: sfgo.PROC_EXE_STR, Type: MapSpecialStr, Source: sfgo.SYSFLOW_SRC, Section: SectProc, Present: hasProc(sfgo.SYSFLOW_SRC)},
+		SF_PROC_ANAME:           &FieldEntry{Map: mapCachedValue(sfgo.SYSFLOW_SRC, ProcAName), FlatIndex: A_IDS, Type: MapArrayStr, Source: sfgo.SYSFLOW_SRC, Section: SectProc, AuxAttr: ProcAName, Present: hasCachedProcs(sfgo.SYSFLOW_SRC, 0)},
+		SF_PROC_AEXE:            &FieldEntry{Map: mapCachedValue(sfgo.SYSFLOW_SRC, ProcAExe), FlatIndex: A_IDS, Type: MapArrayStr, Source: sfgo.SYSFLOW_SRC, Section: SectProc, AuxAttr: ProcAExe, Present: hasCachedProcs(sfgo.SYSFLOW_SRC, 0)},
+		SF_PROC_ACMDLINE:        &FieldEntry{Map: mapCachedValue(sfgo.SYSFLOW_SRC, ProcACmdLine), FlatIndex: A_IDS, Type: MapArrayStr, Source: sfgo.SYSFLOW_SRC, Section: SectProc, AuxAttr: ProcACmdLine, Present: hasCachedProcs(sfgo.SYSFLOW_SRC, 0)},
+		SF_PROC_APID:            &FieldEntry{Map: mapCachedValue(sfgo.SYSFLOW_SRC, ProcAPID), FlatIndex: A_IDS, Type: MapArrayInt, Source: sfgo.SYSFLOW_SRC, Section: SectProc, AuxAttr: ProcAPID, Present: hasCachedProcs(sfgo.SYSFLOW_SRC, 0)},
+		SF_PPROC_OID:            &FieldEntry{Map: mapOID(sfgo.SYSFLOW_SRC, sfgo.PROC_POID_HPID_INT, sfgo.PROC_POID_CREATETS_INT), FlatIndex: sfgo.PROC_POID_HPID_INT, Type: MapSpecialStr, Source: sfgo.SYSFLOW_SRC, Section: SectPProc, Present: hasPProc(sfgo.SYSFLOW_SRC)},
+		SF_PPROC_PID:            &FieldEntry{Map: mapInt(sfgo.SYSFLOW_SRC, sfgo.PROC_POID_HPID_INT), FlatIndex: sfgo.PROC_POID_HPID_INT, Type: MapIntVal, Source: sfgo.SYSFLOW_SRC, Section: SectPProc, Present: hasPProc(sfgo.SYSFLOW_SRC)},
+		SF_PPROC_NAME:           &FieldEntry{Map: mapCachedValue(sfgo.SYSFLOW_SRC, PProcName), FlatIndex: PARENT_IDS, Type: MapSpecialStr, Source: sfgo.SYSFLOW_SRC, Section: SectPProc, AuxAttr: PProcName, Present: hasCachedProcs(sfgo.SYSFLOW_SRC, 1)},
+		SF_PPROC_EXE:            &FieldEntry{Map: mapCachedValue(sfgo.SYSFLOW_SRC, PProcExe), FlatIndex: PARENT_IDS, Type: MapSpecialStr, Source: sfgo.SYSFLOW_SRC, Section: SectPProc, AuxAttr: PProcExe, Present: hasCachedProcs(sfgo.SYSFLOW_SRC, 1)},
+		SF_PPROC_ARGS:           &FieldEntry{Map: mapCachedValue(sfgo.SYSFLOW_SRC, PProcArgs), FlatIndex: PARENT_IDS, Type: MapSpecialStr, Source: sfgo.SYSFLOW_SRC, Section: SectPProc, AuxAttr: PProcArgs, Present: hasCachedProcs(sfgo.SYSFLOW_SRC, 1)},
+		SF_PPROC_UID:            &FieldEntry{Map: mapCachedValue(sfgo.SYSFLOW_SRC, PProcUID), FlatIndex: PARENT_IDS, Type: MapSpecialInt, Source: sfgo.SYSFLOW_SRC, Section: SectPProc, AuxAttr: PProcUID, Present: hasCachedProcs(sfgo.SYSFLOW_SRC, 1)},
+		SF_PPROC_USER:           &FieldEntry{Map: mapCachedValue(sfgo.SYSFLOW_SRC, PProcUser), FlatIndex: PARENT_IDS, Type: MapSpecialStr, Source: sfgo.SYSFLOW_SRC, Section: SectPProc, AuxAttr: PProcUser, Present: hasCachedProcs(sfgo.SYSFLOW_SRC, 1)},
+		SF_PPROC_GID:            &FieldEntry{Map: mapCachedValue(sfgo.SYSFLOW_SRC, PProcGID), FlatIndex: PARENT_IDS, Type: MapSpecialInt, Source: sfgo.SYSFLOW_SRC, Section: SectPProc, AuxAttr: PProcGID, Present: hasCachedProcs(sfgo.SYSFLOW_SRC, 1)},
+		SF_PPROC_GROUP:          &FieldEntry{Map: mapCachedValue(sfgo.SYSFLOW_SRC, PProcGroup), FlatIndex: PARENT_IDS, Type: MapSpecialStr, Source: sfgo.SYSFLOW_SRC, Section: SectPProc, AuxAttr: PProcGroup, Present: hasCachedProcs(sfgo.SYSFLOW_SRC, 1)},
+		SF_PPROC_CREATETS:       &FieldEntry{Map: mapInt(sfgo.SYSFLOW_SRC, sfgo.PROC_POID_CREATETS_INT), FlatIndex: sfgo.PROC_POID_CREATETS_INT, Type: MapIntVal, Source: sfgo.SYSFLOW_SRC, Section: SectPProc, Present: hasPProc(sfgo.SYSFLOW_SRC)},
+		SF_PPROC_TTY:            &FieldEntry{Map: mapCachedValue(sfgo.SYSFLOW_SRC, PProcTTY), FlatIndex: PARENT_IDS, Type: MapSpecialBool, Source: sfgo.SYSFLOW_SRC, Section: SectPProc, AuxAttr: PProcTTY, Present: hasCachedProcs(sfgo.SYSFLOW_SRC, 1)},
+		SF_PPROC_ENTRY:          &FieldEntry{Map: mapCachedValue(sfgo.SYSFLOW_SRC, PProcEntry), FlatIndex: PARENT_IDS, Type: MapSpecialBool, Source: sfgo.SYSFLOW_SRC, Section: SectPProc, AuxAttr: PProcEntry, Present: hasCachedProcs(sfgo.SYSFLOW_SRC, 1)},
+		SF_PPROC_CMDLINE:        &FieldEntry{Map: mapCachedValue(sfgo.SYSFLOW_SRC, PProcCmdLine), FlatIndex: PARENT_IDS, Type: MapSpecialStr, Source: sfgo.SYSFLOW_SRC, Section: SectPProc, AuxAttr: PProcCmdLine, Present: hasCachedProcs(sfgo.SYSFLOW_SRC, 1)},
+		SF_FILE_NAME:            &FieldEntry{Map: mapName(sfgo.SYSFLOW_SRC, sfgo.FILE_PATH_STR), FlatIndex: sfgo.FILE_PATH_STR, Type: MapSpecialStr, Source: sfgo.SYSFLOW_SRC, Section: SectFile, Present: hasFile(sfgo.SYSFLOW_SRC, flattener.FilePresent, sfgo.FILE_OID_STR)},
+		SF_FILE_PATH:            &FieldEntry{Map: mapPath(sfgo.SYSFLOW_SRC, sfgo.FILE_PATH_STR), FlatIndex: sfgo.FILE_PATH_STR, Type: MapSpecialStr, Source: sfgo.SYSFLOW_SRC, Section: SectFile, Present: hasFile(sfgo.SYSFLOW_SRC, flattener.FilePresent, sfgo.FILE_OID_STR)},
+		SF_FILE_SYMLINK:         &FieldEntry{Map: mapSymlink(sfgo.SYSFLOW_SRC, sfgo.FILE_PATH_STR), FlatIndex: sfgo.FILE_PATH_STR, Type: MapSpecialStr, Source: sfgo.SYSFLOW_SRC, Section: SectFile, Present: hasFile(sfgo.SYSFLOW_SRC, flattener.FilePresent, sfgo.FILE_OID_STR)},
+		SF_FILE_OID:             &FieldEntry{Map: mapStr(sfgo.SYSFLOW_SRC, sfgo.FILE_OID_STR), FlatIndex: sfgo.FILE_OID_STR, Type: MapStrVal, Source: sfgo.SYSFLOW_SRC, Section: SectFile, Present: hasFile(sfgo.SYSFLOW_SRC, flattener.FilePresent, sfgo.FILE_OID_STR)},
+		SF_FILE_DIRECTORY:       &FieldEntry{Map: mapDir(sfgo.SYSFLOW_SRC, sfgo.FILE_PATH_STR), FlatIndex: sfgo.FILE_PATH_STR, Type: MapSpecialStr, Source: sfgo.SYSFLOW_SRC, Section: SectFile, Present: hasFile(sfgo.SYSFLOW_SRC, flattener.FilePresent, sfgo.FILE_OID_STR)},
+		SF_FILE_NEWNAME:         &FieldEntry{Map: mapName(sfgo.SYSFLOW_SRC, sfgo.SEC_FILE_PATH_STR), FlatIndex: sfgo.SEC_FILE_PATH_STR, Type: MapSpecialStr, Source: sfgo.SYSFLOW_SRC, Section: SectFile, Present: hasFile(sfgo.SYSFLOW_SRC, flattener.SecFilePresent, sfgo.SEC_FILE_OID_STR)},
+		SF_FILE_NEWPATH:         &FieldEntry{Map: mapPath(sfgo.SYSFLOW_SRC, sfgo.SEC_FILE_PATH_STR), FlatIndex: sfgo.SEC_FILE_PATH_STR, Type: MapStrVal, Source: sfgo.SYSFLOW_SRC, Section: SectFile, Present: hasFile(sfgo.SYSFLOW_SRC, flattener.SecFilePresent, sfgo.SEC_FILE_OID_STR)},
+		SF_FILE_NEWSYMLINK:      &FieldEntry{Map: mapSymlink(sfgo.SYSFLOW_SRC, sfgo.SEC_FILE_PATH_STR), FlatIndex: sfgo.SEC_FILE_PATH_STR, Type: MapSpecialStr, Source: sfgo.SYSFLOW_SRC, Section: SectFile, Present: hasFile(sfgo.SYSFLOW_SRC, flattener.SecFilePresent, sfgo.SEC_FILE_OID_STR)},
+		SF_FILE_NEWOID:          &FieldEntry{Map: mapStr(sfgo.SYSFLOW_SRC, sfgo.SEC_FILE_OID_STR), FlatIndex: sfgo.SEC_FILE_OID_STR, Type: MapStrVal, Source: sfgo.SYSFLOW_SRC, Section: SectFile, Present: hasFile(sfgo.SYSFLOW_SRC, flattener.SecFilePresent, sfgo.SEC_FILE_OID_STR)},
+		SF_FILE_NEWDIRECTORY:    &FieldEntry{Map: mapDir(sfgo.SYSFLOW_SRC, sfgo.SEC_FILE_PATH_STR), FlatIndex: sfgo.SEC_FILE_PATH_STR, Type: MapSpecialStr, Source: sfgo.SYSFLOW_SRC, Section: SectFile, Present: hasFile(sfgo.SYSFLOW_SRC, flattener.SecFilePresent, sfgo.SEC_FILE_OID_STR)},
+		SF_FILE_TYPE:            &FieldEntry{Map: mapFileType(sfgo.SYSFLOW_SRC, sfgo.FILE_RESTYPE_INT), FlatIndex: sfgo.FILE_RESTYPE_INT, Type: MapSpecialStr, Source: sfgo.SYSFLOW_SRC, Section: SectFile, Present: hasFile(sfgo.SYSFLOW_SRC, flattener.FilePresent, sfgo.FILE_OID_STR)},
+		SF_FILE_IS_OPEN_WRITE:   &FieldEntry{Map: mapIsOpenWrite(sfgo.SYSFLOW_SRC, sfgo.FL_FILE_OPENFLAGS_INT), FlatIndex: sfgo.FL_FILE_OPENFLAGS_INT, Type: MapSpecialBool, Source: sfgo.SYSFLOW_SRC, Section: SectFile, Present: isRecType(sfgo.SYSFLOW_SRC, sfgo.FILE_FLOW)},
+		SF_FILE_IS_OPEN_READ:    &FieldEntry{Map: mapIsOpenRead(sfgo.SYSFLOW_SRC, sfgo.FL_FILE_OPENFLAGS_INT), FlatIndex: sfgo.FL_FILE_OPENFLAGS_INT, Type: MapSpecialBool, Source: sfgo.SYSFLOW_SRC, Section: SectFile, Present: isRecType(sfgo.SYSFLOW_SRC, sfgo.FILE_FLOW)},
+		SF_FILE_FD:              &FieldEntry{Map: mapInt(sfgo.SYSFLOW_SRC, sfgo.FL_FILE_FD_INT), FlatIndex: sfgo.FL_FILE_FD_INT, Type: MapIntVal, Source: sfgo.SYSFLOW_SRC, Section: SectFile, Present: isRecType(sfgo.SYSFLOW_SRC, sfgo.FILE_FLOW)},
+		SF_FILE_OPENFLAGS:       &FieldEntry{Map: mapOpenFlags(sfgo.SYSFLOW_SRC, sfgo.FL_FILE_OPENFLAGS_INT), FlatIndex: sfgo.FL_FILE_OPENFLAGS_INT, Type: MapArrayStr, Source: sfgo.SYSFLOW_SRC, Section: SectFile, Present: isRecType(sfgo.SYSFLOW_SRC, sfgo.FILE_FLOW)},
+		SF_NET_PROTO:            &FieldEntry{Map: mapInt(sfgo.SYSFLOW_SRC, sfgo.FL_NETW_PROTO_INT), FlatIndex: sfgo.FL_NETW_PROTO_INT, Type: MapIntVal, Source: sfgo.SYSFLOW_SRC, Section: SectNet, Present: isRecType(sfgo.SYSFLOW_SRC, sfgo.NET_FLOW)},
+		SF_NET_SPORT:            &FieldEntry{Map: mapInt(sfgo.SYSFLOW_SRC, sfgo.FL_NETW_SPORT_INT), FlatIndex: sfgo.FL_NETW_SPORT_INT, Type: MapIntVal, Source: sfgo.SYSFLOW_SRC, Section: SectNet, Present: isRecType(sfgo.SYSFLOW_SRC, sfgo.NET_FLOW)},
+		SF_NET_DPORT:            &FieldEntry{Map: mapInt(sfgo.SYSFLOW_SRC, sfgo.FL_NETW_DPORT_INT), FlatIndex: sfgo.FL_NETW_DPORT_INT, Type: MapIntVal, Source: sfgo.SYSFLOW_SRC, Section: SectNet, Present: isRecType(sfgo.SYSFLOW_SRC, sfgo.NET_FLOW)},
+		SF_NET_PORT:             &FieldEntry{Map: mapPort(sfgo.SYSFLOW_SRC, sfgo.FL_NETW_SPORT_INT, sfgo.FL_NETW_DPORT_INT), FlatIndex: sfgo.FL_NETW_SPORT_INT, Type: MapArrayStr, Source: sfgo.SYSFLOW_SRC, Section: SectNet, Present: isRecType(sfgo.SYSFLOW_SRC, sfgo.NET_FLOW)},
+		SF_NET_SIP:              &FieldEntry{Map: mapIP(sfgo.SYSFLOW_SRC, sfgo.FL_NETW_SIP_INT), IPs: mapIPs(sfgo.SYSFLOW_SRC, sfgo.FL_NETW_SIP_INT), FlatIndex: sfgo.FL_NETW_SIP_INT, Type: MapSpecialStr, Source: sfgo.SYSFLOW_SRC, Section: SectNet, Present: isRecType(sfgo.SYSFLOW_SRC, sfgo.NET_FLOW)},
+		SF_NET_DIP:              &FieldEntry{Map: mapIP(sfgo.SYSFLOW_SRC, sfgo.FL_NETW_DIP_INT), IPs: mapIPs(sfgo.SYSFLOW_SRC, sfgo.FL_NETW_DIP_INT), FlatIndex: sfgo.FL_NETW_DIP_INT, Type: MapSpecialStr, Source: sfgo.SYSFLOW_SRC, Section: SectNet, Present: isRecType(sfgo.SYSFLOW_SRC, sfgo.NET_FLOW)},
+		SF_NET_IP:               &FieldEntry{Map: mapIP(sfgo.SYSFLOW_SRC, sfgo.FL_NETW_SIP_INT, sfgo.FL_NETW_DIP_INT), IPs: mapIPs(sfgo.SYSFLOW_SRC, sfgo.FL_NETW_SIP_INT, sfgo.FL_NETW_DIP_INT), FlatIndex: sfgo.FL_NETW_SIP_INT, Type: MapArrayStr, Source: sfgo.SYSFLOW_SRC, Section: SectNet, Present: isRecType(sfgo.SYSFLOW_SRC, sfgo.NET_FLOW)},
+		SF_FLOW_RBYTES:          &FieldEntry{Map: mapSum(sfgo.SYSFLOW_SRC, sfgo.FL_FILE_NUMRRECVBYTES_INT, sfgo.FL_NETW_NUMRRECVBYTES_INT), FlatIndex: sfgo.FL_FILE_NUMRRECVBYTES_INT, Type: MapSpecialInt, Source: sfgo.SYSFLOW_SRC, Section: SectFlow, Present: isRecType(sfgo.SYSFLOW_SRC, sfgo.FILE_FLOW, sfgo.NET_FLOW)},
+		SF_FLOW_ROPS:            &FieldEntry{Map: mapSum(sfgo.SYSFLOW_SRC, sfgo.FL_FILE_NUMRRECVOPS_INT, sfgo.FL_NETW_NUMRRECVOPS_INT), FlatIndex: sfgo.FL_FILE_NUMRRECVOPS_INT, Type: MapSpecialInt, Source: sfgo.SYSFLOW_SRC, Section: SectFlow, Present: isRecType(sfgo.SYSFLOW_SRC, sfgo.FILE_FLOW, sfgo.NET_FLOW)},
+		SF_FLOW_WBYTES:          &FieldEntry{Map: mapSum(sfgo.SYSFLOW_SRC, sfgo.FL_FILE_NUMWSENDBYTES_INT, sfgo.FL_NETW_NUMWSENDBYTES_INT), FlatIndex: sfgo.FL_FILE_NUMWSENDBYTES_INT, Type: MapSpecialInt, Source: sfgo.SYSFLOW_SRC, Section: SectFlow, Present: isRecType(sfgo.SYSFLOW_SRC, sfgo.FILE_FLOW, sfgo.NET_FLOW)},
+		SF_FLOW_WOPS:            &FieldEntry{Map: mapSum(sfgo.SYSFLOW_SRC, sfgo.FL_FILE_NUMWSENDOPS_INT, sfgo.FL_NETW_NUMWSENDOPS_INT), FlatIndex: sfgo.FL_FILE_NUMWSENDOPS_INT, Type: MapSpecialInt, Source: sfgo.SYSFLOW_SRC, Section: SectFlow, Present: isRecType(sfgo.SYSFLOW_SRC, sfgo.FILE_FLOW, sfgo.NET_FLOW)},
+		SF_CONTAINER_ID:         &FieldEntry{Map: mapStr(sfgo.SYSFLOW_SRC, sfgo.CONT_ID_STR), FlatIndex: sfgo.CONT_ID_STR, Type: MapStrVal, Source: sfgo.SYSFLOW_SRC, Section: SectCont, Present: hasCont(sfgo.SYSFLOW_SRC)},
+		SF_CONTAINER_NAME:       &FieldEntry{Map: mapStr(sfgo.SYSFLOW_SRC, sfgo.CONT_NAME_STR), FlatIndex: sfgo.CONT_NAME_STR, Type: MapStrVal, Source: sfgo.SYSFLOW_SRC, Section: SectCont, Present: hasCont(sfgo.SYSFLOW_SRC)},
+		SF_CONTAINER_IMAGEID:    &FieldEntry{Map: mapStr(sfgo.SYSFLOW_SRC, sfgo.CONT_IMAGEID_STR), FlatIndex: sfgo.CONT_IMAGEID_STR, Type: MapStrVal, Source: sfgo.SYSFLOW_SRC, Section: SectCont, Present: hasCont(sfgo.SYSFLOW_SRC)},
+		SF_CONTAINER_IMAGE:      &FieldEntry{Map: mapStr(sfgo.SYSFLOW_SRC, sfgo.CONT_IMAGE_STR), FlatIndex: sfgo.CONT_IMAGE_STR, Type: MapStrVal, Source: sfgo.SYSFLOW_SRC, Section: SectCont, Present: hasCont(sfgo.SYSFLOW_SRC)},
+		SF_CONTAINER_TYPE:       &FieldEntry{Map: mapContType(sfgo.SYSFLOW_SRC, sfgo.CONT_TYPE_INT), FlatIndex: sfgo.CONT_TYPE_INT, Type: MapSpecialStr, Source: sfgo.SYSFLOW_SRC, Section: SectCont, Present: hasCont(sfgo.SYSFLOW_SRC)},
+		SF_CONTAINER_PRIVILEGED: &FieldEntry{Map: mapInt(sfgo.SYSFLOW_SRC, sfgo.CONT_PRIVILEGED_INT), FlatIndex: sfgo.CONT_PRIVILEGED_INT, Type: MapIntVal, Source: sfgo.SYSFLOW_SRC, Section: SectCont, Present: hasCont(sfgo.SYSFLOW_SRC)},
 		SF_NODE_ID:              &FieldEntry{Map: mapStr(sfgo.SYSFLOW_SRC, sfgo.SFHE_EXPORTER_STR), FlatIndex: sfgo.SFHE_EXPORTER_STR, Type: MapStrVal, Source: sfgo.SYSFLOW_SRC, Section: SectNode},
 		SF_NODE_IP:              &FieldEntry{Map: mapStr(sfgo.SYSFLOW_SRC, sfgo.SFHE_IP_STR), FlatIndex: sfgo.SFHE_IP_STR, Type: MapStrVal, Source: sfgo.SYSFLOW_SRC, Section: SectNode},
 		SF_SCHEMA_VERSION:       &FieldEntry{Map: mapInt(sfgo.SYSFLOW_SRC, sfgo.SFHE_VERSION_INT), FlatIndex: sfgo.SFHE_VERSION_INT, Type: MapIntVal, Source: sfgo.SYSFLOW_SRC, Section: SectMeta},
@@ -357,65 +471,157 @@ func getExtendedMappers() map[string]FieldMap {
 }
 
 // getNonExportedMappers defines all mappers for non-exported (query-only) attributes.
+// Falco's indexed ancestor names, e.g., proc.aname[2], are defined up to depth maxAncestorDepth.
 func getNonExportedMappers() map[string]*FieldEntry {
-	return map[string]*FieldEntry{
+	mappers := map[string]*FieldEntry{
 		// Falco
-		FALCO_EVT_TYPE:          &FieldEntry{Map: mapOpFlags(sfgo.SYSFLOW_SRC)},
-		FALCO_EVT_RAW_RES:       &FieldEntry{Map: mapRecType(sfgo.SYSFLOW_SRC)},
-		FALCO_EVT_RAW_TIME:      &FieldEntry{Map: mapInt(sfgo.SYSFLOW_SRC, sfgo.TS_INT)},
-		FALCO_EVT_DIR:           &FieldEntry{Map: mapConsts(FALCO_ENTER_EVENT, FALCO_EXIT_EVENT)},
-		FALCO_EVT_IS_OPEN_READ:  &FieldEntry{Map: mapIsOpenRead(sfgo.SYSFLOW_SRC, sfgo.FL_FILE_OPENFLAGS_INT)},
-		FALCO_EVT_IS_OPEN_WRITE: &FieldEntry{Map: mapIsOpenWrite(sfgo.SYSFLOW_SRC, sfgo.FL_FILE_OPENFLAGS_INT)},
-		FALCO_EVT_UID:           &FieldEntry{Map: mapInt(sfgo.SYSFLOW_SRC, sfgo.PROC_UID_INT)},
-		FALCO_FD_TYPECHAR:       &FieldEntry{Map: mapFileType(sfgo.SYSFLOW_SRC, sfgo.FILE_RESTYPE_INT)},
-		FALCO_FD_DIRECTORY:      &FieldEntry{Map: mapDir(sfgo.SYSFLOW_SRC, sfgo.FILE_PATH_STR)},
-		FALCO_FD_NAME:           &FieldEntry{Map: mapName(sfgo.SYSFLOW_SRC, sfgo.FILE_PATH_STR)},
-		FALCO_FD_FILENAME:       &FieldEntry{Map: mapName(sfgo.SYSFLOW_SRC, sfgo.FILE_PATH_STR)},
-		FALCO_FD_PROTO:          &FieldEntry{Map: mapInt(sfgo.SYSFLOW_SRC, sfgo.FL_NETW_PROTO_INT)},
-		FALCO_FD_LPROTO:         &FieldEntry{Map: mapInt(sfgo.SYSFLOW_SRC, sfgo.FL_NETW_PROTO_INT)},
-		FALCO_FD_L4PROTO:        &FieldEntry{Map: mapInt(sfgo.SYSFLOW_SRC, sfgo.FL_NETW_PROTO_INT)},
-		FALCO_FD_RPROTO:         &FieldEntry{Map: mapInt(sfgo.SYSFLOW_SRC, sfgo.FL_NETW_PROTO_INT)},
-		FALCO_FD_SPROTO:         &FieldEntry{Map: mapInt(sfgo.SYSFLOW_SRC, sfgo.FL_NETW_PROTO_INT)},
-		FALCO_FD_CPROTO:         &FieldEntry{Map: mapInt(sfgo.SYSFLOW_SRC, sfgo.FL_NETW_PROTO_INT)},
-		FALCO_FD_SPORT:          &FieldEntry{Map: mapInt(sfgo.SYSFLOW_SRC, sfgo.FL_NETW_SPORT_INT)},
-		FALCO_FD_DPORT:          &FieldEntry{Map: mapInt(sfgo.SYSFLOW_SRC, sfgo.FL_NETW_DPORT_INT)},
-		FALCO_FD_SIP:            &FieldEntry{Map: mapIP(sfgo.SYSFLOW_SRC, sfgo.FL_NETW_SIP_INT)},
-		FALCO_FD_DIP:            &FieldEntry{Map: mapIP(sfgo.SYSFLOW_SRC, sfgo.FL_NETW_DIP_INT)},
-		FALCO_FD_IP:             &FieldEntry{Map: mapIP(sfgo.SYSFLOW_SRC, sfgo.FL_NETW_SIP_INT, sfgo.FL_NETW_DIP_INT)},
-		FALCO_FD_PORT:           &FieldEntry{Map: mapPort(sfgo.SYSFLOW_SRC, sfgo.FL_NETW_SPORT_INT, sfgo.FL_NETW_DPORT_INT)},
-		FALCO_FD_NUM:            &FieldEntry{Map: mapInt(sfgo.SYSFLOW_SRC, sfgo.FL_FILE_FD_INT)},
-		FALCO_USER_NAME:         &FieldEntry{Map: mapStr(sfgo.SYSFLOW_SRC, sfgo.PROC_USERNAME_STR)},
-		FALCO_PROC_PID:          &FieldEntry{Map: mapInt(sfgo.SYSFLOW_SRC, sfgo.PROC_OID_HPID_INT)},
-		FALCO_PROC_TID:          &FieldEntry{Map: mapInt(sfgo.SYSFLOW_SRC, sfgo.TID_INT)},
-		FALCO_PROC_GID:          &FieldEntry{Map: mapInt(sfgo.SYSFLOW_SRC, sfgo.PROC_GID_INT)},
-		FALCO_PROC_UID:          &FieldEntry{Map: mapInt(sfgo.SYSFLOW_SRC, sfgo.PROC_UID_INT)},
-		FALCO_PROC_GROUP:        &FieldEntry{Map: mapStr(sfgo.SYSFLOW_SRC, sfgo.PROC_GROUPNAME_STR)},
-		FALCO_PROC_TTY:          &FieldEntry{Map: mapCachedValue(sfgo.SYSFLOW_SRC, PProcTTY)},
-		FALCO_PROC_USER:         &FieldEntry{Map: mapStr(sfgo.SYSFLOW_SRC, sfgo.PROC_USERNAME_STR)},
-		FALCO_PROC_EXE:          &FieldEntry{Map: mapStr(sfgo.SYSFLOW_SRC, sfgo.PROC_EXE_STR)},
-		FALCO_PROC_NAME:         &FieldEntry{Map: mapName(sfgo.SYSFLOW_SRC, sfgo.PROC_EXE_STR)},
-		FALCO_PROC_ARGS:         &FieldEntry{Map: mapStr(sfgo.SYSFLOW_SRC, sfgo.PROC_EXEARGS_STR)},
-		FALCO_PROC_CREATE_TIME:  &FieldEntry{Map: mapInt(sfgo.SYSFLOW_SRC, sfgo.PROC_POID_CREATETS_INT)},
-		FALCO_PROC_CMDLINE:      &FieldEntry{Map: mapJoin(sfgo.SYSFLOW_SRC, sfgo.PROC_EXE_STR, sfgo.PROC_EXEARGS_STR)},
-		FALCO_PROC_ANAME:        &FieldEntry{Map: mapCachedValue(sfgo.SYSFLOW_SRC, ProcAName)},
-		FALCO_PROC_APID:         &FieldEntry{Map: mapCachedValue(sfgo.SYSFLOW_SRC, ProcAPID)},
-		FALCO_PROC_PPID:         &FieldEntry{Map: mapInt(sfgo.SYSFLOW_SRC, sfgo.PROC_POID_HPID_INT)},
-		FALCO_PROC_PGID:         &FieldEntry{Map: mapCachedValue(sfgo.SYSFLOW_SRC, PProcGID)},
-		FALCO_PROC_PUID:         &FieldEntry{Map: mapCachedValue(sfgo.SYSFLOW_SRC, PProcUID)},
-		FALCO_PROC_PGROUP:       &FieldEntry{Map: mapCachedValue(sfgo.SYSFLOW_SRC, PProcGroup)},
-		FALCO_PROC_PTTY:         &FieldEntry{Map: mapCachedValue(sfgo.SYSFLOW_SRC, PProcTTY)},
-		FALCO_PROC_PUSER:        &FieldEntry{Map: mapCachedValue(sfgo.SYSFLOW_SRC, PProcUser)},
-		FALCO_PROC_PEXE:         &FieldEntry{Map: mapCachedValue(sfgo.SYSFLOW_SRC, PProcExe)},
-		FALCO_PROC_PARGS:        &FieldEntry{Map: mapCachedValue(sfgo.SYSFLOW_SRC, PProcArgs)},
-		FALCO_PROC_PCREATE_TIME: &FieldEntry{Map: mapInt(sfgo.SYSFLOW_SRC, sfgo.PROC_POID_CREATETS_INT)},
-		FALCO_PROC_PNAME:        &FieldEntry{Map: mapCachedValue(sfgo.SYSFLOW_SRC, PProcName)},
-		FALCO_PROC_PCMDLINE:     &FieldEntry{Map: mapCachedValue(sfgo.SYSFLOW_SRC, PProcCmdLine)},
-		FALCO_CONT_ID:           &FieldEntry{Map: mapStr(sfgo.SYSFLOW_SRC, sfgo.CONT_ID_STR)},
-		FALCO_CONT_IMAGE_ID:     &FieldEntry{Map: mapStr(sfgo.SYSFLOW_SRC, sfgo.CONT_IMAGEID_STR)},
-		FALCO_CONT_IMAGE:        &FieldEntry{Map: mapStr(sfgo.SYSFLOW_SRC, sfgo.CONT_IMAGE_STR)},
-		FALCO_CONT_NAME:         &FieldEntry{Map: mapStr(sfgo.SYSFLOW_SRC, sfgo.CONT_NAME_STR)},
-		FALCO_CONT_TYPE:         &FieldEntry{Map: mapContType(sfgo.SYSFLOW_SRC, sfgo.CONT_TYPE_INT)},
-		FALCO_CONT_PRIVILEGED:   &FieldEntry{Map: mapInt(sfgo.SYSFLOW_SRC, sfgo.CONT_PRIVILEGED_INT)},
+		FALCO_EVT_TYPE:              &FieldEntry{Map: mapOpFlags(sfgo.SYSFLOW_SRC), Type: MapArrayStr},
+		FALCO_EVT_RAW_RES:           &FieldEntry{Map: mapRet(sfgo.SYSFLOW_SRC), Type: MapSpecialInt},
+		FALCO_EVT_RAW_TIME:          &FieldEntry{Map: mapInt(sfgo.SYSFLOW_SRC, sfgo.TS_INT), Type: MapIntVal},
+		FALCO_EVT_DIR:               &FieldEntry{Map: mapConsts(FALCO_ENTER_EVENT, FALCO_EXIT_EVENT), Type: MapArrayStr},
+		FALCO_EVT_IS_OPEN_READ:      &FieldEntry{Map: mapIsOpenRead(sfgo.SYSFLOW_SRC, sfgo.FL_FILE_OPENFLAGS_INT), Type: MapSpecialBool, Present: isRecType(sfgo.SYSFLOW_SRC, sfgo.FILE_FLOW)},
+		FALCO_EVT_IS_OPEN_WRITE:     &FieldEntry{Map: mapIsOpenWrite(sfgo.SYSFLOW_SRC, sfgo.FL_FILE_OPENFLAGS_INT), Type: MapSpecialBool, Present: isRecType(sfgo.SYSFLOW_SRC, sfgo.FILE_FLOW)},
+		FALCO_EVT_NAME:              &FieldEntry{Map: mapPath(sfgo.SYSFLOW_SRC, sfgo.FILE_PATH_STR), Type: MapStrVal, Present: hasFile(sfgo.SYSFLOW_SRC, flattener.FilePresent, sfgo.FILE_OID_STR)},
+		FALCO_EVT_PATH:              &FieldEntry{Map: mapPath(sfgo.SYSFLOW_SRC, sfgo.FILE_PATH_STR), Type: MapStrVal, Present: hasFile(sfgo.SYSFLOW_SRC, flattener.FilePresent, sfgo.FILE_OID_STR)},
+		FALCO_EVT_FILENAME:          &FieldEntry{Map: mapPath(sfgo.SYSFLOW_SRC, sfgo.FILE_PATH_STR), Type: MapStrVal, Present: hasFile(sfgo.SYSFLOW_SRC, flattener.FilePresent, sfgo.FILE_OID_STR)},
+		FALCO_EVT_OLDPATH:           &FieldEntry{Map: mapPath(sfgo.SYSFLOW_SRC, sfgo.FILE_PATH_STR), Type: MapStrVal, Present: hasFile(sfgo.SYSFLOW_SRC, flattener.FilePresent, sfgo.FILE_OID_STR)},
+		FALCO_EVT_TARGET:            &FieldEntry{Map: mapPath(sfgo.SYSFLOW_SRC, sfgo.FILE_PATH_STR), Type: MapStrVal, Present: hasFile(sfgo.SYSFLOW_SRC, flattener.FilePresent, sfgo.FILE_OID_STR)},
+		FALCO_EVT_NEWPATH:           &FieldEntry{Map: mapPath(sfgo.SYSFLOW_SRC, sfgo.SEC_FILE_PATH_STR), Type: MapStrVal, Present: hasFile(sfgo.SYSFLOW_SRC, flattener.SecFilePresent, sfgo.SEC_FILE_OID_STR)},
+		FALCO_EVT_FLAGS:             &FieldEntry{Map: mapFalcoOpenFlags(sfgo.SYSFLOW_SRC, sfgo.FL_FILE_OPENFLAGS_INT), Type: MapArrayStr, Present: isRecType(sfgo.SYSFLOW_SRC, sfgo.FILE_FLOW)},
+		FALCO_EVT_UID:               &FieldEntry{Map: mapStr(sfgo.SYSFLOW_SRC, sfgo.PROC_USERNAME_STR), Type: MapStrVal, Present: hasProc(sfgo.SYSFLOW_SRC)},
+		FALCO_FD_TYPECHAR:           &FieldEntry{Map: mapFileType(sfgo.SYSFLOW_SRC, sfgo.FILE_RESTYPE_INT), Type: MapSpecialStr, Present: hasFile(sfgo.SYSFLOW_SRC, flattener.FilePresent, sfgo.FILE_OID_STR)},
+		FALCO_FD_TYPE:               &FieldEntry{Map: mapFalcoFileType(sfgo.SYSFLOW_SRC, sfgo.FILE_RESTYPE_INT), Type: MapSpecialStr, Present: hasFile(sfgo.SYSFLOW_SRC, flattener.FilePresent, sfgo.FILE_OID_STR)},
+		FALCO_FD_SOCKFAMILY:         &FieldEntry{Map: mapSockFamily(sfgo.SYSFLOW_SRC, sfgo.FILE_RESTYPE_INT), Type: MapSpecialStr, Present: hasFile(sfgo.SYSFLOW_SRC, flattener.FilePresent, sfgo.FILE_OID_STR)},
+		FALCO_FD_DIRECTORY:          &FieldEntry{Map: mapDir(sfgo.SYSFLOW_SRC, sfgo.FILE_PATH_STR), Type: MapSpecialStr, Present: hasFile(sfgo.SYSFLOW_SRC, flattener.FilePresent, sfgo.FILE_OID_STR)},
+		FALCO_FD_NAME:               &FieldEntry{Map: mapName(sfgo.SYSFLOW_SRC, sfgo.FILE_PATH_STR), Type: MapSpecialStr, Present: hasFile(sfgo.SYSFLOW_SRC, flattener.FilePresent, sfgo.FILE_OID_STR)},
+		FALCO_FD_FILENAME:           &FieldEntry{Map: mapName(sfgo.SYSFLOW_SRC, sfgo.FILE_PATH_STR), Type: MapSpecialStr, Present: hasFile(sfgo.SYSFLOW_SRC, flattener.FilePresent, sfgo.FILE_OID_STR)},
+		FALCO_FD_PROTO:              &FieldEntry{Map: mapInt(sfgo.SYSFLOW_SRC, sfgo.FL_NETW_PROTO_INT), Type: MapIntVal, Present: isRecType(sfgo.SYSFLOW_SRC, sfgo.NET_FLOW)},
+		FALCO_FD_LPROTO:             &FieldEntry{Map: mapInt(sfgo.SYSFLOW_SRC, sfgo.FL_NETW_PROTO_INT), Type: MapIntVal, Present: isRecType(sfgo.SYSFLOW_SRC, sfgo.NET_FLOW)},
+		FALCO_FD_L4PROTO:            &FieldEntry{Map: mapProto(sfgo.SYSFLOW_SRC, sfgo.FL_NETW_PROTO_INT), Type: MapSpecialStr, Present: isRecType(sfgo.SYSFLOW_SRC, sfgo.NET_FLOW)},
+		FALCO_FD_RPROTO:             &FieldEntry{Map: mapInt(sfgo.SYSFLOW_SRC, sfgo.FL_NETW_PROTO_INT), Type: MapIntVal, Present: isRecType(sfgo.SYSFLOW_SRC, sfgo.NET_FLOW)},
+		FALCO_FD_SPROTO:             &FieldEntry{Map: mapInt(sfgo.SYSFLOW_SRC, sfgo.FL_NETW_PROTO_INT), Type: MapIntVal, Present: isRecType(sfgo.SYSFLOW_SRC, sfgo.NET_FLOW)},
+		FALCO_FD_CPROTO:             &FieldEntry{Map: mapInt(sfgo.SYSFLOW_SRC, sfgo.FL_NETW_PROTO_INT), Type: MapIntVal, Present: isRecType(sfgo.SYSFLOW_SRC, sfgo.NET_FLOW)},
+		FALCO_FD_SPORT:              &FieldEntry{Map: mapInt(sfgo.SYSFLOW_SRC, sfgo.FL_NETW_SPORT_INT), Type: MapIntVal, Present: isRecType(sfgo.SYSFLOW_SRC, sfgo.NET_FLOW)},
+		FALCO_FD_DPORT:              &FieldEntry{Map: mapInt(sfgo.SYSFLOW_SRC, sfgo.FL_NETW_DPORT_INT), Type: MapIntVal, Present: isRecType(sfgo.SYSFLOW_SRC, sfgo.NET_FLOW)},
+		FALCO_FD_SIP:                &FieldEntry{Map: mapIP(sfgo.SYSFLOW_SRC, sfgo.FL_NETW_SIP_INT), IPs: mapIPs(sfgo.SYSFLOW_SRC, sfgo.FL_NETW_SIP_INT), Type: MapSpecialStr, Present: isRecType(sfgo.SYSFLOW_SRC, sfgo.NET_FLOW)},
+		FALCO_FD_DIP:                &FieldEntry{Map: mapIP(sfgo.SYSFLOW_SRC, sfgo.FL_NETW_DIP_INT), IPs: mapIPs(sfgo.SYSFLOW_SRC, sfgo.FL_NETW_DIP_INT), Type: MapSpecialStr, Present: isRecType(sfgo.SYSFLOW_SRC, sfgo.NET_FLOW)},
+		FALCO_FD_IP:                 &FieldEntry{Map: mapIP(sfgo.SYSFLOW_SRC, sfgo.FL_NETW_SIP_INT, sfgo.FL_NETW_DIP_INT), IPs: mapIPs(sfgo.SYSFLOW_SRC, sfgo.FL_NETW_SIP_INT, sfgo.FL_NETW_DIP_INT), Type: MapArrayStr, Present: isRecType(sfgo.SYSFLOW_SRC, sfgo.NET_FLOW)},
+		FALCO_FD_PORT:               &FieldEntry{Map: mapPort(sfgo.SYSFLOW_SRC, sfgo.FL_NETW_SPORT_INT, sfgo.FL_NETW_DPORT_INT), Type: MapArrayStr, Present: isRecType(sfgo.SYSFLOW_SRC, sfgo.NET_FLOW)},
+		FALCO_FD_NUM:                &FieldEntry{Map: mapInt(sfgo.SYSFLOW_SRC, sfgo.FL_FILE_FD_INT), Type: MapIntVal, Present: isRecType(sfgo.SYSFLOW_SRC, sfgo.FILE_FLOW)},
+		FALCO_USER_NAME:             &FieldEntry{Map: mapStr(sfgo.SYSFLOW_SRC, sfgo.PROC_USERNAME_STR), Type: MapStrVal, Present: hasProc(sfgo.SYSFLOW_SRC)},
+		FALCO_PROC_PID:              &FieldEntry{Map: mapInt(sfgo.SYSFLOW_SRC, sfgo.PROC_OID_HPID_INT), Type: MapIntVal, Present: hasProc(sfgo.SYSFLOW_SRC)},
+		FALCO_PROC_TID:              &FieldEntry{Map: mapInt(sfgo.SYSFLOW_SRC, sfgo.TID_INT), Type: MapIntVal},
+		FALCO_PROC_GID:              &FieldEntry{Map: mapInt(sfgo.SYSFLOW_SRC, sfgo.PROC_GID_INT), Type: MapIntVal, Present: hasProc(sfgo.SYSFLOW_SRC)},
+		FALCO_PROC_UID:              &FieldEntry{Map: mapInt(sfgo.SYSFLOW_SRC, sfgo.PROC_UID_INT), Type: MapIntVal, Present: hasProc(sfgo.SYSFLOW_SRC)},
+		FALCO_PROC_GROUP:            &FieldEntry{Map: mapStr(sfgo.SYSFLOW_SRC, sfgo.PROC_GROUPNAME_STR), Type: MapStrVal, Present: hasProc(sfgo.SYSFLOW_SRC)},
+		FALCO_PROC_TTY:              &FieldEntry{Map: mapCachedValue(sfgo.SYSFLOW_SRC, PProcTTY), Type: MapSpecialBool, Present: hasCachedProcs(sfgo.SYSFLOW_SRC, 1)},
+		FALCO_PROC_USER:             &FieldEntry{Map: mapStr(sfgo.SYSFLOW_SRC, sfgo.PROC_USERNAME_STR), Type: MapStrVal, Present: hasProc(sfgo.SYSFLOW_SRC)},
+		FALCO_PROC_EXE:              &FieldEntry{Map: mapStr(sfgo.SYSFLOW_SRC, sfgo.PROC_EXE_STR), Type: MapStrVal, Present: hasProc(sfgo.SYSFLOW_SRC)},
+		FALCO_PROC_NAME:             &FieldEntry{Map: mapName(sfgo.SYSFLOW_SRC, sfgo.PROC_EXE_STR), Type: MapSpecialStr, Present: hasProc(sfgo.SYSFLOW_SRC)},
+		FALCO_PROC_ARGS:             &FieldEntry{Map: mapStr(sfgo.SYSFLOW_SRC, sfgo.PROC_EXEARGS_STR), Type: MapStrVal, Present: hasProc(sfgo.SYSFLOW_SRC)},
+		FALCO_PROC_CREATE_TIME:      &FieldEntry{Map: mapInt(sfgo.SYSFLOW_SRC, sfgo.PROC_POID_CREATETS_INT), Type: MapIntVal, Present: hasPProc(sfgo.SYSFLOW_SRC)},
+		FALCO_PROC_CMDLINE:          &FieldEntry{Map: mapJoin(sfgo.SYSFLOW_SRC, sfgo.PROC_EXE_STR, sfgo.PROC_EXEARGS_STR), Type: MapSpecialStr, Present: hasProc(sfgo.SYSFLOW_SRC)},
+		FALCO_PROC_DURATION:         &FieldEntry{Map: mapDuration(sfgo.SYSFLOW_SRC, sfgo.PROC_OID_CREATETS_INT), Type: MapSpecialInt, Present: hasProc(sfgo.SYSFLOW_SRC)},
+		FALCO_PROC_ANAME:            &FieldEntry{Map: mapCachedValue(sfgo.SYSFLOW_SRC, ProcAName), Type: MapArrayStr, Present: hasCachedProcs(sfgo.SYSFLOW_SRC, 0)},
+		FALCO_PROC_APID:             &FieldEntry{Map: mapCachedValue(sfgo.SYSFLOW_SRC, ProcAPID), Type: MapArrayInt, Present: hasCachedProcs(sfgo.SYSFLOW_SRC, 0)},
+		FALCO_PROC_PPID:             &FieldEntry{Map: mapInt(sfgo.SYSFLOW_SRC, sfgo.PROC_POID_HPID_INT), Type: MapIntVal, Present: hasPProc(sfgo.SYSFLOW_SRC)},
+		FALCO_PROC_PGID:             &FieldEntry{Map: mapCachedValue(sfgo.SYSFLOW_SRC, PProcGID), Type: MapSpecialInt, Present: hasCachedProcs(sfgo.SYSFLOW_SRC, 1)},
+		FALCO_PROC_PUID:             &FieldEntry{Map: mapCachedValue(sfgo.SYSFLOW_SRC, PProcUID), Type: MapSpecialInt, Present: hasCachedProcs(sfgo.SYSFLOW_SRC, 1)},
+		FALCO_PROC_PGROUP:           &FieldEntry{Map: mapCachedValue(sfgo.SYSFLOW_SRC, PProcGroup), Type: MapSpecialStr, Present: hasCachedProcs(sfgo.SYSFLOW_SRC, 1)},
+		FALCO_PROC_PTTY:             &FieldEntry{Map: mapCachedValue(sfgo.SYSFLOW_SRC, PProcTTY), Type: MapSpecialBool, Present: hasCachedProcs(sfgo.SYSFLOW_SRC, 1)},
+		FALCO_PROC_PUSER:            &FieldEntry{Map: mapCachedValue(sfgo.SYSFLOW_SRC, PProcUser), Type: MapSpecialStr, Present: hasCachedProcs(sfgo.SYSFLOW_SRC, 1)},
+		FALCO_PROC_PEXE:             &FieldEntry{Map: mapCachedValue(sfgo.SYSFLOW_SRC, PProcExe), Type: MapSpecialStr, Present: hasCachedProcs(sfgo.SYSFLOW_SRC, 1)},
+		FALCO_PROC_PARGS:            &FieldEntry{Map: mapCachedValue(sfgo.SYSFLOW_SRC, PProcArgs), Type: MapSpecialStr, Present: hasCachedProcs(sfgo.SYSFLOW_SRC, 1)},
+		FALCO_PROC_PCREATE_TIME:     &FieldEntry{Map: mapInt(sfgo.SYSFLOW_SRC, sfgo.PROC_POID_CREATETS_INT), Type: MapIntVal, Present: hasPProc(sfgo.SYSFLOW_SRC)},
+		FALCO_PROC_PNAME:            &FieldEntry{Map: mapCachedValue(sfgo.SYSFLOW_SRC, PProcName), Type: MapSpecialStr, Present: hasCachedProcs(sfgo.SYSFLOW_SRC, 1)},
+		FALCO_PROC_PCMDLINE:         &FieldEntry{Map: mapCachedValue(sfgo.SYSFLOW_SRC, PProcCmdLine), Type: MapSpecialStr, Present: hasCachedProcs(sfgo.SYSFLOW_SRC, 1)},
+		FALCO_CONT_ID:               &FieldEntry{Map: mapStr(sfgo.SYSFLOW_SRC, sfgo.CONT_ID_STR), Type: MapStrVal, Present: hasCont(sfgo.SYSFLOW_SRC)},
+		FALCO_CONT_IMAGE_ID:         &FieldEntry{Map: mapStr(sfgo.SYSFLOW_SRC, sfgo.CONT_IMAGEID_STR), Type: MapStrVal, Present: hasCont(sfgo.SYSFLOW_SRC)},
+		FALCO_CONT_IMAGE_REPOSITORY: &FieldEntry{Map: mapRepo(sfgo.SYSFLOW_SRC, sfgo.CONT_IMAGE_STR), Type: MapSpecialStr, Present: hasCont(sfgo.SYSFLOW_SRC)},
+		FALCO_CONT_IMAGE:            &FieldEntry{Map: mapStr(sfgo.SYSFLOW_SRC, sfgo.CONT_IMAGE_STR), Type: MapStrVal, Present: hasCont(sfgo.SYSFLOW_SRC)},
+		FALCO_CONT_NAME:             &FieldEntry{Map: mapStr(sfgo.SYSFLOW_SRC, sfgo.CONT_NAME_STR), Type: MapStrVal, Present: hasCont(sfgo.SYSFLOW_SRC)},
+		FALCO_CONT_TYPE:             &FieldEntry{Map: mapContType(sfgo.SYSFLOW_SRC, sfgo.CONT_TYPE_INT), Type: MapSpecialStr, Present: hasCont(sfgo.SYSFLOW_SRC)},
+		FALCO_CONT_PRIVILEGED:       &FieldEntry{Map: mapInt(sfgo.SYSFLOW_SRC, sfgo.CONT_PRIVILEGED_INT), Type: MapBoolVal, Present: hasCont(sfgo.SYSFLOW_SRC)},
+	}
+	for depth := 0; depth <= maxAncestorDepth; depth++ {
+		mappers[fmt.Sprintf("%s[%d]", FALCO_PROC_ANAME, depth)] = &FieldEntry{Map: mapAncestorName(sfgo.SYSFLOW_SRC, depth), Type: MapSpecialStr, Present: hasCachedProcs(sfgo.SYSFLOW_SRC, depth)}
+	}
+	return mappers
+}
+
+// presence returns the entity presence bitmap of source src of a record, and whether the record has one.
+// Flat records have bitmaps if they come from the flattener.
+func presence(r *Record, src sfgo.Source) (int64, bool) {
+	for idx, s := range r.Fr.Sources {
+		if s == src && len(r.Fr.Ints[idx]) > int(flattener.PresenceIdx) {
+			return r.Fr.Ints[idx][flattener.PresenceIdx], true
+		}
+	}
+	return 0, false
+}
+
+// hasProc indicates whether a record has a process. Records without presence bitmaps are assumed to have
+// processes if their OIDs are not zero.
+func hasProc(src sfgo.Source) BoolFieldMap {
+	return func(r *Record) bool {
+		if p, ok := presence(r, src); ok {
+			return p&flattener.ProcPresent != 0
+		}
+		return r.GetInt(sfgo.PROC_OID_HPID_INT, src) != 0 || r.GetInt(sfgo.PROC_OID_CREATETS_INT, src) != 0
+	}
+}
+
+// hasPProc indicates whether the process of a record has a parent process.
+func hasPProc(src sfgo.Source) BoolFieldMap {
+	return func(r *Record) bool {
+		if p, ok := presence(r, src); ok {
+			return p&flattener.PProcPresent != 0
+		}
+		return r.GetInt(sfgo.PROC_POID_HPID_INT, src) != 0 || r.GetInt(sfgo.PROC_POID_CREATETS_INT, src) != 0
+	}
+}
+
+// hasCachedProcs indicates whether more than depth processes of the process tree of a record are cached.
+func hasCachedProcs(src sfgo.Source, depth int) BoolFieldMap {
+	return func(r *Record) bool {
+		if r.Cr == nil {
+			return false
+		}
+		oid := sfgo.OID{CreateTS: r.GetInt(sfgo.PROC_OID_CREATETS_INT, src), Hpid: r.GetInt(sfgo.PROC_OID_HPID_INT, src)}
+		return len(r.MemoizePtree(oid)) > depth
+	}
+}
+
+// hasFile indicates whether a record has the file with presence bit bit, identified by OID attribute attr.
+func hasFile(src sfgo.Source, bit int64, attr sfgo.Attribute) BoolFieldMap {
+	return func(r *Record) bool {
+		if p, ok := presence(r, src); ok {
+			return p&bit != 0
+		}
+		return r.GetStr(attr, src) != sfgo.Zeros.String
+	}
+}
+
+// hasCont indicates whether a record has a container.
+func hasCont(src sfgo.Source) BoolFieldMap {
+	return func(r *Record) bool {
+		if p, ok := presence(r, src); ok {
+			return p&flattener.ContPresent != 0
+		}
+		return r.GetStr(sfgo.CONT_ID_STR, src) != sfgo.Zeros.String
+	}
+}
+
+// isRecType indicates whether a record is of one of types rtypes.
+func isRecType(src sfgo.Source, rtypes ...int64) BoolFieldMap {
+	return func(r *Record) bool {
+		rtype := r.GetInt(sfgo.SF_REC_TYPE, src)
+		for _, t := range rtypes {
+			if rtype == t {
+				return true
+			}
+		}
+		return false
 	}
 }
 
@@ -516,10 +722,18 @@ func mapDir(src sfgo.Source, attr sfgo.Attribute) FieldMap {
 	}
 }
 
-// nolint
+// mapRepo maps an image name to its repository, i.e., the name without tag and digest.
+// Registry ports, as in registry:5000/image:tag, are part of the repository.
 func mapRepo(src sfgo.Source, attr sfgo.Attribute) FieldMap {
 	return func(r *Record) interface{} {
-		return strings.Split(r.GetStr(attr, src), ":")[0]
+		repo := r.GetStr(attr, src)
+		if i := strings.Index(repo, "@"); i >= 0 {
+			repo = repo[:i]
+		}
+		if i := strings.LastIndex(repo, ":"); i > strings.LastIndex(repo, "/") {
+			repo = repo[:i]
+		}
+		return repo
 	}
 }
 
@@ -543,6 +757,42 @@ func mapFileType(src sfgo.Source, attr sfgo.Attribute) FieldMap {
 	}
 }
 
+// fileTypeNames maps file resource types to the names of Falco's fd.type.
+var fileTypeNames = map[string]string{
+	"f": "file",
+	"d": "directory",
+	"4": "ipv4",
+	"6": "ipv6",
+	"u": "unix",
+	"p": "pipe",
+	"e": "event",
+	"s": "signalfd",
+	"l": "eventpoll",
+	"i": "inotify",
+	"t": "timerfd",
+	"n": "netlink",
+}
+
+// mapFalcoFileType maps a file resource type to its name in Falco's fd.type, e.g., ipv4.
+func mapFalcoFileType(src sfgo.Source, attr sfgo.Attribute) FieldMap {
+	return func(r *Record) interface{} {
+		return fileTypeNames[sfgo.GetFileType(r.GetInt(attr, src))]
+	}
+}
+
+// mapSockFamily maps a file resource type to the socket family of Falco's fd.sockfamily, i.e., ip or unix.
+func mapSockFamily(src sfgo.Source, attr sfgo.Attribute) FieldMap {
+	return func(r *Record) interface{} {
+		switch sfgo.GetFileType(r.GetInt(attr, src)) {
+		case "4", "6":
+			return "ip"
+		case "u":
+			return "unix"
+		}
+		return sfgo.Zeros.String
+	}
+}
+
 func mapIsOpenWrite(src sfgo.Source, attr sfgo.Attribute) FieldMap {
 	return func(r *Record) interface{} {
 		return sfgo.IsOpenWrite(r.GetInt(attr, src))
@@ -561,7 +811,18 @@ func mapOpenFlags(src sfgo.Source, attr sfgo.Attribute) FieldMap {
 	}
 }
 
-// nolint
+// mapFalcoOpenFlags maps open flags to their names in Falco's evt.arg.flags, e.g., O_CREAT.
+func mapFalcoOpenFlags(src sfgo.Source, attr sfgo.Attribute) FieldMap {
+	return func(r *Record) interface{} {
+		flags := sfgo.GetOpenFlags(r.GetInt(attr, src))
+		names := make([]string, len(flags))
+		for i, f := range flags {
+			names[i] = "O_" + f
+		}
+		return strings.Join(names, LISTSEP)
+	}
+}
+
 func mapProto(src sfgo.Source, attr sfgo.Attribute) FieldMap {
 	return func(r *Record) interface{} {
 		return sfgo.GetProto(r.GetInt(attr, src))
@@ -588,6 +849,17 @@ func mapIP(src sfgo.Source, attrs ...sfgo.Attribute) FieldMap {
 	}
 }
 
+func mapIPs(src sfgo.Source, attrs ...sfgo.Attribute) IPFieldMap {
+	return func(r *Record) []uint32 {
+		var ips = make([]uint32, 0, len(attrs))
+		for _, attr := range attrs {
+			ip := uint32(r.GetInt(attr, src))
+			ips = append(ips, ip<<24|ip<<8&0xFF0000|ip>>8&0xFF00|ip>>24)
+		}
+		return ips
+	}
+}
+
 func mapContType(src sfgo.Source, attr sfgo.Attribute) FieldMap {
 	return func(r *Record) interface{} {
 		return sfgo.GetContType(r.GetInt(attr, src))
@@ -601,6 +873,24 @@ func mapCachedValue(src sfgo.Source, attr RecAttribute) FieldMap {
 	}
 }
 
+// mapAncestorName maps the name of the ancestor of the process of a record at depth, where the parent is at depth 1.
+func mapAncestorName(src sfgo.Source, depth int) FieldMap {
+	return func(r *Record) interface{} {
+		oid := sfgo.OID{CreateTS: r.GetInt(sfgo.PROC_OID_CREATETS_INT, src), Hpid: r.GetInt(sfgo.PROC_OID_HPID_INT, src)}
+		if ptree := r.MemoizePtree(oid); len(ptree) > depth {
+			return filepath.Base(ptree[depth].Exe)
+		}
+		return sfgo.Zeros.String
+	}
+}
+
+// mapDuration maps the time elapsed between the creation timestamp attr and the timestamp of a record.
+func mapDuration(src sfgo.Source, attr sfgo.Attribute) FieldMap {
+	return func(r *Record) interface{} {
+		return r.GetInt(sfgo.TS_INT, src) - r.GetInt(attr, src)
+	}
+}
+
 func mapOID(src sfgo.Source, attrs ...sfgo.Attribute) FieldMap {
 	return func(r *Record) interface{} {
 		h := xxhash.New()
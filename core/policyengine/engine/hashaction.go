@@ -0,0 +1,312 @@
+//
+// Copyright (C) 2020 IBM Corporation.
+//
+// Authors:
+// Frederico Araujo <frederico.araujo@ibm.com>
+// Teryl Taylor <terylt@ibm.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+package engine
+
+import (
+	"container/list"
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"syscall"
+
+	"github.com/sysflow-telemetry/sf-apis/go/logger"
+	"github.com/sysflow-telemetry/sf-apis/go/sfgo"
+	"golang.org/x/sys/unix"
+)
+
+const hashQueueSize = 1000
+
+// maxSymlinks is the maximum number of symbolic links followed when resolving a path, as in Linux.
+const maxSymlinks = 40
+
+// hashJob is a record whose file is to be hashed by the hash workers.
+type hashJob struct {
+	s    *hashActuator
+	rule Rule
+	r    *Record
+	done func()
+}
+
+// hashKey identifies a version of a file in the hash cache.
+type hashKey struct {
+	dev   uint64
+	ino   uint64
+	mtime int64
+	size  int64
+}
+
+// hashEntry is a cached file hash.
+type hashEntry struct {
+	key hashKey
+	hs  HashSet
+}
+
+// hashActuator computes the MD5, SHA1 and SHA256 hashes of the files referenced by file records, and of the
+// executables of exec events, on a pool of workers. Hashes are cached by the inode and modification time of files,
+// and the least recently used hashes are evicted when the cache is full.
+type hashActuator struct {
+	config HashConfig
+	mutex  sync.Mutex
+	cache  map[hashKey]*list.Element
+	lru    *list.List
+}
+
+// hashJobs is the queue of the hash workers, which are shared by all hash actuators.
+var hashJobs chan hashJob
+
+// hashWorkersOnce starts the hash workers.
+var hashWorkersOnce sync.Once
+
+// hashOverflows counts the records not hashed because the queue of the hash workers was full.
+var hashOverflows uint64
+
+// newHashActuator creates a new hash actuator, starting the hash workers on first use.
+// The number of workers is set by the configuration of the first hash actuator, and is at least one.
+func newHashActuator(conf Config) (Actuator, error) {
+	hashWorkersOnce.Do(func() {
+		hashJobs = make(chan hashJob, hashQueueSize)
+		n := conf.HashWorkers
+		if n < 1 {
+			n = 1
+		}
+		for i := 0; i < n; i++ {
+			go hashWorker()
+		}
+	})
+	return &hashActuator{config: conf.HashConfig, cache: make(map[hashKey]*list.Element), lru: list.New()}, nil
+}
+
+// hashWorker hashes the files of queued records.
+func hashWorker() {
+	for j := range hashJobs {
+		if err := j.s.Handle(j.rule, j.r); err != nil {
+			logger.Error.Printf("Error running action %s of rule %s: %v\n", Hash, j.rule.Name, err)
+		}
+		j.done()
+	}
+}
+
+// HandleAsync queues record r for hashing. Records are not hashed, and the overflow is counted, while the queue is full.
+func (s *hashActuator) HandleAsync(rule Rule, r *Record, done func()) {
+	if len(hashPath(r)) == 0 || len(r.Ctx.GetHashes().SHA256) > 0 {
+		done()
+		return
+	}
+	select {
+	case hashJobs <- hashJob{s: s, rule: rule, r: r, done: done}:
+	default:
+		if n := atomic.AddUint64(&hashOverflows, 1); n == 1 || n%hashQueueSize == 0 {
+			logger.Warn.Printf("Hash queue full, %d records not hashed so far\n", n)
+		}
+		done()
+	}
+}
+
+// Handle computes the hashes of the file of record r, and stores them in the record context.
+func (s *hashActuator) Handle(rule Rule, r *Record) error {
+	path := hashPath(r)
+	if len(path) == 0 || len(r.Ctx.GetHashes().SHA256) > 0 {
+		return nil
+	}
+	hs, err := s.hash(s.root(r), path)
+	if err != nil {
+		return err
+	}
+	r.Ctx.SetHashes(hs)
+	return nil
+}
+
+// root returns the root directory under which the files of the process of record r are resolved. Files of
+// containerized processes are resolved through the root directory of the process in procfs (/proc/<pid>/root),
+// since they are in the container's filesystem, so they can only be hashed while the process runs. All roots
+// are under the configured host root.
+func (s *hashActuator) root(r *Record) string {
+	if len(r.GetStr(sfgo.CONT_ID_STR, sfgo.SYSFLOW_SRC)) > 0 {
+		pid := strconv.FormatInt(r.GetInt(sfgo.PROC_OID_HPID_INT, sfgo.SYSFLOW_SRC), 10)
+		return filepath.Join(s.config.HashHostRoot, "/proc", pid, "root")
+	}
+	return filepath.Join("/", s.config.HashHostRoot)
+}
+
+// openInRoot opens the file at path under directory root, resolving symbolic links and parent directory
+// references as if root were the root directory, so that the files of a process can't point outside of its root.
+// Kernels without openat2 (before Linux 5.6) resolve the path with joinInRoot.
+func openInRoot(root string, path string) (*os.File, error) {
+	dir, err := os.Open(root)
+	if err != nil {
+		return nil, err
+	}
+	defer dir.Close()
+	name := filepath.Join(root, path)
+	fd, err := unix.Openat2(int(dir.Fd()), path, &unix.OpenHow{
+		Flags:   unix.O_RDONLY | unix.O_CLOEXEC,
+		Resolve: unix.RESOLVE_IN_ROOT | unix.RESOLVE_NO_MAGICLINKS,
+	})
+	if err == unix.ENOSYS {
+		if name, err = joinInRoot(root, path); err != nil {
+			return nil, err
+		}
+		return os.OpenFile(name, os.O_RDONLY|syscall.O_NOFOLLOW, 0)
+	}
+	if err != nil {
+		return nil, &os.PathError{Op: "open", Path: name, Err: err}
+	}
+	return os.NewFile(uintptr(fd), name), nil
+}
+
+// joinInRoot resolves path under directory root as openat2 does with RESOLVE_IN_ROOT: absolute symbolic links
+// are resolved from root, and parent directory references never leave it. Unlike openat2, the resolution can be
+// raced by concurrent changes to the directories under root.
+func joinInRoot(root string, path string) (string, error) {
+	resolved, rest, links := "/", path, 0
+	for len(rest) > 0 {
+		var c string
+		if i := strings.IndexByte(rest, '/'); i >= 0 {
+			c, rest = rest[:i], rest[i+1:]
+		} else {
+			c, rest = rest, ""
+		}
+		if c == "" || c == "." {
+			continue
+		}
+		if c == ".." {
+			resolved = filepath.Dir(resolved)
+			continue
+		}
+		next := filepath.Join(resolved, c)
+		fi, err := os.Lstat(filepath.Join(root, next))
+		if err != nil {
+			return "", err
+		}
+		if fi.Mode()&os.ModeSymlink == 0 {
+			resolved = next
+			continue
+		}
+		if links++; links > maxSymlinks {
+			return "", &os.PathError{Op: "open", Path: filepath.Join(root, path), Err: syscall.ELOOP}
+		}
+		target, err := os.Readlink(filepath.Join(root, next))
+		if err != nil {
+			return "", err
+		}
+		if filepath.IsAbs(target) {
+			resolved = "/"
+		}
+		rest = target + "/" + rest
+	}
+	return filepath.Join(root, resolved), nil
+}
+
+// hashPath returns the path of the file to be hashed for record r: the file of file events and flows, or the
+// executable of exec events. It returns an empty path for other records.
+func hashPath(r *Record) string {
+	switch r.GetInt(sfgo.SF_REC_TYPE, sfgo.SYSFLOW_SRC) {
+	case sfgo.FILE_EVT, sfgo.FILE_FLOW:
+		return r.GetStr(sfgo.FILE_PATH_STR, sfgo.SYSFLOW_SRC)
+	case sfgo.PROC_EVT:
+		if r.GetInt(sfgo.EV_PROC_OPFLAGS_INT, sfgo.SYSFLOW_SRC)&sfgo.OP_EXEC == sfgo.OP_EXEC {
+			return r.GetStr(sfgo.PROC_EXE_STR, sfgo.SYSFLOW_SRC)
+		}
+	}
+	return sfgo.Zeros.String
+}
+
+// hash computes the hashes of the regular file at path under directory root, or returns them from the cache.
+// Files larger than the configured maximum size are not hashed.
+func (s *hashActuator) hash(root string, path string) (HashSet, error) {
+	f, err := openInRoot(root, path)
+	if err != nil {
+		return HashSet{}, err
+	}
+	defer f.Close()
+	fi, err := f.Stat()
+	if err != nil {
+		return HashSet{}, err
+	}
+	if !fi.Mode().IsRegular() {
+		return HashSet{}, fmt.Errorf("%s is not a regular file", f.Name())
+	}
+	if s.config.HashMaxSize > 0 && fi.Size() > s.config.HashMaxSize {
+		return HashSet{}, fmt.Errorf("%s is larger than the maximum hashed file size of %d bytes", f.Name(), s.config.HashMaxSize)
+	}
+	st, ok := fi.Sys().(*syscall.Stat_t)
+	if !ok {
+		return HashSet{}, errors.New("unable to read the inode of " + f.Name())
+	}
+	key := hashKey{dev: uint64(st.Dev), ino: st.Ino, mtime: fi.ModTime().UnixNano(), size: fi.Size()}
+	if hs, ok := s.cached(key); ok {
+		return hs, nil
+	}
+
+	// only the stat'ed size is hashed, in case the file grows
+	h5, h1, h256 := md5.New(), sha1.New(), sha256.New()
+	if _, err := io.Copy(io.MultiWriter(h5, h1, h256), io.LimitReader(f, fi.Size())); err != nil {
+		return HashSet{}, err
+	}
+	hs := HashSet{
+		MD5:      hex.EncodeToString(h5.Sum(nil)),
+		SHA1:     hex.EncodeToString(h1.Sum(nil)),
+		SHA256:   hex.EncodeToString(h256.Sum(nil)),
+		Size:     int(fi.Size()),
+		UpdateTs: key.mtime,
+	}
+	s.store(key, hs)
+	return hs, nil
+}
+
+// cached returns the cached hashes of key, marking them as the most recently used.
+func (s *hashActuator) cached(key hashKey) (HashSet, bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if e, ok := s.cache[key]; ok {
+		s.lru.MoveToFront(e)
+		return e.Value.(*hashEntry).hs, true
+	}
+	return HashSet{}, false
+}
+
+// store caches the hashes of key, evicting the least recently used hashes if the cache is full.
+func (s *hashActuator) store(key hashKey, hs HashSet) {
+	if s.config.HashCacheSize <= 0 {
+		return
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if e, ok := s.cache[key]; ok {
+		s.lru.MoveToFront(e)
+		return
+	}
+	if s.lru.Len() >= s.config.HashCacheSize {
+		e := s.lru.Back()
+		s.lru.Remove(e)
+		delete(s.cache, e.Value.(*hashEntry).key)
+	}
+	s.cache[key] = s.lru.PushFront(&hashEntry{key: key, hs: hs})
+}
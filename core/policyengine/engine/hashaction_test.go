@@ -0,0 +1,128 @@
+//
+// Copyright (C) 2020 IBM Corporation.
+//
+// Authors:
+// Frederico Araujo <frederico.araujo@ibm.com>
+// Teryl Taylor <terylt@ibm.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+package engine
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// hashRoot creates a root directory holding file bin/sh, and symbolic links to it and to a file outside of the root.
+func hashRoot(t *testing.T) (string, func()) {
+	dir, err := ioutil.TempDir("", "hashroot")
+	assert.NoError(t, err)
+	root := filepath.Join(dir, "root")
+	assert.NoError(t, os.MkdirAll(filepath.Join(root, "bin"), 0755))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(root, "bin", "sh"), []byte("abc"), 0755))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "secret"), []byte("secret"), 0644))
+	for link, target := range map[string]string{
+		"bin/rel":    "sh",
+		"bin/abs":    "/bin/sh",
+		"bin/up":     "../../bin/sh",
+		"escape":     "../secret",
+		"escape_abs": filepath.Join(dir, "secret"),
+		"loop":       "loop",
+	} {
+		assert.NoError(t, os.Symlink(target, filepath.Join(root, link)))
+	}
+	return root, func() { os.RemoveAll(dir) }
+}
+
+func TestOpenInRoot(t *testing.T) {
+	root, cleanup := hashRoot(t)
+	defer cleanup()
+	for _, open := range []struct {
+		name string
+		open func(string, string) (*os.File, error)
+	}{
+		{"openat2", openInRoot},
+		{"fallback", func(root string, path string) (*os.File, error) {
+			name, err := joinInRoot(root, path)
+			if err != nil {
+				return nil, err
+			}
+			return os.Open(name)
+		}},
+	} {
+		for path, content := range map[string]string{
+			"/bin/sh":         "abc",
+			"/bin/rel":        "abc",
+			"/bin/abs":        "abc",
+			"/bin/up":         "abc",
+			"/../../bin/./sh": "abc",
+			"/escape":         "",
+			"/escape_abs":     "",
+			"/loop":           "",
+		} {
+			f, err := open.open(root, path)
+			if content == "" {
+				assert.Error(t, err, open.name+" "+path)
+				continue
+			}
+			if assert.NoError(t, err, open.name+" "+path) {
+				b, err := ioutil.ReadAll(f)
+				assert.NoError(t, err)
+				assert.Equal(t, content, string(b), open.name+" "+path)
+				f.Close()
+			}
+		}
+	}
+}
+
+func TestHashLimits(t *testing.T) {
+	root, cleanup := hashRoot(t)
+	defer cleanup()
+	for _, name := range []string{"a", "b", "c", "big"} {
+		assert.NoError(t, ioutil.WriteFile(filepath.Join(root, name), []byte(name), 0644))
+	}
+	a, err := newHashActuator(Config{HashConfig: HashConfig{HashCacheSize: 2, HashMaxSize: 2}})
+	assert.NoError(t, err)
+	s := a.(*hashActuator)
+	ino := func(name string) uint64 {
+		fi, err := os.Stat(filepath.Join(root, name))
+		assert.NoError(t, err)
+		return fi.Sys().(*syscall.Stat_t).Ino
+	}
+	cached := func() []uint64 {
+		var inos []uint64
+		for e := s.lru.Front(); e != nil; e = e.Next() {
+			inos = append(inos, e.Value.(*hashEntry).key.ino)
+		}
+		return inos
+	}
+
+	// the least recently used hashes are evicted
+	for _, name := range []string{"a", "b", "a", "c"} {
+		_, err := s.hash(root, name)
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, []uint64{ino("c"), ino("a")}, cached())
+	assert.Equal(t, 2, len(s.cache))
+
+	// files larger than the maximum size are not hashed
+	_, err = s.hash(root, "big")
+	assert.Error(t, err)
+	assert.Equal(t, []uint64{ino("c"), ino("a")}, cached())
+}
@@ -0,0 +1,77 @@
+//
+// Copyright (C) 2020 IBM Corporation.
+//
+// Authors:
+// Frederico Araujo <frederico.araujo@ibm.com>
+// Teryl Taylor <terylt@ibm.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+package engine
+
+import (
+	"errors"
+	"strconv"
+)
+
+// Configuration keys of the hash action.
+const (
+	HashHostRootConfigKey  string = "hash.hostroot"
+	HashWorkersConfigKey   string = "hash.workers"
+	HashCacheSizeConfigKey string = "hash.cachesize"
+	HashMaxSizeConfigKey   string = "hash.maxsize"
+)
+
+// HashConfig holds the configuration of the hash action.
+type HashConfig struct {
+	HashHostRoot  string
+	HashWorkers   int
+	HashCacheSize int
+	HashMaxSize   int64
+}
+
+// CreateHashConfig creates a new hash action config object from config dictionary.
+func CreateHashConfig(conf map[string]interface{}) (c HashConfig, err error) {
+	// default values
+	c = HashConfig{
+		HashWorkers:   2,
+		HashCacheSize: 1024,
+		HashMaxSize:   100 << 20}
+
+	// parse config map
+	if v, ok := conf[HashHostRootConfigKey].(string); ok {
+		c.HashHostRoot = v
+	}
+	if v, ok := conf[HashWorkersConfigKey].(string); ok {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			return c, errors.New("Configuration tag 'hash.workers' must be a positive integer")
+		}
+		c.HashWorkers = n
+	}
+	if v, ok := conf[HashCacheSizeConfigKey].(string); ok {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			return c, errors.New("Configuration tag 'hash.cachesize' must be a non-negative integer")
+		}
+		c.HashCacheSize = n
+	}
+	if v, ok := conf[HashMaxSizeConfigKey].(string); ok {
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || n < 0 {
+			return c, errors.New("Configuration tag 'hash.maxsize' must be a non-negative integer")
+		}
+		c.HashMaxSize = n
+	}
+	return
+}
@@ -20,10 +20,11 @@
 package engine
 
 import (
-	"errors"
+	"fmt"
 	"regexp"
 	"strconv"
 	"strings"
+	"sync/atomic"
 
 	"github.com/antlr/antlr4/runtime/Go/antlr"
 	"github.com/sysflow-telemetry/sf-apis/go/logger"
@@ -31,35 +32,82 @@ import (
 	"github.com/sysflow-telemetry/sf-processor/core/policyengine/lang/parser"
 )
 
-// Parsed rule and filter object maps.
-var rules = make([]Rule, 0)
-var filters = make([]Filter, 0)
-
-// Accessory parsing maps.
-var lists = make(map[string][]string)
-var macroCtxs = make(map[string]parser.IExpressionContext)
-
 // Regular expression for parsing lists.
 var itemsre = regexp.MustCompile(`(^\[)(.*)(\]$?)`)
 
+// policySet stores an immutable set of compiled rules and filters.
+type policySet struct {
+	rules    []Rule
+	filters  []Filter
+	dispatch dispatchTable
+	bundle   *PolicyBundle
+}
+
+// newPolicySet creates a policy set with rules and filters, dispatching records to rules by record type.
+func newPolicySet(rules []Rule, filters []Filter, bundle *PolicyBundle) *policySet {
+	for i := range rules {
+		rules[i].stats = new(evalStats)
+	}
+	for i := range filters {
+		filters[i].stats = new(evalStats)
+	}
+	return &policySet{rules: rules, filters: filters, dispatch: newDispatchTable(rules), bundle: bundle}
+}
+
+// emptyPolicySet is the policy set of an interpreter that has not compiled any policies yet.
+var emptyPolicySet = &policySet{}
+
 // PolicyInterpreter defines a rules engine for SysFlow data streams.
 type PolicyInterpreter struct {
-	ahdl ActionHandler
+	ahdl     ActionHandler
+	policies atomic.Value
+	version  string
+	stats    bool
 }
 
 // NewPolicyInterpreter constructs a new interpreter instance.
+// The engine version checked against the required_sysflow_version of policies is the configured processor version.
 func NewPolicyInterpreter(conf Config) *PolicyInterpreter {
 	ah := NewActionHandler(conf)
-	return &PolicyInterpreter{ah}
+	return &PolicyInterpreter{ahdl: ah, version: conf.Version, stats: conf.Stats}
+}
+
+// stamp records the policy bundle of the policy set, if any, in the context of matching record r.
+func (ps *policySet) stamp(r *Record) {
+	if ps.bundle != nil {
+		r.Ctx.SetBundle(*ps.bundle)
+	}
+}
+
+// Bundle returns the policy bundle from which the active policy set was compiled, or nil if it wasn't compiled from a bundle.
+func (pi *PolicyInterpreter) Bundle() *PolicyBundle {
+	return pi.getPolicySet().bundle
+}
+
+// getPolicySet returns the active policy set of the interpreter.
+func (pi *PolicyInterpreter) getPolicySet() *policySet {
+	if ps, ok := pi.policies.Load().(*policySet); ok {
+		return ps
+	}
+	return emptyPolicySet
 }
 
-// Compile parses and interprets an input policy defined in path.
-func (pi *PolicyInterpreter) compile(path string) error {
+// policyFile holds the parser and error listeners of a policy file being compiled.
+type policyFile struct {
+	path           string
+	parser         *parser.SfplParser
+	lexerErrors    *errorhandler.SfplErrorListener
+	parserErrors   *errorhandler.SfplErrorListener
+	compilerErrors *errorhandler.SfplErrorListener
+}
+
+// parse sets up the parser for an input policy defined in path and collects its macro and list definitions.
+func (pi *PolicyInterpreter) parse(listener *sfplListener, path string) (*policyFile, error) {
 	// Setup the input
 	is, err := antlr.NewFileStream(path)
 	if err != nil {
 		logger.Error.Println("Error reading policy from path", path)
-		return err
+		return nil, err
 	}
 
 	// Create the Lexer
@@ -75,65 +123,145 @@ func (pi *PolicyInterpreter) compile(path string) error {
 	p.RemoveErrorListeners()
 	p.AddErrorListener(parserErrors)
 
+	// Create the compiler error listener
+	compilerErrors := &errorhandler.SfplErrorListener{}
+	listener.errors = compilerErrors
+
 	// Pre-processing (to deal with usage before definitions of macros and lists)
-	antlr.ParseTreeWalkerDefault.Walk(&sfplListener{}, p.Defs())
-	p.GetInputStream().Seek(0)
+	antlr.ParseTreeWalkerDefault.Walk(listener, p.Defs())
+
+	return &policyFile{path: path, parser: p, lexerErrors: lexerErrors, parserErrors: parserErrors, compilerErrors: compilerErrors}, nil
+}
+
+// walk interprets the rules and filters of a parsed policy file, and returns its parse tree.
+func (pi *PolicyInterpreter) walk(listener *sfplListener, pf *policyFile) parser.IPolicyContext {
+	listener.errors = pf.compilerErrors
+	pf.parser.GetInputStream().Seek(0)
+	tree := pf.parser.Policy()
+	antlr.ParseTreeWalkerDefault.Walk(listener, tree)
+	return tree
+}
 
+// CompileError reports the errors found while compiling a policy file. Errors holds the lexer, parser and
+// compiler errors of the file, in this order, as *errorhandler.SfplSyntaxError values with their positions.
+type CompileError struct {
+	Path   string
+	Errors []error
+}
+
+// Error returns a string reporting the first error found in the policy file.
+func (e *CompileError) Error() string {
+	return fmt.Sprintf("%d errors found during compilation of policy %s, first at %v. check logs for detail.", len(e.Errors), e.Path, e.Errors[0])
+}
+
+// compile interprets the rules and filters of a parsed policy file.
+func (pi *PolicyInterpreter) compile(listener *sfplListener, pf *policyFile) error {
 	// Parse the policy
-	antlr.ParseTreeWalkerDefault.Walk(&sfplListener{}, p.Policy())
+	pi.walk(listener, pf)
 
-	errFound := false
-	if len(lexerErrors.Errors) > 0 {
-		logger.Error.Printf("Lexer %d errors found\n", len(lexerErrors.Errors))
-		for _, e := range lexerErrors.Errors {
+	var errs []error
+	if len(pf.lexerErrors.Errors) > 0 {
+		logger.Error.Printf("Lexer %d errors found in %s\n", len(pf.lexerErrors.Errors), pf.path)
+		for _, e := range pf.lexerErrors.Errors {
+			logger.Error.Println("\t", e.Error())
+		}
+		errs = append(errs, pf.lexerErrors.Errors...)
+	}
+	if len(pf.parserErrors.Errors) > 0 {
+		logger.Error.Printf("Parser %d errors found in %s\n", len(pf.parserErrors.Errors), pf.path)
+		for _, e := range pf.parserErrors.Errors {
 			logger.Error.Println("\t", e.Error())
 		}
-		errFound = true
+		errs = append(errs, pf.parserErrors.Errors...)
 	}
-	if len(parserErrors.Errors) > 0 {
-		logger.Error.Printf("Parser %d errors found\n", len(parserErrors.Errors))
-		for _, e := range parserErrors.Errors {
+	if len(pf.compilerErrors.Errors) > 0 {
+		logger.Error.Printf("Compiler %d errors found in %s\n", len(pf.compilerErrors.Errors), pf.path)
+		for _, e := range pf.compilerErrors.Errors {
 			logger.Error.Println("\t", e.Error())
 		}
-		errFound = true
+		errs = append(errs, pf.compilerErrors.Errors...)
 	}
 
-	if errFound {
-		return errors.New("errors found during compilation of policies. check logs for detail.")
+	if len(errs) > 0 {
+		return &CompileError{Path: pf.path, Errors: errs}
 	}
 
 	return nil
 }
 
 // Compile parses and interprets a set of input policies defined in paths.
+// Macros and lists of all policies are collected, in order, before rules and filters are compiled,
+// so that appends in later policy files also apply to rules defined in earlier ones.
+// On success, the compiled policies atomically replace the interpreter's active policy set.
 func (pi *PolicyInterpreter) Compile(paths ...string) error {
+	return pi.CompileBundle(nil, paths...)
+}
+
+// CompileBundle compiles the policies in paths, which were extracted from the verified policy bundle b.
+// Records matching rules of the compiled policy set are stamped with the bundle's version and digest.
+func (pi *PolicyInterpreter) CompileBundle(b *PolicyBundle, paths ...string) error {
+	listener := newSfplListener(pi.version)
+	pfs := make([]*policyFile, 0, len(paths))
 	for _, path := range paths {
 		logger.Trace.Println("Parsing policy file ", path)
-		if err := pi.compile(path); err != nil {
+		pf, err := pi.parse(listener, path)
+		if err != nil {
+			return err
+		}
+		pfs = append(pfs, pf)
+	}
+	for _, pf := range pfs {
+		logger.Trace.Println("Compiling policy file ", pf.path)
+		if err := pi.compile(listener, pf); err != nil {
 			return err
 		}
 	}
+	if err := pi.ahdl.bind(listener.rules); err != nil {
+		return err
+	}
+	pi.policies.Store(newPolicySet(listener.rules, listener.filters, b))
 	return nil
 }
 
 // ProcessAsync executes all compiled policies against record r.
+// Records with asynchronous actions are output once the actions complete, and may thus be
+// output after records evaluated later; ProcessOrdered preserves the order of records.
 func (pi *PolicyInterpreter) ProcessAsync(applyFilters bool, filterOnly bool, r *Record, out func(r *Record)) {
+	pi.processAsync(applyFilters, filterOnly, r, out, func() {})
+}
+
+// ProcessOrdered executes all compiled policies against record r, and outputs r through o
+// after the records previously evaluated with o, once its asynchronous actions complete.
+func (pi *PolicyInterpreter) ProcessOrdered(applyFilters bool, filterOnly bool, r *Record, o *OrderedOutput) {
+	p := o.add()
+	pi.processAsync(applyFilters, filterOnly, r, p.out, p.complete)
+}
+
+// processAsync executes all compiled policies against record r, and calls done once r is output,
+// or once r is known not to be output.
+func (pi *PolicyInterpreter) processAsync(applyFilters bool, filterOnly bool, r *Record, out func(r *Record), done func()) {
 	if applyFilters && pi.EvalFilters(r) {
+		done()
 		return
 	}
 	if filterOnly {
 		out(r)
 	}
 	match := false
-	for _, rule := range rules {
-		if rule.Enabled && rule.isApplicable(r) && rule.condition.Eval(r) {
-			pi.ahdl.HandleActionAsync(rule, r, out)
+	var pending []asyncAction
+	ps := pi.getPolicySet()
+	for _, rule := range ps.dispatch.candidates(r) {
+		if pi.evalRule(rule, r) {
+			pending = pi.ahdl.HandleActionAsync(*rule, r, pending)
 			match = true
 		}
 	}
-	if match {
-		out(r)
+	if !match {
+		done()
+		return
 	}
+	ps.stamp(r)
+	runAsync(pending, r, out, done)
 }
 
 // Process executes all compiled policies against record r.
@@ -145,66 +273,608 @@ func (pi *PolicyInterpreter) Process(applyFilters bool, filterOnly bool, r *Reco
 	if filterOnly {
 		return true, r
 	}
-	for _, rule := range rules {
-		if rule.Enabled && rule.isApplicable(r) && rule.condition.Eval(r) {
-			pi.ahdl.HandleAction(rule, r)
+	ps := pi.getPolicySet()
+	for _, rule := range ps.dispatch.candidates(r) {
+		if pi.evalRule(rule, r) {
+			pi.ahdl.HandleAction(*rule, r)
 			match = true
 		}
 	}
+	if match {
+		ps.stamp(r)
+	}
 	return match, r
 }
 
 // EvalFilters executes compiled policy filters against record r.
 func (pi *PolicyInterpreter) EvalFilters(r *Record) bool {
-	for _, f := range filters {
-		if f.Enabled && f.condition.Eval(r) {
+	for _, f := range pi.getPolicySet().filters {
+		if f.Enabled && pi.evalFilter(f, r) {
 			return true
 		}
 	}
 	return false
 }
 
+// evalRule evaluates the condition of rule on record r, collecting its statistics if enabled.
+func (pi *PolicyInterpreter) evalRule(rule *Rule, r *Record) bool {
+	if pi.stats {
+		return rule.stats.eval(rule.condition, r)
+	}
+	return rule.condition.Eval(r)
+}
+
+// evalFilter evaluates the condition of filter f on record r, collecting its statistics if enabled.
+func (pi *PolicyInterpreter) evalFilter(f Filter, r *Record) bool {
+	if pi.stats {
+		return f.stats.eval(f.condition, r)
+	}
+	return f.condition.Eval(r)
+}
+
 type sfplListener struct {
 	*parser.BaseSfplListener
+	rules         []Rule
+	filters       []Filter
+	lists         map[string][]string
+	macroCtxs     map[string]parser.IExpressionContext
+	ruleCtxs      map[string]parser.IExpressionContext
+	unknownFields map[string][]antlr.Token
+	skipUnknown   map[string]bool
+	exceptions    map[string][]*exception
+	sequences     map[string]bool
+	reported      map[antlr.Token]bool
+	version       string
+	errors        *errorhandler.SfplErrorListener
+}
+
+func newSfplListener(version string) *sfplListener {
+	return &sfplListener{
+		version:       version,
+		rules:         make([]Rule, 0),
+		filters:       make([]Filter, 0),
+		lists:         make(map[string][]string),
+		macroCtxs:     make(map[string]parser.IExpressionContext),
+		ruleCtxs:      make(map[string]parser.IExpressionContext),
+		unknownFields: make(map[string][]antlr.Token),
+		skipUnknown:   make(map[string]bool),
+		exceptions:    make(map[string][]*exception),
+		sequences:     make(map[string]bool),
+		reported:      make(map[antlr.Token]bool),
+	}
+}
+
+// ExitPreq is called when production preq is exited.
+func (listener *sfplListener) ExitPreq(ctx *parser.PreqContext) {
+	if !isDefsPass(ctx) {
+		return
+	}
+	req := trimBoundingQuotes(ctx.Atom().GetText())
+	// required_engine_version is the Falco engine version, which is unrelated to SysFlow versions
+	if ctx.REQ().GetText() == "required_engine_version" {
+		if !isFalcoEngineVersion(req) {
+			listener.errors.SemanticError(ctx.Atom().GetStart(),
+				fmt.Sprintf("invalid Falco required engine version %s, SysFlow versions are required with required_sysflow_version", req))
+			return
+		}
+		logger.Trace.Printf("Skipping check for Falco required engine version %s\n", req)
+		return
+	}
+	rv, err := parseVersion(req)
+	if err != nil {
+		listener.errors.SemanticError(ctx.Atom().GetStart(), fmt.Sprintf("invalid required SysFlow version %s", req))
+		return
+	}
+	ev, err := parseVersion(listener.version)
+	if err != nil {
+		logger.Warn.Printf("Unknown policy engine version '%s', skipping check for required SysFlow version %s\n", listener.version, req)
+		return
+	}
+	if ev.less(rv) {
+		listener.errors.SemanticError(ctx.Atom().GetStart(),
+			fmt.Sprintf("policy requires engine version %s or newer, but the policy engine version is %s", req, listener.version))
+	}
 }
 
 // ExitList is called when production list is exited.
 func (listener *sfplListener) ExitPlist(ctx *parser.PlistContext) {
+	if !isDefsPass(ctx) {
+		return
+	}
 	logger.Trace.Println("Parsing list ", ctx.GetText())
-	lists[ctx.ID().GetText()] = listener.extractListFromItems(ctx.Items())
+	name := ctx.Identifier().GetText()
+	l, defined := listener.lists[name]
+	appended := listener.getAppendFlag(ctx.AllFappend())
+	if listener.checkDefinition("list", name, ctx.Identifier().GetStart(), defined, appended) {
+		listener.lists[name] = append(l, listener.extractListFromItems(ctx.Items())...)
+	}
 }
 
 // ExitMacro is called when production macro is exited.
 func (listener *sfplListener) ExitPmacro(ctx *parser.PmacroContext) {
+	if !isDefsPass(ctx) {
+		return
+	}
 	logger.Trace.Println("Parsing macro ", ctx.GetText())
-	macroCtxs[ctx.ID().GetText()] = ctx.Expression()
+	name := ctx.Identifier().GetText()
+	m, defined := listener.macroCtxs[name]
+	appended := listener.getAppendFlag(ctx.AllFappend())
+	op := logicalOperator(ctx.OR(), ctx.AND())
+	if !listener.checkDefinition("macro", name, ctx.Identifier().GetStart(), defined, appended) ||
+		!listener.checkCondition("macro", name, ctx.COND(), op, appended) {
+		return
+	}
+	listener.unknownFields[name] = append(listener.unknownFields[name], findUnknownFields(ctx.Expression())...)
+	if appended {
+		listener.macroCtxs[name] = listener.appendCondition(m, op, ctx.Expression())
+	} else {
+		listener.macroCtxs[name] = ctx.Expression()
+	}
 }
 
 // ExitFilter is called when production filter is exited.
 func (listener *sfplListener) ExitPfilter(ctx *parser.PfilterContext) {
 	logger.Trace.Println("Parsing filter ", ctx.GetText())
+	name := ctx.ID().GetText()
+	if !listener.checkFields("filter", name, ctx.Expression(), false) {
+		return
+	}
 	f := Filter{
-		Name:      ctx.ID().GetText(),
+		Name:      name,
 		condition: listener.visitExpression(ctx.Expression()),
 		Enabled:   ctx.ENABLED() == nil || listener.getEnabledFlag(ctx.Enabled()),
 	}
-	filters = append(filters, f)
+	listener.filters = append(listener.filters, f)
 }
 
 // ExitFilter is called when production filter is exited.
 func (listener *sfplListener) ExitPrule(ctx *parser.PruleContext) {
 	logger.Trace.Println("Parsing rule ", ctx.GetText())
+	if ctx.COND() != nil && ctx.Expression() == nil {
+		// the syntax error has already been reported by the parser
+		return
+	}
+	name := listener.getOffChannelText(ctx.Text(0))
+	_, defined := listener.ruleCtxs[name]
+	appended := listener.getAppendFlag(ctx.AllFappend())
+	op := logicalOperator(ctx.OR(), ctx.AND())
+	if !listener.checkDefinition("rule", name, ctx.Text(0).GetStart(), defined, appended) ||
+		(ctx.COND() != nil && !listener.checkCondition("rule", name, ctx.COND(), op, appended)) {
+		return
+	}
+	if appended && (ctx.SEQUENCE() != nil || listener.sequences[name]) {
+		listener.errors.SemanticError(ctx.Text(0).GetStart(), fmt.Sprintf("sequence %s cannot be appended", name))
+		return
+	}
+	if appended {
+		listener.appendRule(name, op, ctx)
+		return
+	}
+	desc := listener.getAttrText(ctx, parser.SfplParserDESC)
+	if desc == nil {
+		listener.errors.SemanticError(ctx.Text(0).GetStart(), fmt.Sprintf("rule %s has no desc", name))
+		return
+	}
+	if ctx.SEQUENCE() != nil {
+		listener.compileSequence(name, desc, ctx)
+		return
+	}
+	if ctx.KEY(0) != nil || ctx.WINDOW(0) != nil || ctx.STEPS(0) != nil {
+		listener.errors.SemanticError(ctx.Text(0).GetStart(), fmt.Sprintf("rule %s has key, window or steps, which are only supported by sequences", name))
+		return
+	}
+	if ctx.COND() == nil {
+		listener.errors.SemanticError(ctx.Text(0).GetStart(), fmt.Sprintf("rule %s has no condition", name))
+		return
+	}
+	listener.ruleCtxs[name] = ctx.Expression()
+	listener.skipUnknown[name] = ctx.SKIPUNKNOWN(0) != nil && listener.getSkipUnknownFlag(ctx.Skipunknown(0))
+	if !listener.checkFields("rule", name, ctx.Expression(), listener.skipUnknown[name]) {
+		return
+	}
+	listener.compileExceptions(name, ctx, false)
 	r := Rule{
-		Name:      listener.getOffChannelText(ctx.Text(0)),
-		Desc:      listener.getOffChannelText(ctx.Text(1)),
-		condition: listener.visitExpression(ctx.Expression()),
+		Name:      name,
+		Desc:      listener.getOffChannelText(desc),
+		condition: listener.compileCondition(name, ctx.Expression()),
+		Actions:   listener.getActions(ctx),
+		Output:    listener.getOutput(ctx),
+		Tags:      listener.getTags(ctx),
+		Priority:  listener.getPriority(ctx),
+		Prefilter: listener.getPrefilter(ctx),
+		Enabled:   ctx.ENABLED(0) == nil || listener.getEnabledFlag(ctx.Enabled(0)),
+	}
+	r.types = prefilterTypes(r.Prefilter) & listener.recordTypes(ctx.Expression())
+	if len(r.Output) > 0 {
+		r.output = compileOutput(r.Output)
+	}
+	r.tags = compileTags(r.Tags)
+	listener.rules = append(listener.rules, r)
+}
+
+// compileSequence compiles sequence rule name. The exceptions of a sequence apply to all of its steps.
+func (listener *sfplListener) compileSequence(name string, desc parser.ITextContext, ctx *parser.PruleContext) {
+	token := ctx.Text(0).GetStart()
+	if ctx.COND() != nil {
+		listener.errors.SemanticError(token, fmt.Sprintf("sequence %s has a condition, sequence conditions are defined by steps", name))
+		return
+	}
+	if ctx.STEPS(0) == nil {
+		listener.errors.SemanticError(token, fmt.Sprintf("sequence %s has no steps", name))
+		return
+	}
+	if ctx.WINDOW(0) == nil {
+		listener.errors.SemanticError(token, fmt.Sprintf("sequence %s has no window", name))
+		return
+	}
+	w := trimBoundingQuotes(ctx.Atom(0).GetText())
+	window, err := parseWindow(w)
+	if err != nil {
+		listener.errors.SemanticError(ctx.Atom(0).GetStart(), fmt.Sprintf("invalid window %s in sequence %s", w, name))
+		return
+	}
+	listener.ruleCtxs[name] = nil
+	listener.sequences[name] = true
+	listener.skipUnknown[name] = ctx.SKIPUNKNOWN(0) != nil && listener.getSkipUnknownFlag(ctx.Skipunknown(0))
+	listener.compileExceptions(name, ctx, false)
+	key := listener.getFields(ctx.Fields(0))
+	var steps []Criterion
+	var keys [][]string
+	var types recordTypes
+	for _, sctx := range ctx.Steps(0).(*parser.StepsContext).AllStep() {
+		step := sctx.(*parser.StepContext)
+		if !listener.checkFields("sequence", name, step.Expression(), listener.skipUnknown[name]) {
+			return
+		}
+		steps = append(steps, listener.compileCondition(name, step.Expression()))
+		types |= listener.recordTypes(step.Expression())
+		if step.KEY() != nil {
+			keys = append(keys, listener.getFields(step.Fields()))
+		} else {
+			keys = append(keys, key)
+		}
+	}
+	seq, err := newSequence(name, steps, keys, window)
+	if err != nil {
+		listener.errors.SemanticError(token, err.Error())
+		return
+	}
+	r := Rule{
+		Name:      name,
+		Desc:      listener.getOffChannelText(desc),
+		condition: seq.criterion(),
 		Actions:   listener.getActions(ctx),
+		Output:    listener.getOutput(ctx),
 		Tags:      listener.getTags(ctx),
 		Priority:  listener.getPriority(ctx),
 		Prefilter: listener.getPrefilter(ctx),
 		Enabled:   ctx.ENABLED(0) == nil || listener.getEnabledFlag(ctx.Enabled(0)),
 	}
-	rules = append(rules, r)
+	r.types = prefilterTypes(r.Prefilter) & types
+	if len(r.Output) > 0 {
+		r.output = compileOutput(r.Output)
+	}
+	r.tags = compileTags(r.Tags)
+	listener.rules = append(listener.rules, r)
+}
+
+// appendRule appends the condition and exceptions of an appended rule definition to the previously defined rule.
+// Other attributes of appended rules are ignored.
+func (listener *sfplListener) appendRule(name string, op antlr.TerminalNode, ctx *parser.PruleContext) {
+	if ctx.DESC() != nil || ctx.ACTION(0) != nil || ctx.OUTPUT(0) != nil || ctx.PRIORITY(0) != nil ||
+		ctx.TAGS(0) != nil || ctx.PREFILTER(0) != nil || ctx.ENABLED(0) != nil {
+		logger.Warn.Printf("Only the condition and exceptions of appended rule %s are used, other attributes are ignored\n", name)
+	}
+	if ctx.COND() == nil && ctx.EXCEPTIONS(0) == nil {
+		listener.errors.SemanticError(ctx.Text(0).GetStart(), fmt.Sprintf("appended rule %s has no condition or exceptions", name))
+		return
+	}
+	if ctx.COND() != nil {
+		listener.ruleCtxs[name] = listener.appendCondition(listener.ruleCtxs[name], op, ctx.Expression())
+		if !listener.checkFields("rule", name, ctx.Expression(), listener.skipUnknown[name]) {
+			rules := listener.rules[:0]
+			for _, r := range listener.rules {
+				if r.Name != name {
+					rules = append(rules, r)
+				}
+			}
+			listener.rules = rules
+			return
+		}
+	}
+	listener.compileExceptions(name, ctx, true)
+	for i := range listener.rules {
+		if listener.rules[i].Name == name {
+			listener.rules[i].condition = listener.compileCondition(name, listener.ruleCtxs[name])
+			listener.rules[i].types = prefilterTypes(listener.rules[i].Prefilter) & listener.recordTypes(listener.ruleCtxs[name])
+		}
+	}
+}
+
+// compileCondition compiles the condition of rule name, which does not hold for records matched by the rule's exceptions.
+// Aggregates are evaluated last, on the records that satisfy the rest of the condition and aren't matched by exceptions.
+func (listener *sfplListener) compileCondition(name string, ctx parser.IExpressionContext) Criterion {
+	cond, aggs := listener.visitCondition(ctx)
+	var excs []Criterion
+	for _, e := range listener.exceptions[name] {
+		excs = append(excs, e.criterion())
+	}
+	if len(excs) > 0 {
+		cond = cond.And(Any(excs).Not())
+	}
+	if len(aggs) > 0 {
+		cond = cond.And(evalAll(aggs))
+	}
+	return cond
+}
+
+// visitCondition compiles a rule condition into the conjunction of its terms that are not aggregates,
+// and its aggregates. Aggregates are only supported as top-level conjuncts of rule conditions.
+func (listener *sfplListener) visitCondition(ctx parser.IExpressionContext) (Criterion, []Criterion) {
+	e, aggCtxs := listener.conditionExpr(ctx)
+	var aggs []Criterion
+	for _, a := range aggCtxs {
+		aggs = append(aggs, listener.compileAggregate(a))
+	}
+	return e.optimize().compile(), aggs
+}
+
+// conditionExpr builds the expression tree of a rule condition without its top-level aggregates,
+// which are returned separately.
+func (listener *sfplListener) conditionExpr(ctx parser.IExpressionContext) (*expr, []*parser.AggregateContext) {
+	orCtx := ctx.GetChild(0).(parser.IOr_expressionContext)
+	if orCtx.GetChildCount() != 1 {
+		return listener.visitExpr(ctx), nil
+	}
+	var preds []*expr
+	var aggs []*parser.AggregateContext
+	for _, termCtx := range orCtx.GetChild(0).GetChildren() {
+		if t, ok := termCtx.(*parser.TermContext); ok {
+			if a, ok := t.Aggregate().(*parser.AggregateContext); ok {
+				aggs = append(aggs, a)
+			} else {
+				preds = append(preds, listener.visitTermExpr(t))
+			}
+		}
+	}
+	return andExpr(preds...), aggs
+}
+
+// compileAggregate compiles an aggregate term, of the form fn([attr]) op threshold within window [by attrs].
+func (listener *sfplListener) compileAggregate(ctx *parser.AggregateContext) Criterion {
+	atoms := ctx.AllAtom()
+	var attr string
+	if _, ok := ctx.GetChild(2).(parser.IAtomContext); ok {
+		attr = atoms[0].GetText()
+		atoms = atoms[1:]
+	}
+	t := trimBoundingQuotes(atoms[0].GetText())
+	threshold, err := strconv.ParseFloat(t, 64)
+	if err != nil {
+		listener.errors.SemanticError(atoms[0].GetStart(), fmt.Sprintf("invalid aggregate threshold %s", t))
+		return False
+	}
+	w := trimBoundingQuotes(atoms[1].GetText())
+	window, err := parseWindow(w)
+	if err != nil {
+		listener.errors.SemanticError(atoms[1].GetStart(), fmt.Sprintf("invalid aggregate window %s", w))
+		return False
+	}
+	var by []string
+	for _, a := range atoms[2:] {
+		by = append(by, a.GetText())
+	}
+	a, err := newAggregate(ctx.ID().GetText(), attr, ctx.Binary_operator().GetText(), threshold, window, by)
+	if err != nil {
+		listener.errors.SemanticError(ctx.GetStart(), err.Error())
+		return False
+	}
+	return a.criterion()
+}
+
+// compileExceptions compiles the exceptions of rule name. Exceptions of appended rules either define
+// new exceptions, or append values to the exceptions of the same name defined by the rule.
+func (listener *sfplListener) compileExceptions(name string, ctx *parser.PruleContext, appended bool) {
+	for _, excs := range ctx.AllExceptions() {
+		for _, ectx := range excs.(*parser.ExceptionsContext).AllException() {
+			listener.compileException(name, ectx.(*parser.ExceptionContext), appended)
+		}
+	}
+}
+
+// compileException compiles exception ctx of rule name, and indexes its values.
+func (listener *sfplListener) compileException(rule string, ctx *parser.ExceptionContext, appended bool) {
+	name := ctx.ID().GetText()
+	where := fmt.Sprintf("exception %s of rule %s", name, rule)
+	var e *exception
+	for _, x := range listener.exceptions[rule] {
+		if x.name == name {
+			e = x
+		}
+	}
+	if e != nil && !appended {
+		listener.errors.SemanticError(ctx.ID().GetSymbol(), fmt.Sprintf("%s is already defined", where))
+		return
+	}
+	if e != nil && (ctx.FIELDS(0) != nil || ctx.COMPS(0) != nil) {
+		listener.errors.SemanticError(ctx.ID().GetSymbol(), fmt.Sprintf("cannot redefine the fields of %s, only values can be appended", where))
+		return
+	}
+	if e == nil {
+		fields := listener.getFields(ctx.Fields(0))
+		var comps []string
+		if c, ok := ctx.Comps(0).(*parser.CompsContext); ok {
+			for _, comp := range c.AllComp() {
+				comps = append(comps, comp.GetText())
+			}
+		}
+		var err error
+		if e, err = newException(name, fields, comps); err != nil {
+			listener.errors.SemanticError(ctx.ID().GetSymbol(), fmt.Sprintf("%s in %s", err.Error(), where))
+			return
+		}
+		listener.exceptions[rule] = append(listener.exceptions[rule], e)
+	}
+	for _, vs := range ctx.AllValues() {
+		for _, v := range vs.(*parser.ValuesContext).AllValue() {
+			if err := e.add(listener.getTuple(v.(*parser.ValueContext))); err != nil {
+				listener.errors.SemanticError(v.GetStart(), fmt.Sprintf("%s in %s", err.Error(), where))
+			}
+		}
+	}
+}
+
+// getFields returns the field names of a fields attribute, which is either a single field or a list of fields.
+func (listener *sfplListener) getFields(ctx parser.IFieldsContext) []string {
+	f, ok := ctx.(*parser.FieldsContext)
+	if !ok {
+		return nil
+	}
+	if f.Items() != nil {
+		return listener.extractListFromAtoms(f.Items().(*parser.ItemsContext).AllAtom())
+	}
+	return []string{f.Atom().GetText()}
+}
+
+// getTuple returns the values of an exception value tuple. A single value is a tuple for a single field.
+func (listener *sfplListener) getTuple(ctx *parser.ValueContext) [][]string {
+	if ctx.Tuple() == nil {
+		return [][]string{listener.reduceList(ctx.Atom().GetText())}
+	}
+	var tuple [][]string
+	for _, c := range ctx.Tuple().GetChildren() {
+		switch v := c.(type) {
+		case parser.IAtomContext:
+			tuple = append(tuple, listener.reduceList(v.GetText()))
+		case *parser.ItemsContext:
+			tuple = append(tuple, listener.extractListFromAtoms(v.AllAtom()))
+		}
+	}
+	return tuple
+}
+
+// checkFields checks the condition of a rule or filter, and of the macros it references, for references to
+// unknown fields. These are reported as errors, unless skip is set, in which case the rule is skipped with a warning.
+// Conditions missing after a syntax error are not checked.
+func (listener *sfplListener) checkFields(kind string, name string, ctx parser.IExpressionContext, skip bool) bool {
+	if ctx == nil {
+		return false
+	}
+	where := fmt.Sprintf("%s %s", kind, name)
+	tokens := findUnknownFields(ctx)
+	refs := make(map[antlr.Token]string)
+	for _, m := range listener.findMacroRefs(ctx, make(map[string]bool)) {
+		for _, t := range listener.unknownFields[m] {
+			refs[t] = fmt.Sprintf("macro %s used by %s", m, where)
+			tokens = append(tokens, t)
+		}
+	}
+	if len(tokens) == 0 {
+		return true
+	}
+	if skip {
+		logger.Warn.Printf("Skipping %s with unknown field %s\n", where, tokens[0].GetText())
+		return false
+	}
+	src := ctx.GetStart().GetInputStream().GetSourceName()
+	for _, t := range tokens {
+		msg := fmt.Sprintf("unknown field %s in %s", t.GetText(), where)
+		if ref, ok := refs[t]; ok {
+			msg = fmt.Sprintf("unknown field %s in %s", t.GetText(), ref)
+		}
+		if tsrc := t.GetInputStream().GetSourceName(); tsrc != src {
+			msg = fmt.Sprintf("%s (defined in %s)", msg, tsrc)
+		}
+		listener.reportOnce(t, msg)
+	}
+	return false
+}
+
+// reportOnce reports an error at token, unless it has already been reported.
+// Macros are compiled for every rule that references them, so their errors would otherwise be repeated.
+func (listener *sfplListener) reportOnce(token antlr.Token, msg string) {
+	if !listener.reported[token] {
+		listener.reported[token] = true
+		listener.errors.SemanticError(token, msg)
+	}
+}
+
+// findMacroRefs returns the names of the macros referenced, directly or indirectly, by condition ctx.
+func (listener *sfplListener) findMacroRefs(ctx antlr.Tree, visited map[string]bool) []string {
+	var refs []string
+	if v, ok := ctx.(parser.IVariableContext); ok {
+		name := v.GetText()
+		if m, ok := listener.macroCtxs[name]; ok && !visited[name] {
+			visited[name] = true
+			refs = append(refs, name)
+			refs = append(refs, listener.findMacroRefs(m, visited)...)
+		}
+		return refs
+	}
+	for _, c := range ctx.GetChildren() {
+		refs = append(refs, listener.findMacroRefs(c, visited)...)
+	}
+	return refs
+}
+
+// checkDefinition reports an error if a definition redefines an existing macro, list, or rule without
+// appending to it, or if it appends to one that has not been defined yet.
+func (listener *sfplListener) checkDefinition(kind string, name string, token antlr.Token, defined bool, appended bool) bool {
+	if appended && !defined {
+		listener.errors.SemanticError(token, fmt.Sprintf("cannot append to undefined %s %s", kind, name))
+		return false
+	}
+	if !appended && defined {
+		listener.errors.SemanticError(token, fmt.Sprintf("%s %s is already defined, set 'append: true' to append to it", kind, name))
+		return false
+	}
+	return true
+}
+
+// checkCondition reports an error if a condition starts with a logical operator but isn't appended, or vice versa.
+func (listener *sfplListener) checkCondition(kind string, name string, cond antlr.TerminalNode, op antlr.TerminalNode, appended bool) bool {
+	if appended && op == nil {
+		listener.errors.SemanticError(cond.GetSymbol(), fmt.Sprintf("appended condition of %s %s must start with 'and' or 'or'", kind, name))
+		return false
+	}
+	if !appended && op != nil {
+		listener.errors.SemanticError(op.GetSymbol(), fmt.Sprintf("condition of %s %s starts with '%s' but does not set 'append: true'", kind, name, op.GetText()))
+		return false
+	}
+	return true
+}
+
+// appendCondition textually appends condition ctx to condition base, joined by the logical operator op,
+// and parses the resulting condition. As in Falco, operator precedence applies across the joined conditions.
+func (listener *sfplListener) appendCondition(base parser.IExpressionContext, op antlr.TerminalNode, ctx parser.IExpressionContext) parser.IExpressionContext {
+	cond := strings.Join([]string{listener.getOffChannelText(base), op.GetText(), listener.getOffChannelText(ctx)}, SPACE)
+	lexer := parser.NewSfplLexer(antlr.NewInputStream(cond))
+	lexer.RemoveErrorListeners()
+	p := parser.NewSfplParser(antlr.NewCommonTokenStream(lexer, antlr.TokenDefaultChannel))
+	p.RemoveErrorListeners()
+	return p.Expression()
+}
+
+func (listener *sfplListener) getAppendFlag(ctxs []parser.IFappendContext) bool {
+	appended := false
+	for _, ctx := range ctxs {
+		flag := trimBoundingQuotes(ctx.GetText())
+		if b, err := strconv.ParseBool(flag); err == nil {
+			appended = appended || b
+		} else {
+			logger.Warn.Println("Unrecognized append flag: ", flag)
+		}
+	}
+	return appended
+}
+
+func (listener *sfplListener) getSkipUnknownFlag(ctx parser.ISkipunknownContext) bool {
+	flag := trimBoundingQuotes(ctx.GetText())
+	if b, err := strconv.ParseBool(flag); err == nil {
+		return b
+	}
+	logger.Warn.Println("Unrecognized skip-if-unknown-filter flag: ", flag)
+	return false
 }
 
 func (listener *sfplListener) getEnabledFlag(ctx parser.IEnabledContext) bool {
@@ -216,7 +886,7 @@ func (listener *sfplListener) getEnabledFlag(ctx parser.IEnabledContext) bool {
 	return true
 }
 
-func (listener *sfplListener) getOffChannelText(ctx parser.ITextContext) string {
+func (listener *sfplListener) getOffChannelText(ctx antlr.ParserRuleContext) string {
 	a := ctx.GetStart().GetStart()
 	b := ctx.GetStop().GetStop()
 	interval := antlr.Interval{Start: a, Stop: b}
@@ -245,52 +915,73 @@ func (listener *sfplListener) getPriority(ctx *parser.PruleContext) Priority {
 	ictx := ctx.Severity(0)
 	if ictx != nil {
 		p := ictx.GetText()
-		switch strings.ToLower(p) {
-		case Low.String():
-			return Low
-		case Medium.String():
-			return Medium
-		case High.String():
-			return High
-		case FPriorityDebug:
-			return Low
-		case FPriorityInfo:
-			return Low
-		case FPriorityInformational:
-			return Low
-		case FPriorityNotice:
-			return Low
-		case FPriorityWarning:
-			return Medium
-		case FPriorityError:
-			return High
-		case FPriorityCritical:
-			return High
-		case FPriorityEmergency:
-			return High
-		default:
-			logger.Warn.Printf("Unrecognized priority value %s. Deferring to %s\n", p, Low.String())
+		if priority, ok := parsePriority(p); ok {
+			return priority
 		}
+		logger.Warn.Printf("Unrecognized priority value %s. Deferring to %s\n", p, Low.String())
 	}
 	return Low
 }
 
+// parsePriority maps priority value p, which is a SysFlow or Falco priority, to a priority level.
+func parsePriority(p string) (Priority, bool) {
+	switch strings.ToLower(p) {
+	case Low.String():
+		return Low, true
+	case Medium.String():
+		return Medium, true
+	case High.String():
+		return High, true
+	case FPriorityDebug:
+		return Low, true
+	case FPriorityInfo:
+		return Low, true
+	case FPriorityInformational:
+		return Low, true
+	case FPriorityNotice:
+		return Low, true
+	case FPriorityWarning:
+		return Medium, true
+	case FPriorityError:
+		return High, true
+	case FPriorityCritical:
+		return High, true
+	case FPriorityEmergency:
+		return High, true
+	}
+	return Low, false
+}
+
+func (listener *sfplListener) getAttrText(ctx *parser.PruleContext, ttype int) parser.ITextContext {
+	for i, c := range ctx.GetChildren() {
+		if t, ok := c.(antlr.TerminalNode); ok && t.GetSymbol().GetTokenType() == ttype {
+			if tctx, ok := ctx.GetChild(i + 2).(parser.ITextContext); ok {
+				return tctx
+			}
+		}
+	}
+	return nil
+}
+
+func (listener *sfplListener) getOutput(ctx *parser.PruleContext) string {
+	if tctx := listener.getAttrText(ctx, parser.SfplParserOUTPUT); tctx != nil {
+		return strings.Join(strings.Fields(listener.getOffChannelText(tctx)), SPACE)
+	}
+	return ""
+}
+
 func (listener *sfplListener) getActions(ctx *parser.PruleContext) []Action {
 	var actions []Action
 	if ctx.OUTPUT(0) != nil {
 		actions = append(actions, Alert)
 	} else if ctx.ACTION(0) != nil {
-		astr := ctx.Text(2).GetText()
+		astr := listener.getAttrText(ctx, parser.SfplParserACTION).GetText()
 		l := listener.extractList(astr)
 		for _, v := range l {
-			switch strings.ToLower(v) {
-			case Alert.String():
-				actions = append(actions, Alert)
-			case Tag.String():
-				actions = append(actions, Tag)
-			case Hash.String():
-				actions = append(actions, Hash)
-			default:
+			a := Action(strings.ToLower(v))
+			if IsRegisteredAction(a) {
+				actions = append(actions, a)
+			} else {
 				logger.Warn.Println("Unrecognized action value ", v)
 			}
 		}
@@ -299,7 +990,11 @@ func (listener *sfplListener) getActions(ctx *parser.PruleContext) []Action {
 }
 
 func (listener *sfplListener) extractList(str string) []string {
-	return strings.Split(itemsre.ReplaceAllString(str, "$2"), LISTSEP)
+	items := itemsre.ReplaceAllString(str, "$2")
+	if items == "" {
+		return []string{}
+	}
+	return strings.Split(items, LISTSEP)
 }
 
 func (listener *sfplListener) extractListFromItems(ctx parser.IItemsContext) []string {
@@ -310,10 +1005,13 @@ func (listener *sfplListener) extractListFromItems(ctx parser.IItemsContext) []s
 }
 
 func (listener *sfplListener) extractTags(ctx parser.ITagsContext) []string {
+	s := []string{}
 	if ctx != nil {
-		return listener.extractList(ctx.GetText())
+		for _, v := range ctx.(*parser.TagsContext).AllAtom() {
+			s = append(s, trimBoundingQuotes(v.GetText()))
+		}
 	}
-	return []string{}
+	return s
 }
 
 func (listener *sfplListener) extractListFromAtoms(ctxs []parser.IAtomContext) []string {
@@ -326,7 +1024,7 @@ func (listener *sfplListener) extractListFromAtoms(ctxs []parser.IAtomContext) [
 
 func (listener *sfplListener) reduceList(sl string) []string {
 	s := []string{}
-	if l, ok := lists[sl]; ok {
+	if l, ok := listener.lists[sl]; ok {
 		for _, v := range l {
 			s = append(s, listener.reduceList(v)...)
 		}
@@ -336,40 +1034,63 @@ func (listener *sfplListener) reduceList(sl string) []string {
 	return s
 }
 
+// visitExpression compiles expression ctx into an optimized criterion.
 func (listener *sfplListener) visitExpression(ctx parser.IExpressionContext) Criterion {
+	return listener.visitExpr(ctx).optimize().compile()
+}
+
+// visitExpr builds the expression tree of expression ctx, expanding macro references.
+func (listener *sfplListener) visitExpr(ctx parser.IExpressionContext) *expr {
 	orCtx := ctx.GetChild(0).(parser.IOr_expressionContext)
-	orPreds := make([]Criterion, 0)
+	orExprs := make([]*expr, 0)
 	for _, andCtx := range orCtx.GetChildren() {
 		if andCtx.GetChildCount() > 0 {
-			andPreds := make([]Criterion, 0)
+			andExprs := make([]*expr, 0)
 			for _, termCtx := range andCtx.GetChildren() {
 				t, isTermCtx := termCtx.(parser.ITermContext)
 				if isTermCtx {
-					c := listener.visitTerm(t)
-					andPreds = append(andPreds, c)
+					andExprs = append(andExprs, listener.visitTermExpr(t))
 				}
 			}
-			orPreds = append(orPreds, All(andPreds))
+			orExprs = append(orExprs, andExpr(andExprs...))
 		}
 	}
-	return Any(orPreds)
+	return orExpr(orExprs...)
 }
 
-func (listener *sfplListener) visitTerm(ctx parser.ITermContext) Criterion {
+// visitTermExpr builds the expression tree of term ctx. Terms with only literal operands are folded into constants.
+func (listener *sfplListener) visitTermExpr(ctx parser.ITermContext) *expr {
 	termCtx := ctx.(*parser.TermContext)
 	if termCtx.Variable() != nil {
-		if m, ok := macroCtxs[termCtx.GetText()]; ok {
-			return listener.visitExpression(m)
+		if m, ok := listener.macroCtxs[termCtx.GetText()]; ok {
+			return listener.visitExpr(m)
 		}
-		logger.Error.Println("Unrecognized reference ", termCtx.GetText())
 	} else if termCtx.NOT() != nil {
-		return listener.visitTerm(termCtx.GetChild(1).(parser.ITermContext)).Not()
+		return notExpr(listener.visitTermExpr(termCtx.GetChild(1).(parser.ITermContext)))
+	} else if termCtx.Expression() != nil {
+		return listener.visitExpr(termCtx.Expression())
+	}
+	c := listener.visitTerm(ctx)
+	if isConstantTerm(termCtx) {
+		return constExpr(c.Eval(nil))
+	}
+	return predExpr(c, termCtx.GetText(), termCost(termCtx))
+}
+
+// visitTerm compiles term ctx, which is not a macro reference, a negation, or a parenthesized expression,
+// into a criterion.
+func (listener *sfplListener) visitTerm(ctx parser.ITermContext) Criterion {
+	termCtx := ctx.(*parser.TermContext)
+	if termCtx.Variable() != nil {
+		logger.Error.Println("Unrecognized reference ", termCtx.GetText())
 	} else if opCtx, ok := termCtx.Unary_operator().(*parser.Unary_operatorContext); ok {
 		lop := termCtx.Atom(0).(*parser.AtomContext).GetText()
 		if opCtx.EXISTS() != nil {
 			return Exists(lop)
 		}
 		logger.Error.Println("Unrecognized unary operator ", opCtx.GetText())
+	} else if termCtx.Arith_expression(0) != nil {
+		return listener.compileArithComparison(termCtx)
 	} else if opCtx, ok := termCtx.Binary_operator().(*parser.Binary_operatorContext); ok {
 		lop := termCtx.Atom(0).(*parser.AtomContext).GetText()
 		rop := termCtx.Atom(1).(*parser.AtomContext).GetText()
@@ -379,34 +1100,192 @@ func (listener *sfplListener) visitTerm(ctx parser.ITermContext) Criterion {
 			return IContains(lop, rop)
 		} else if opCtx.STARTSWITH() != nil {
 			return StartsWith(lop, rop)
+		} else if opCtx.ISTARTSWITH() != nil {
+			return IStartsWith(lop, rop)
 		} else if opCtx.ENDSWITH() != nil {
 			return EndsWith(lop, rop)
+		} else if opCtx.IENDSWITH() != nil {
+			return IEndsWith(lop, rop)
+		} else if opCtx.IEQ() != nil {
+			return IEq(lop, rop)
 		} else if opCtx.EQ() != nil {
-			return Eq(lop, rop)
+			return listener.compileComparison(Eq, lop, rop, termCtx)
 		} else if opCtx.NEQ() != nil {
-			return NEq(lop, rop)
+			return listener.compileComparison(NEq, lop, rop, termCtx)
 		} else if opCtx.GT() != nil {
-			return Gt(lop, rop)
+			return listener.compileComparison(Gt, lop, rop, termCtx)
 		} else if opCtx.GE() != nil {
-			return Ge(lop, rop)
+			return listener.compileComparison(Ge, lop, rop, termCtx)
 		} else if opCtx.LT() != nil {
-			return Lt(lop, rop)
+			return listener.compileComparison(Lt, lop, rop, termCtx)
 		} else if opCtx.LE() != nil {
-			return Le(lop, rop)
+			return listener.compileComparison(Le, lop, rop, termCtx)
+		} else if opCtx.MATCHES() != nil {
+			return listener.compileMatches(Matches, lop, termCtx.Atom(1))
+		} else if opCtx.IMATCHES() != nil {
+			return listener.compileMatches(IMatches, lop, termCtx.Atom(1))
 		}
 		logger.Error.Println("Unrecognized binary operator ", opCtx.GetText())
-	} else if termCtx.Expression() != nil {
-		return listener.visitExpression(termCtx.Expression())
+	} else if termCtx.Aggregate() != nil {
+		listener.reportOnce(termCtx.GetStart(), fmt.Sprintf("aggregate %s is only supported as a top-level conjunct of a rule condition", listener.getOffChannelText(termCtx)))
 	} else if termCtx.IN() != nil {
 		lop := termCtx.Atom(0).(*parser.AtomContext).GetText()
 		rop := termCtx.AllAtom()[1:]
-		return In(lop, listener.extractListFromAtoms(rop))
+		c, err := In(lop, listener.extractListFromAtoms(rop))
+		if err != nil {
+			listener.reportOnce(termCtx.GetStart(), err.Error())
+		}
+		return c
+	} else if termCtx.IIN() != nil {
+		lop := termCtx.Atom(0).(*parser.AtomContext).GetText()
+		rop := termCtx.AllAtom()[1:]
+		c, err := IIn(lop, listener.extractListFromAtoms(rop))
+		if err != nil {
+			listener.reportOnce(termCtx.GetStart(), err.Error())
+		}
+		return c
 	} else if termCtx.PMATCH() != nil {
 		lop := termCtx.Atom(0).(*parser.AtomContext).GetText()
 		rop := termCtx.AllAtom()[1:]
 		return PMatch(lop, listener.extractListFromAtoms(rop))
+	} else if termCtx.INCIDR() != nil {
+		lop := termCtx.Atom(0).(*parser.AtomContext).GetText()
+		rop := termCtx.AllAtom()[1:]
+		c, err := InCIDR(lop, listener.extractListFromAtoms(rop))
+		if err != nil {
+			listener.errors.SemanticError(termCtx.INCIDR().GetSymbol(), err.Error())
+		}
+		return c
 	} else {
 		logger.Warn.Println("Unrecognized term ", termCtx.GetText())
 	}
 	return False
 }
+
+func (listener *sfplListener) compileComparison(compare func(string, string) (Criterion, error), lop string, rop string, ctx parser.ITermContext) Criterion {
+	c, err := compare(lop, rop)
+	if err != nil {
+		listener.reportOnce(ctx.GetStart(), err.Error())
+		return False
+	}
+	return c
+}
+
+// compileArithComparison compiles term ctx, which compares the values of arithmetic expressions.
+func (listener *sfplListener) compileArithComparison(ctx *parser.TermContext) Criterion {
+	c, err := listener.visitArithComparison(ctx)
+	if err != nil {
+		listener.reportOnce(ctx.GetStart(), err.Error())
+		return False
+	}
+	return c
+}
+
+// visitArithComparison compiles the operands of term ctx, and their comparison.
+func (listener *sfplListener) visitArithComparison(ctx *parser.TermContext) (Criterion, error) {
+	l, err := listener.visitArith(ctx.Arith_expression(0))
+	if err != nil {
+		return False, err
+	}
+	r, err := listener.visitArith(ctx.Arith_expression(1))
+	if err != nil {
+		return False, err
+	}
+	return compare(ctx.Binary_operator().GetText(), l, r)
+}
+
+// visitArith compiles arithmetic expression ctx into an operand.
+func (listener *sfplListener) visitArith(ctx antlr.Tree) (operand, error) {
+	return listener.foldArith(ctx.GetChildren(), listener.visitArithTerm)
+}
+
+// visitArithTerm compiles a product of arithmetic factors into an operand.
+func (listener *sfplListener) visitArithTerm(ctx antlr.Tree) (operand, error) {
+	return listener.foldArith(ctx.GetChildren(), listener.visitArithFactor)
+}
+
+// visitArithFactor compiles a function call, a parenthesized arithmetic expression, or an atom into an operand.
+func (listener *sfplListener) visitArithFactor(ctx antlr.Tree) (operand, error) {
+	factorCtx := ctx.(*parser.Arith_factorContext)
+	if fnCtx, ok := factorCtx.Function().(*parser.FunctionContext); ok {
+		var args []operand
+		for _, a := range fnCtx.AllArith_expression() {
+			o, err := listener.visitArith(a)
+			if err != nil {
+				return o, err
+			}
+			args = append(args, o)
+		}
+		return call(listener.getOffChannelText(fnCtx), fnCtx.ID().GetText(), args)
+	} else if e := factorCtx.Arith_expression(); e != nil {
+		return listener.visitArith(e)
+	}
+	return attrOperand(factorCtx.Atom().GetText()), nil
+}
+
+// foldArith compiles operands separated by arithmetic operators into an operand, from left to right.
+func (listener *sfplListener) foldArith(children []antlr.Tree, visit func(antlr.Tree) (operand, error)) (operand, error) {
+	o, err := visit(children[0])
+	for i := 1; err == nil && i+1 < len(children); i += 2 {
+		var r operand
+		if r, err = visit(children[i+1]); err == nil {
+			o, err = arith(children[i].(antlr.TerminalNode).GetText(), o, r)
+		}
+	}
+	return o, err
+}
+
+func (listener *sfplListener) compileMatches(matches func(string, string) (Criterion, error), lop string, ctx parser.IAtomContext) Criterion {
+	rop := ctx.GetText()
+	c, err := matches(lop, trimBoundingQuotes(rop))
+	if err != nil {
+		listener.errors.SemanticError(ctx.GetStart(), fmt.Sprintf("invalid regular expression %s: %v", rop, err))
+		return False
+	}
+	return c
+}
+
+// findUnknownFields returns the operands of condition ctx that are named like attributes but have no field map,
+// excluding those in referenced macros. List items are literals, and are not checked.
+func findUnknownFields(ctx antlr.Tree) []antlr.Token {
+	var tokens []antlr.Token
+	var atoms []parser.IAtomContext
+	switch t := ctx.(type) {
+	case *parser.TermContext:
+		atoms = t.AllAtom()
+		if t.IN() != nil || t.IIN() != nil || t.PMATCH() != nil || t.INCIDR() != nil {
+			atoms = atoms[:1]
+		}
+	case *parser.AggregateContext:
+		atoms = t.AllAtom()
+	case *parser.Arith_factorContext:
+		if t.Atom() != nil {
+			atoms = []parser.IAtomContext{t.Atom()}
+		}
+	}
+	for _, a := range atoms {
+		if id := a.(*parser.AtomContext).ID(); id != nil && Mapper.IsUnknownField(id.GetText()) {
+			tokens = append(tokens, id.GetSymbol())
+		}
+	}
+	for _, c := range ctx.GetChildren() {
+		if _, ok := c.(parser.IAtomContext); !ok {
+			tokens = append(tokens, findUnknownFields(c)...)
+		}
+	}
+	return tokens
+}
+
+// isDefsPass returns whether ctx is visited in the pre-processing pass over macro and list definitions.
+func isDefsPass(ctx antlr.ParserRuleContext) bool {
+	_, ok := ctx.GetParent().(*parser.DefsContext)
+	return ok
+}
+
+// logicalOperator returns the logical operator that starts an appended condition, or nil if there's none.
+func logicalOperator(or antlr.TerminalNode, and antlr.TerminalNode) antlr.TerminalNode {
+	if or != nil {
+		return or
+	}
+	return and
+}
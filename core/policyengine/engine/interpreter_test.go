@@ -20,13 +20,24 @@
 package engine_test
 
 import (
+	"errors"
+	"fmt"
+	"io/ioutil"
 	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/sysflow-telemetry/sf-apis/go/ioutils"
 	"github.com/sysflow-telemetry/sf-apis/go/logger"
+	"github.com/sysflow-telemetry/sf-apis/go/sfgo"
+	"github.com/sysflow-telemetry/sf-processor/core/cache"
 	. "github.com/sysflow-telemetry/sf-processor/core/policyengine/engine"
+	"github.com/sysflow-telemetry/sf-processor/core/policyengine/lang/errorhandler"
 )
 
 var pi PolicyInterpreter
@@ -40,7 +51,27 @@ func TestCompile(t *testing.T) {
 	logger.Trace.Println("Running test compile")
 	paths, err := ioutils.ListFilePaths("../../../resources/policies/tests", ".yaml")
 	assert.NoError(t, err)
-	assert.NoError(t, pi.Compile(paths...))
+	// unit test policies may define the same lists and macros, so each one is
+	// compiled on its own, together with the _more policy that appends to it.
+	for _, path := range paths {
+		if strings.HasSuffix(path, "_more.yaml") {
+			continue
+		}
+		files := []string{path}
+		if more := strings.TrimSuffix(path, ".yaml") + "_more.yaml"; contains(paths, more) {
+			files = append(files, more)
+		}
+		assert.NoError(t, pi.Compile(files...), path)
+	}
+}
+
+func contains(paths []string, path string) bool {
+	for _, p := range paths {
+		if p == path {
+			return true
+		}
+	}
+	return false
 }
 
 func TestCompileDist(t *testing.T) {
@@ -49,3 +80,831 @@ func TestCompileDist(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NoError(t, pi.Compile(paths...))
 }
+
+// testPolicy returns the path of unit test policy file name.
+func testPolicy(name string) string {
+	return filepath.Join("../../../resources/policies/tests", name)
+}
+
+// compilePolicy compiles policy with interpreter pi from a temporary policy file.
+func compilePolicy(t *testing.T, pi *PolicyInterpreter, policy string) error {
+	f, err := ioutil.TempFile("", "policy*.yaml")
+	assert.NoError(t, err)
+	defer os.Remove(f.Name())
+	_, err = f.WriteString(policy)
+	assert.NoError(t, err)
+	assert.NoError(t, f.Close())
+	return pi.Compile(f.Name())
+}
+
+func countMatches(pi *PolicyInterpreter) int {
+	r := NewRecord(sfgo.FlatRecord{}, nil)
+	pi.Process(true, false, r)
+	return len(r.Ctx.GetRules())
+}
+
+func TestRecompile(t *testing.T) {
+	a := testPolicy("unit_test_recompile.yaml")
+	b := testPolicy("unit_test_recompile_more.yaml")
+
+	pi1 := NewPolicyInterpreter(Config{})
+	pi2 := NewPolicyInterpreter(Config{})
+	assert.NoError(t, pi1.Compile(a))
+	assert.NoError(t, pi2.Compile(a, b))
+	assert.Equal(t, 1, countMatches(pi1))
+	assert.Equal(t, 2, countMatches(pi2))
+
+	// recompiling replaces the active policy set
+	assert.NoError(t, pi1.Compile(a))
+	assert.NoError(t, pi1.Compile(a))
+	assert.Equal(t, 1, countMatches(pi1))
+	assert.Equal(t, 2, countMatches(pi2))
+}
+
+func TestCaseInsensitiveOperators(t *testing.T) {
+	pi := NewPolicyInterpreter(Config{})
+	assert.NoError(t, pi.Compile(testPolicy("unit_test_icase_exceptions.yaml")))
+	_, r := pi.Process(true, false, newProcRecord("/bin/sh", "-c /tmp/run.sh"))
+	assert.Equal(t, 1, len(r.Ctx.GetRules()))
+	_, r = pi.Process(true, false, newProcRecord("/bin/sh", "-c /opt/install.sh"))
+	assert.Equal(t, 0, len(r.Ctx.GetRules()))
+	_, r = pi.Process(true, false, newProcRecord("/bin/sh", "-x /tmp/run.sh"))
+	assert.Equal(t, 0, len(r.Ctx.GetRules()))
+}
+
+func TestKeywordNames(t *testing.T) {
+	pi := NewPolicyInterpreter(Config{})
+	assert.NoError(t, pi.Compile(testPolicy("unit_test_keywords.yaml")))
+	for exe, match := range map[string]bool{"/bin/imatches": true, "/bin/in_cidr": true, "/usr/bin/iin": true, "/bin/sh": false} {
+		_, r := pi.Process(true, false, newProcRecord(exe, ""))
+		var rules []string
+		for _, rule := range r.Ctx.GetRules() {
+			rules = append(rules, rule.Name)
+		}
+		if match {
+			assert.Contains(t, rules, "Keywords as names", exe)
+		} else {
+			assert.NotContains(t, rules, "Keywords as names", exe)
+		}
+	}
+}
+
+func TestCompileErrors(t *testing.T) {
+	rule := "- rule: R\n  desc: r\n  condition: %s\n  priority: low\n"
+	exc := fmt.Sprintf(rule, "a = a") + "  exceptions:\n"
+	seq := "- sequence: S\n  desc: s\n  priority: low\n"
+	steps := "  steps:\n    - condition: a = a\n    - condition: b = b\n"
+	for _, c := range []struct {
+		name   string
+		policy string
+		line   int
+		column int
+		msg    string
+	}{
+		{"invalid regex", fmt.Sprintf(rule, "sf.proc.exe matches \"^/bin/(sh\""), 3, 33, "invalid regular expression \"^/bin/(sh\""},
+		{"invalid cidr", fmt.Sprintf(rule, "sf.net.dip in_cidr (10.0.0.0/33)"), 3, 24, "invalid CIDR subnet 10.0.0.0/33"},
+
+		{"type: int literal", fmt.Sprintf(rule, "sf.proc.pid = bash"), 3, 13, "cannot compare int attribute sf.proc.pid with non-int value bash"},
+		{"type: attributes", fmt.Sprintf(rule, "sf.proc.uid != sf.proc.user"), 3, 13, "cannot compare int attribute sf.proc.uid with string attribute sf.proc.user"},
+		{"type: ordering", fmt.Sprintf(rule, "sf.proc.exe > 1"), 3, 13, "cannot order string attribute sf.proc.exe, only int attributes are ordered"},
+		{"type: bool literal", fmt.Sprintf(rule, "sf.proc.tty = yes"), 3, 13, "cannot compare bool attribute sf.proc.tty with non-bool value yes"},
+		{"type: list item", fmt.Sprintf(rule, "sf.net.dport in (22, ssh)"), 3, 13, "cannot compare int attribute sf.net.dport with non-int value ssh"},
+		{"type: case-insensitive list item", fmt.Sprintf(rule, "sf.net.dport iin (22, SSH)"), 3, 13, "cannot compare int attribute sf.net.dport with non-int value SSH"},
+		{"arith: string term", fmt.Sprintf(rule, "sf.proc.exe + 1 > 2"), 3, 13, "cannot apply + to string value sf.proc.exe"},
+		{"arith: literal term", fmt.Sprintf(rule, "sf.proc.pid * abc = 1"), 3, 13, "cannot apply * to non-int value abc"},
+		{"arith: len type", fmt.Sprintf(rule, "len(sf.proc.pid) > 1"), 3, 13, "function len takes string arguments, got int value sf.proc.pid"},
+		{"arith: arity", fmt.Sprintf(rule, "len(sf.proc.exe, sf.proc.args) > 1"), 3, 13, "function len takes 1 argument(s), got 2"},
+		{"arith: function", fmt.Sprintf(rule, "size(sf.proc.exe) > 1"), 3, 13, "unknown function size"},
+		{"arith: int result", fmt.Sprintf(rule, "len(sf.proc.exe) = sf.proc.exe"), 3, 13, "cannot compare int value len(sf.proc.exe) with string value sf.proc.exe"},
+		{"arith: string result", fmt.Sprintf(rule, "basename(sf.proc.exe) > 1"), 3, 13, "cannot order string value basename(sf.proc.exe), only int values are ordered"},
+		{"arith: regex operand", fmt.Sprintf(rule, "basename(sf.proc.exe) matches sf.proc.name"), 3, 13, "cannot match basename(sf.proc.exe) with non-literal regular expression sf.proc.name"},
+		{"arith: unknown field", fmt.Sprintf(rule, "len(sf.proc.unknown) > 1"), 3, 17, "unknown field sf.proc.unknown in rule R"},
+
+		{"append: redefine list", "- list: l\n  items: [a]\n- list: l\n  items: [b]\n", 3, 8, "list l is already defined, set 'append: true' to append to it"},
+		{"append: redefine macro", "- macro: m\n  condition: a = a\n- macro: m\n  condition: b = b\n", 3, 9, "macro m is already defined, set 'append: true' to append to it"},
+		{"append: redefine rule", fmt.Sprintf(rule, "a = a") + fmt.Sprintf(rule, "b = b"), 5, 8, "rule R is already defined, set 'append: true' to append to it"},
+		{"append: list", "- list: l\n  append: true\n  items: [a]\n", 1, 8, "cannot append to undefined list l"},
+		{"append: macro", "- macro: m\n  append: true\n  condition: or a = a\n", 1, 9, "cannot append to undefined macro m"},
+		{"append: rule", "- rule: R\n  append: true\n  condition: and a = a\n", 1, 8, "cannot append to undefined rule R"},
+		{"append: missing operator", "- macro: m\n  condition: a = a\n- macro: m\n  append: true\n  condition: b = b\n", 5, 2, "appended condition of macro m must start with 'and' or 'or'"},
+		{"append: missing append", "- macro: m\n  condition: and a = a\n", 2, 13, "condition of macro m starts with 'and' but does not set 'append: true'"},
+		{"append: missing desc", "- rule: R\n  condition: a = a\n  priority: low\n", 1, 8, "rule R has no desc"},
+
+		{"unknown: rule", fmt.Sprintf(rule, "sf.proc.nmae = bash"), 3, 13, "unknown field sf.proc.nmae in rule R"},
+		{"unknown: rop", fmt.Sprintf(rule, "sf.proc.uid = sf.pproc.iud"), 3, 27, "unknown field sf.pproc.iud in rule R"},
+		{"unknown: falco", fmt.Sprintf(rule, "proc.nmae in (bash)"), 3, 13, "unknown field proc.nmae in rule R"},
+		{"unknown: extended", fmt.Sprintf(rule, "ext.proc.foo exists"), 3, 13, "unknown field ext.proc.foo in rule R"},
+		{"unknown: macro", "- macro: m\n  condition: sf.proc.nmae = bash\n" + fmt.Sprintf(rule, "not m"), 2, 13, "unknown field sf.proc.nmae in macro m used by rule R"},
+		{"unknown: appended rule", fmt.Sprintf(rule, "sf.proc.name = bash") + "- rule: R\n  append: true\n  condition: and sf.file.pth = /etc\n", 7, 17, "unknown field sf.file.pth in rule R"},
+		{"unknown: filter", "- filter: f\n  condition: sf.proc.nmae = bash\n", 2, 13, "unknown field sf.proc.nmae in filter f"},
+
+		{"exception: unknown field", exc + "    - name: e\n      fields: [sf.proc.nmae]\n", 6, 12, "unknown field sf.proc.nmae in exception e of rule R"},
+		{"exception: no fields", exc + "    - name: e\n      values: [a]\n", 6, 12, "no fields in exception e of rule R"},
+		{"exception: comps", exc + "    - name: e\n      fields: [sf.proc.exe, sf.proc.args]\n      comps: [=]\n", 6, 12, "1 comps for 2 fields in exception e of rule R"},
+		{"exception: tuple size", exc + "    - name: e\n      fields: [sf.proc.exe, sf.proc.args]\n      values: [[a]]\n", 8, 15, "1 values for 2 fields in exception e of rule R"},
+		{"exception: value type", exc + "    - name: e\n      fields: [sf.proc.pid]\n      values: [bash]\n", 8, 15, "cannot compare int attribute sf.proc.pid with non-int value bash in exception e of rule R"},
+		{"exception: single value", exc + "    - name: e\n      fields: [sf.proc.exe]\n      comps: [startswith]\n      values: [[[a, b]]]\n", 9, 15, "comparison startswith of field sf.proc.exe takes a single value, got 2 in exception e of rule R"},
+		{"exception: duplicate", exc + "    - name: e\n      fields: [sf.proc.exe]\n    - name: e\n      fields: [sf.proc.exe]\n", 8, 12, "exception e of rule R is already defined"},
+		{"exception: redefine", exc + "    - name: e\n      fields: [sf.proc.exe]\n- rule: R\n  append: true\n  exceptions:\n    - name: e\n      fields: [sf.proc.args]\n", 11, 12, "cannot redefine the fields of exception e of rule R, only values can be appended"},
+		{"exception: empty append", fmt.Sprintf(rule, "a = a") + "- rule: R\n  append: true\n", 5, 8, "appended rule R has no condition or exceptions"},
+		{"exception: no condition", "- rule: R\n  desc: r\n  priority: low\n", 1, 8, "rule R has no condition"},
+		{"exception: bad condition", fmt.Sprintf(rule, ")"), 3, 2, "no viable alternative at input"},
+
+		{"sequence: one step", seq + "  key: sf.proc.pid\n  window: 60\n  steps:\n    - condition: a = a\n", 1, 12, "sequence S has 1 steps, at least 2 are required"},
+		{"sequence: no steps", seq + "  key: sf.proc.pid\n  window: 60\n", 1, 12, "sequence S has no steps"},
+		{"sequence: no window", seq + "  key: sf.proc.pid\n" + steps, 1, 12, "sequence S has no window"},
+		{"sequence: window", seq + "  key: sf.proc.pid\n  window: soon\n" + steps, 5, 10, "invalid window soon in sequence S"},
+		{"sequence: no key", seq + "  window: 60\n" + steps, 1, 12, "step 1 of sequence S has no key"},
+		{"sequence: key fields", seq + "  key: sf.proc.pid\n  window: 60\n" + steps + "      key: [sf.proc.pid, sf.proc.exe]\n", 1, 12, "step 2 of sequence S has 2 key fields, expected 1"},
+		{"sequence: unknown key", seq + "  key: sf.proc.nmae\n  window: 60\n" + steps, 1, 12, "unknown key field sf.proc.nmae in sequence S"},
+		{"sequence: unknown field", seq + "  key: sf.proc.pid\n  window: 60\n  steps:\n    - condition: sf.proc.nmae = a\n    - condition: b = b\n", 7, 17, "unknown field sf.proc.nmae in sequence S"},
+		{"sequence: condition", "- sequence: S\n  desc: s\n  condition: a = a\n  key: sf.proc.pid\n  window: 60\n" + steps, 1, 12, "sequence S has a condition, sequence conditions are defined by steps"},
+		{"sequence: rule steps", fmt.Sprintf(rule, "a = a") + steps, 1, 8, "rule R has key, window or steps, which are only supported by sequences"},
+		{"sequence: append", seq + "  key: sf.proc.pid\n  window: 60\n" + steps + "- rule: S\n  append: true\n  condition: and c = c\n", 9, 8, "sequence S cannot be appended"},
+
+		{"aggregate: count attribute", fmt.Sprintf(rule, "count(sf.proc.pid) > 1 within 10s"), 3, 13, "aggregate count takes no attribute"},
+		{"aggregate: sum attribute", fmt.Sprintf(rule, "sum() > 1 within 10s"), 3, 13, "aggregate sum takes an attribute"},
+		{"aggregate: sum type", fmt.Sprintf(rule, "sum(sf.proc.exe) > 1 within 10s"), 3, 13, "aggregate sum of non-integer attribute sf.proc.exe"},
+		{"aggregate: unknown field", fmt.Sprintf(rule, "sum(sf.proc.nmae) > 1 within 10s"), 3, 17, "unknown field sf.proc.nmae in rule R"},
+		{"aggregate: function", fmt.Sprintf(rule, "avg(sf.proc.pid) > 1 within 10s"), 3, 13, "unknown aggregate function avg"},
+		{"aggregate: operator", fmt.Sprintf(rule, "count() contains 1 within 10s"), 3, 13, "unsupported comparison contains of aggregate count"},
+		{"aggregate: threshold", fmt.Sprintf(rule, "count() > many within 10s"), 3, 23, "invalid aggregate threshold many"},
+		{"aggregate: window", fmt.Sprintf(rule, "count() > 1 within soon"), 3, 32, "invalid aggregate window soon"},
+		{"aggregate: key", fmt.Sprintf(rule, "count() > 1 within 10s by sf.proc.nmae"), 3, 39, "unknown field sf.proc.nmae in rule R"},
+		{"aggregate: or", fmt.Sprintf(rule, "a = a or count() > 1 within 10s"), 3, 22, "aggregate count() > 1 within 10s is only supported as a top-level conjunct of a rule condition"},
+		{"aggregate: not", fmt.Sprintf(rule, "not count() > 1 within 10s"), 3, 17, "aggregate count() > 1 within 10s is only supported as a top-level conjunct of a rule condition"},
+		{"aggregate: macro", "- macro: m\n  condition: count() > 1 within 10s\n" + fmt.Sprintf(rule, "m"), 2, 13, "aggregate count() > 1 within 10s is only supported as a top-level conjunct of a rule condition"},
+		{"aggregate: filter", "- filter: f\n  condition: count() > 1 within 10s\n", 2, 13, "aggregate count() > 1 within 10s is only supported as a top-level conjunct of a rule condition"},
+	} {
+		var ce *CompileError
+		if assert.True(t, errors.As(compilePolicy(t, NewPolicyInterpreter(Config{}), c.policy), &ce), c.name) {
+			err := ce.Errors[0].(*errorhandler.SfplSyntaxError)
+			assert.Equal(t, c.line, err.Line(), c.name)
+			assert.Equal(t, c.column, err.Column(), c.name)
+			assert.Contains(t, err.Msg(), c.msg, c.name)
+		}
+	}
+}
+
+func TestArithmeticExpressions(t *testing.T) {
+	for cond, matched := range map[string]bool{
+		"sf.ts + sf.proc.pid > 1000":                     true,
+		"sf.ts + sf.proc.pid > 2000":                     false,
+		"(sf.ts - sf.proc.pid) * 2 = 1500":               true,
+		"sf.ts / 0 = 0 and sf.ts % 7 = 6":                true,
+		"len(sf.proc.args) > 4":                          true,
+		"len(sf.proc.args) = 2 + 3 * 4":                  true,
+		"basename(sf.proc.exe) = sh":                     true,
+		"dirname(sf.proc.exe) != /bin":                   false,
+		"dirname(dirname(sf.proc.exe)) = /":              true,
+		"upper(basename(sf.proc.exe)) contains SH":       true,
+		"lower(sf.proc.args) matches \"^-c /tmp/\"":      true,
+		"basename(sf.proc.exe) = basename(sf.proc.args)": false,
+	} {
+		pi := NewPolicyInterpreter(Config{})
+		assert.NoError(t, compilePolicy(t, pi, "- rule: A\n  desc: rule a\n  condition: "+cond+"\n  action: [alert]\n  priority: low\n"), cond)
+		r := newProcRecord("/bin/sh", "-c /tmp/run.sh")
+		r.Fr.Ints[0][sfgo.TS_INT] = 1000
+		r.Fr.Ints[0][sfgo.PROC_OID_HPID_INT] = 250
+		_, r = pi.Process(true, false, r)
+		assert.Equal(t, matched, len(r.Ctx.GetRules()) == 1, cond)
+	}
+}
+
+func TestOutput(t *testing.T) {
+	pi := NewPolicyInterpreter(Config{})
+	assert.NoError(t, pi.Compile(testPolicy("unit_test_output.yaml")))
+	fr := sfgo.FlatRecord{
+		Sources: []sfgo.Source{sfgo.SYSFLOW_SRC},
+		Ints:    [][]int64{make([]int64, sfgo.INT_ARRAY_SIZE)},
+		Strs:    [][]string{make([]string, sfgo.STR_ARRAY_SIZE)},
+	}
+	fr.Strs[0][sfgo.PROC_EXE_STR] = "/usr/bin/curl"
+	fr.Strs[0][sfgo.PROC_EXEARGS_STR] = "-s example.com"
+	r := NewRecord(fr, nil)
+	pi.Process(true, false, r)
+	rules := r.Ctx.GetRules()
+	assert.Equal(t, 1, len(rules))
+	assert.Equal(t, "Process /usr/bin/curl executed (-s example.com %unknown.field).", r.Ctx.GetOutput(rules[0]))
+}
+
+func newProcRecord(exe string, args string) *Record {
+	fr := sfgo.FlatRecord{
+		Sources: []sfgo.Source{sfgo.SYSFLOW_SRC},
+		Ints:    [][]int64{make([]int64, sfgo.INT_ARRAY_SIZE)},
+		Strs:    [][]string{make([]string, sfgo.STR_ARRAY_SIZE)},
+	}
+	fr.Strs[0][sfgo.PROC_EXE_STR] = exe
+	fr.Strs[0][sfgo.PROC_EXEARGS_STR] = args
+	return NewRecord(fr, nil)
+}
+
+func TestAppend(t *testing.T) {
+	a := testPolicy("unit_test_append.yaml")
+	b := testPolicy("unit_test_append_more.yaml")
+
+	pi := NewPolicyInterpreter(Config{})
+	assert.NoError(t, pi.Compile(a, b))
+	for _, c := range []struct {
+		exe   string
+		args  string
+		match bool
+	}{
+		{"/bin/sh", "", true},
+		{"/bin/bash", "", true},
+		{"/bin/zsh", "", true},
+		{"/bin/bash", "-c ignore", false},
+		{"/usr/bin/curl", "", false},
+	} {
+		match, _ := pi.Process(true, false, newProcRecord(c.exe, c.args))
+		assert.Equal(t, c.match, match, c.exe+" "+c.args)
+	}
+
+	// appends must follow the definitions they extend
+	assert.Error(t, NewPolicyInterpreter(Config{}).Compile(b, a))
+}
+
+func TestRequiredEngineVersion(t *testing.T) {
+	rule := "- rule: A\n  desc: rule a\n  condition: a = a\n  action: [alert]\n  priority: low\n"
+	for _, c := range []struct {
+		engine   string
+		key      string
+		required string
+		ok       bool
+	}{
+		{"0.2.2", "required_sysflow_version", "0.2.2", true},
+		{"0.2.2", "required_sysflow_version", "0.2", true},
+		{"0.2.2", "required_sysflow_version", "v0.1.9", true},
+		{"0.2.2", "required_sysflow_version", "0.2.2-rc1", true},
+		{"0.2.2", "required_sysflow_version", `"0.2.2+5"`, true},
+		{"0.2.2-rc1", "required_sysflow_version", "0.2.2", false},
+		{"0.2.2-rc1", "required_sysflow_version", "0.2.2-rc1", true},
+		{"0.2.2-rc1", "required_sysflow_version", "0.2.2-rc2", false},
+		{"0.2.2-rc.2", "required_sysflow_version", "0.2.2-rc.10", false},
+		{"0.2.2-rc.10", "required_sysflow_version", "0.2.2-rc.2", true},
+		{"0.2.2-1", "required_sysflow_version", "0.2.2-alpha", false},
+		{"0.2.2-alpha.1", "required_sysflow_version", "0.2.2-alpha", true},
+		{"0.2.2", "required_sysflow_version", "0.2.3", false},
+		{"0.2.2", "required_sysflow_version", "0.10", false},
+		{"0.2.2", "required_sysflow_version", "1", false},
+		{"0.2.2", "required_sysflow_version", "1.0", false},
+		{"0.2.2", "required_sysflow_version", "seven", false},
+		{"", "required_sysflow_version", "1.0", true},
+		{"SYSFLOW_VERSION", "required_sysflow_version", "1.0", true},
+		{"0.2.2", "required_engine_version", "7", true},
+		{"0.2.2", "required_engine_version", "0.3.0", false},
+		{"", "required_engine_version", "7", true},
+	} {
+		name := c.engine + " " + c.key + " " + c.required
+		err := compilePolicy(t, NewPolicyInterpreter(Config{Version: c.engine}), "- "+c.key+": "+c.required+"\n\n"+rule)
+		if c.ok {
+			assert.NoError(t, err, name)
+		} else {
+			assert.Error(t, err, name)
+		}
+	}
+}
+
+func TestUnknownFields(t *testing.T) {
+	for name, policy := range map[string]string{
+		"skip":          "- rule: A\n  desc: rule a\n  condition: a = a or sf.proc.nmae = bash\n  action: [alert]\n  priority: low\n  skip-if-unknown-filter: true\n",
+		"skip macro":    "- macro: m\n  condition: sf.proc.nmae = bash\n- rule: A\n  desc: rule a\n  condition: a = a or m\n  action: [alert]\n  priority: low\n  skip-if-unknown-filter: true\n",
+		"skip appended": "- rule: A\n  desc: rule a\n  condition: a = a\n  action: [alert]\n  priority: low\n  skip-if-unknown-filter: true\n- rule: A\n  append: true\n  condition: or sf.file.pth = /etc\n",
+		"unused macro":  "- macro: m\n  condition: sf.proc.nmae = bash\n",
+	} {
+		pi := NewPolicyInterpreter(Config{})
+		assert.NoError(t, compilePolicy(t, pi, policy), name)
+		assert.Equal(t, 0, countMatches(pi), name)
+	}
+
+	pi := NewPolicyInterpreter(Config{})
+	assert.NoError(t, compilePolicy(t, pi, "- rule: A\n  desc: rule a\n  condition: a = a and not sf.proc.name in (proc.nmae, sf.foo) and not sf.proc.exe pmatch (sf.bar)\n  action: [alert]\n  priority: low\n"))
+	assert.Equal(t, 1, countMatches(pi))
+}
+
+func TestExceptions(t *testing.T) {
+	pi := NewPolicyInterpreter(Config{})
+	assert.NoError(t, pi.Compile(testPolicy("unit_test_exceptions.yaml"), testPolicy("unit_test_exceptions_more.yaml")))
+	for _, c := range []struct {
+		exe   string
+		args  string
+		match bool
+	}{
+		{"/usr/bin/curl", "-s example.com", false},
+		{"/usr/bin/curl", "example.com", true},
+		{"/usr/bin/fetch", "-q example.com", false},
+		{"/usr/bin/fetch", "example.com", true},
+		{"/usr/bin/apt", "install", false},
+		{"/usr/bin/top", "", false},
+		{"/usr/bin/git", "clone repo", false},
+		{"/usr/bin/git", "push", true},
+		{"/usr/bin/ls", "--version", false},
+		{"/usr/bin/ls", "", true},
+		{"/bin/sh", "", false},
+	} {
+		match, _ := pi.Process(true, false, newProcRecord(c.exe, c.args))
+		assert.Equal(t, c.match, match, c.exe+" "+c.args)
+	}
+}
+
+func newSeqRecord(ts int64, ct string, exe string, args string) *Record {
+	r := newProcRecord(exe, args)
+	r.Fr.Ints[0][sfgo.TS_INT] = ts * int64(time.Second)
+	r.Fr.Strs[0][sfgo.CONT_ID_STR] = ct
+	return r
+}
+
+func TestSequences(t *testing.T) {
+	pi := NewPolicyInterpreter(Config{})
+	assert.NoError(t, pi.Compile(testPolicy("unit_test_sequence.yaml")))
+	first := newSeqRecord(0, "c1", "/usr/bin/curl", "/tmp/x")
+	for _, c := range []struct {
+		r     *Record
+		match bool
+	}{
+		{first, false},
+		{newSeqRecord(10, "c2", "/tmp/x", ""), false},
+		{newSeqRecord(20, "c1", "/tmp/y", ""), false},
+		{newSeqRecord(30, "c1", "/tmp/x", ""), true},
+		{newSeqRecord(40, "c1", "/tmp/x", ""), false},
+		{newSeqRecord(100, "c1", "/usr/bin/curl", "/tmp/x"), false},
+		{newSeqRecord(200, "c1", "/tmp/x", ""), false},
+		{newSeqRecord(300, "", "/usr/bin/curl", "/tmp/x"), false},
+		{newSeqRecord(310, "", "/tmp/x", ""), false},
+	} {
+		match, r := pi.Process(true, false, c.r)
+		assert.Equal(t, c.match, match)
+		if match {
+			recs := r.Ctx.GetSequence(r.Ctx.GetRules()[0])
+			assert.Equal(t, []*Record{first, c.r}, recs)
+		}
+	}
+}
+
+func TestSequenceKeys(t *testing.T) {
+	pi := NewPolicyInterpreter(Config{})
+	assert.NoError(t, pi.Compile(testPolicy("unit_test_sequence_keys.yaml")))
+	// partial matches are kept for at most 65536 keys, dropping the oldest ones
+	for i := 0; i <= 1<<16; i++ {
+		pi.Process(true, false, newSeqRecord(0, "c1", "/usr/bin/curl", strconv.Itoa(i)))
+	}
+	match, _ := pi.Process(true, false, newSeqRecord(1, "c1", "/bin/sh", "0"))
+	assert.False(t, match)
+	match, _ = pi.Process(true, false, newSeqRecord(1, "c1", "/bin/sh", "1"))
+	assert.True(t, match)
+	match, _ = pi.Process(true, false, newSeqRecord(1, "c1", "/bin/sh", strconv.Itoa(1<<16)))
+	assert.True(t, match)
+}
+
+func TestAggregates(t *testing.T) {
+	pi := NewPolicyInterpreter(Config{})
+	assert.NoError(t, pi.Compile(testPolicy("unit_test_aggregates.yaml")))
+	for i, c := range []struct {
+		ts    int64
+		ct    string
+		exe   string
+		bytes int64
+		match bool
+	}{
+		{0, "c1", "/usr/bin/nc", 0, false},
+		{1, "c1", "/usr/bin/nc", 0, false},
+		{1, "c1", "/usr/bin/ls", 0, false},
+		{2, "c1", "/usr/bin/nc", 0, true},
+		{3, "c1", "/usr/bin/nc", 0, true},
+		{3, "c2", "/usr/bin/nc", 0, false},
+		{20, "c1", "/usr/bin/nc", 0, false},
+		{0, "c1", "/usr/bin/scp", 600, false},
+		{30, "c2", "/usr/bin/scp", 600, false},
+		{30, "c1", "/usr/bin/scp", 500, true},
+		{100, "c1", "/usr/bin/scp", 500, false},
+	} {
+		r := newSeqRecord(c.ts, c.ct, c.exe, "")
+		// File and network flow attributes share flat indices, so sf.flow.wbytes sums the value twice.
+		r.Fr.Ints[0][sfgo.FL_NETW_NUMWSENDBYTES_INT] = c.bytes / 2
+		match, _ := pi.Process(true, false, r)
+		assert.Equal(t, c.match, match, i)
+	}
+}
+
+func TestDispatch(t *testing.T) {
+	pi := NewPolicyInterpreter(Config{})
+	assert.NoError(t, pi.Compile(testPolicy("unit_test_dispatch.yaml")))
+	for _, c := range []struct {
+		rtype   int64
+		opflags int64
+		rules   []string
+	}{
+		{sfgo.PROC_EVT, sfgo.OP_EXEC, []string{"Dispatch exec", "Dispatch any"}},
+		{sfgo.FILE_FLOW, sfgo.OP_OPEN, []string{"Dispatch not process", "Dispatch file or connect", "Dispatch any"}},
+		{sfgo.NET_FLOW, sfgo.OP_CONNECT, []string{"Dispatch network", "Dispatch not process", "Dispatch file or connect", "Dispatch any"}},
+		{sfgo.FILE_FLOW, sfgo.OP_CONNECT, []string{"Dispatch not process", "Dispatch file or connect", "Dispatch any"}},
+		{sfgo.NET_FLOW, sfgo.OP_CLOSE, []string{"Dispatch network", "Dispatch not process", "Dispatch any"}},
+	} {
+		r := newProcRecord("/bin/sh", "")
+		r.Fr.Ints[0][sfgo.SF_REC_TYPE] = c.rtype
+		r.Fr.Ints[0][sfgo.EV_PROC_OPFLAGS_INT] = c.opflags
+		pi.Process(true, false, r)
+		var names []string
+		for _, rule := range r.Ctx.GetRules() {
+			names = append(names, rule.Name)
+		}
+		assert.Equal(t, c.rules, names)
+	}
+}
+
+func TestOptimizedConditions(t *testing.T) {
+	rule := "- macro: shell\n  condition: sf.proc.exe in (/bin/sh, /bin/bash)\n" +
+		"- rule: R\n  desc: r\n  condition: %s\n  action: [alert]\n  priority: low\n"
+	for _, c := range []struct {
+		cond  string
+		exe   string
+		match bool
+	}{
+		{"a = a and shell", "/bin/sh", true},
+		{"a = b and shell", "/bin/sh", false},
+		{"a = b or shell", "/bin/bash", true},
+		{"a = a or sf.proc.exe = /bin/sh", "/bin/ls", true},
+		{"not a = b and shell", "/bin/sh", true},
+		{"not not shell", "/bin/sh", true},
+		{"not not shell", "/bin/ls", false},
+		{"shell and (shell and sf.proc.args = '-c')", "/bin/sh", true},
+		{"shell and (shell and sf.proc.args = '-i')", "/bin/sh", false},
+		{"(shell or shell) and not (shell and a = b)", "/bin/bash", true},
+		{"sf.proc.args contains c and sf.proc.exe = /bin/sh and sf.proc.pid = 0", "/bin/sh", true},
+		{"not (a = a or shell)", "/bin/ls", false},
+	} {
+		pi := NewPolicyInterpreter(Config{})
+		assert.NoError(t, compilePolicy(t, pi, fmt.Sprintf(rule, c.cond)), c.cond)
+		match, _ := pi.Process(true, false, newProcRecord(c.exe, "-c"))
+		assert.Equal(t, c.match, match, c.cond)
+	}
+}
+
+func TestLint(t *testing.T) {
+	a := testPolicy("lint/lint_a.yaml")
+	b := testPolicy("lint/lint_b.yaml")
+	findings, err := NewPolicyInterpreter(Config{}).Lint(a, b)
+	assert.NoError(t, err)
+	var got []string
+	for _, f := range findings {
+		got = append(got, fmt.Sprintf("%s:%d %s %s", filepath.Base(f.File), f.Line, f.Severity, f.Check))
+	}
+	assert.Equal(t, []string{
+		"lint_a.yaml:3 warning unused-list",
+		"lint_a.yaml:9 warning unused-macro",
+		"lint_a.yaml:13 error undefined-macro",
+		"lint_a.yaml:15 warning unknown-priority",
+		"lint_a.yaml:16 warning constant-condition",
+		"lint_a.yaml:21 warning constant-condition",
+		"lint_a.yaml:32 error undefined-macro",
+		"lint_b.yaml:1 warning shadowed-list",
+		"lint_b.yaml:4 error redefined-list",
+		"lint_b.yaml:6 error duplicate-rule",
+	}, got)
+	assert.Contains(t, findings[len(findings)-1].Message, "lint_a.yaml:11:")
+
+	findings, err = NewPolicyInterpreter(Config{}).Lint(testPolicy("lint/lint_c.yaml"))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(findings))
+	assert.Equal(t, CheckCompile, findings[0].Check)
+	assert.Equal(t, 3, findings[0].Line)
+}
+
+func TestStats(t *testing.T) {
+	a := testPolicy("unit_test_stats.yaml")
+	for _, enabled := range []bool{true, false} {
+		pi := NewPolicyInterpreter(Config{Stats: enabled})
+		assert.NoError(t, pi.Compile(a))
+		for _, exe := range []string{"/bin/sh", "/bin/ls", "/bin/bash", "/usr/bin/vi"} {
+			pi.Process(true, false, newProcRecord(exe, ""))
+		}
+		rules, filters := pi.Stats()
+		assert.Equal(t, 2, len(rules))
+		assert.Equal(t, 1, len(filters))
+		if !enabled {
+			assert.Equal(t, uint64(0), rules[0].Evaluations)
+			assert.Equal(t, uint64(0), filters[0].Evaluations)
+			continue
+		}
+		assert.Equal(t, "stats_ls", filters[0].Name)
+		assert.Equal(t, uint64(4), filters[0].Evaluations)
+		assert.Equal(t, uint64(1), filters[0].Matches)
+		assert.Equal(t, "Stats sh rule", rules[0].Name)
+		assert.Equal(t, uint64(3), rules[0].Evaluations)
+		assert.Equal(t, uint64(1), rules[0].Matches)
+		assert.Equal(t, "Stats exec rule", rules[1].Name)
+		assert.Equal(t, uint64(3), rules[1].Evaluations)
+		assert.Equal(t, uint64(2), rules[1].Matches)
+		assert.True(t, rules[1].Time > 0)
+		pi.LogStats()
+
+		assert.NoError(t, pi.Compile(a))
+		rules, _ = pi.Stats()
+		assert.Equal(t, uint64(0), rules[0].Evaluations)
+	}
+}
+
+// recordActuator records the rules it is run for.
+type recordActuator struct {
+	mutex sync.Mutex
+	rules []string
+}
+
+func (a *recordActuator) Handle(rule Rule, r *Record) error {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	a.rules = append(a.rules, rule.Name)
+	return nil
+}
+
+func TestActions(t *testing.T) {
+	act := &recordActuator{}
+	created := 0
+	RegisterAction("record", func(conf Config) (Actuator, error) {
+		created++
+		return act, nil
+	})
+	RegisterAction("broken", func(conf Config) (Actuator, error) {
+		return nil, errors.New("broken action")
+	})
+	assert.True(t, IsRegisteredAction(Alert))
+	assert.True(t, IsRegisteredAction("record"))
+	assert.False(t, IsRegisteredAction("unknown"))
+
+	a := testPolicy("unit_test_actions.yaml")
+	pi := NewPolicyInterpreter(Config{})
+	assert.NoError(t, pi.Compile(a))
+	assert.NoError(t, pi.Compile(a))
+	assert.Equal(t, 1, created)
+	match, r := pi.Process(true, false, newProcRecord("/bin/sh", ""))
+	assert.True(t, match)
+	assert.Equal(t, 2, len(r.Ctx.GetRules()))
+	pi.Process(true, false, newProcRecord("/bin/ls", ""))
+	assert.Equal(t, []string{"Actions sh rule"}, act.rules)
+
+	assert.Error(t, compilePolicy(t, pi, "- rule: Sh\n  desc: sh\n  condition: sf.proc.exe = /bin/sh\n  action: [broken]\n  priority: low\n"))
+}
+
+func TestHash(t *testing.T) {
+	dir, err := ioutil.TempDir("", "hostroot")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "bin"), 0755))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "bin", "sh"), []byte("abc"), 0755))
+	a := testPolicy("unit_test_hash.yaml")
+	pi := NewPolicyInterpreter(Config{HashConfig: HashConfig{HashHostRoot: dir, HashWorkers: 2, HashCacheSize: 10}})
+	assert.NoError(t, pi.Compile(a))
+
+	newHashRecord := func(rtype int64, opflags int64, path string) *Record {
+		r := newProcRecord("/bin/sh", "")
+		r.Fr.Ints[0][sfgo.SF_REC_TYPE] = rtype
+		r.Fr.Ints[0][sfgo.EV_PROC_OPFLAGS_INT] = opflags
+		r.Fr.Strs[0][sfgo.FILE_PATH_STR] = path
+		return r
+	}
+	_, r := pi.Process(true, false, newHashRecord(sfgo.PROC_EVT, sfgo.OP_EXEC, ""))
+	hs := r.Ctx.GetHashes()
+	assert.Equal(t, "900150983cd24fb0d6963f7d28e17f72", hs.MD5)
+	assert.Equal(t, "a9993e364706816aba3e25717850c26c9cd0d89d", hs.SHA1)
+	assert.Equal(t, "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad", hs.SHA256)
+	assert.Equal(t, 3, hs.Size)
+
+	// records are output once their files are hashed
+	var mutex sync.Mutex
+	var out []*Record
+	for _, r := range []*Record{
+		newHashRecord(sfgo.FILE_EVT, sfgo.OP_OPEN, "/bin/sh"),
+		newHashRecord(sfgo.PROC_EVT, sfgo.OP_CLONE, ""),
+		newHashRecord(sfgo.FILE_FLOW, sfgo.OP_OPEN, "/missing"),
+	} {
+		pi.ProcessAsync(true, false, r, func(r *Record) {
+			mutex.Lock()
+			defer mutex.Unlock()
+			out = append(out, r)
+		})
+	}
+	WaitForActions()
+	assert.Equal(t, 3, len(out))
+	hashed := 0
+	for _, r := range out {
+		if r.Ctx.GetHashes().SHA256 == hs.SHA256 {
+			hashed++
+		}
+	}
+	assert.Equal(t, 1, hashed)
+
+	// files of containerized processes are resolved through the process root
+	f := filepath.Join(dir, "file")
+	assert.NoError(t, ioutil.WriteFile(f, []byte("abc"), 0644))
+	r = newHashRecord(sfgo.FILE_EVT, sfgo.OP_OPEN, f)
+	r.Fr.Strs[0][sfgo.CONT_ID_STR] = "container"
+	r.Fr.Ints[0][sfgo.PROC_OID_HPID_INT] = int64(os.Getpid())
+	cpi := NewPolicyInterpreter(Config{})
+	assert.NoError(t, cpi.Compile(a))
+	cpi.Process(true, false, r)
+	assert.Equal(t, hs.SHA256, r.Ctx.GetHashes().SHA256)
+
+	// modified files are hashed again
+	time.Sleep(10 * time.Millisecond)
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "bin", "sh"), []byte("abcd"), 0755))
+	_, r = pi.Process(true, false, newHashRecord(sfgo.PROC_EVT, sfgo.OP_EXEC, ""))
+	assert.Equal(t, "e2fc714c4727ee9395f324cd2e7f331f", r.Ctx.GetHashes().MD5)
+}
+
+func TestTags(t *testing.T) {
+	pi := NewPolicyInterpreter(Config{})
+	assert.NoError(t, pi.Compile(testPolicy("unit_test_tags.yaml")))
+
+	// tags are rendered, deduplicated across rules, and only added by tag actions
+	_, r := pi.Process(true, false, newProcRecord("/bin/sh", ""))
+	rules := r.Ctx.GetRules()
+	assert.Equal(t, 3, len(rules))
+	for _, rule := range rules {
+		if rule.Name == "Tagged exe rule" {
+			assert.Equal(t, []EnrichmentTag{[]string{"static", "exe:%sf.proc.exe", "%sf.proc.args"}}, rule.Tags)
+		}
+	}
+	assert.ElementsMatch(t, []string{"static", "exe:/bin/sh", "team:-x"}, r.Ctx.GetTags())
+}
+
+// delayActuator completes asynchronously, after a delay for records of /bin/sh.
+type delayActuator struct{}
+
+func (delayActuator) Handle(rule Rule, r *Record) error {
+	return nil
+}
+
+func (delayActuator) HandleAsync(rule Rule, r *Record, done func()) {
+	if r.GetStr(sfgo.PROC_EXE_STR, sfgo.SYSFLOW_SRC) == "/bin/sh" {
+		time.AfterFunc(50*time.Millisecond, done)
+		return
+	}
+	done()
+}
+
+func TestProcessOrdered(t *testing.T) {
+	RegisterAction("delay", func(conf Config) (Actuator, error) {
+		return delayActuator{}, nil
+	})
+	pi := NewPolicyInterpreter(Config{})
+	assert.NoError(t, pi.Compile(testPolicy("unit_test_ordered.yaml")))
+
+	var mutex sync.Mutex
+	var out []string
+	o := NewOrderedOutput(10, func(r *Record) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		out = append(out, r.GetStr(sfgo.PROC_EXE_STR, sfgo.SYSFLOW_SRC))
+	})
+	for _, exe := range []string{"/bin/sh", "/bin/cat", "/bin/ls"} {
+		pi.ProcessOrdered(true, false, newProcRecord(exe, ""), o)
+	}
+	o.Close()
+	assert.Equal(t, []string{"/bin/sh", "/bin/ls"}, out)
+}
+
+func TestNonSudoSetuid(t *testing.T) {
+	pi := NewPolicyInterpreter(Config{})
+	assert.NoError(t, pi.Compile("../../../resources/policies/ttps/ttps.yaml"))
+	for _, c := range []struct {
+		exe   string
+		user  string
+		match bool
+	}{
+		{"/usr/bin/evil", "alice", true},
+		{"/usr/bin/evil", "root", false},
+		{"/usr/sbin/nrpe", "nagios", false},
+	} {
+		r := newProcRecord(c.exe, "")
+		r.Cr = cache.GetInstance()
+		r.Fr.Ints[0][sfgo.SF_REC_TYPE] = sfgo.PROC_EVT
+		r.Fr.Ints[0][sfgo.EV_PROC_OPFLAGS_INT] = sfgo.OP_SETUID
+		r.Fr.Ints[0][sfgo.PROC_OID_HPID_INT] = 42
+		r.Fr.Strs[0][sfgo.PROC_USERNAME_STR] = c.user
+		pi.Process(true, false, r)
+		var rules []string
+		for _, rule := range r.Ctx.GetRules() {
+			rules = append(rules, rule.Name)
+		}
+		if c.match {
+			assert.Contains(t, rules, "Non sudo setuid", c.exe+" "+c.user)
+		} else {
+			assert.NotContains(t, rules, "Non sudo setuid", c.exe+" "+c.user)
+		}
+	}
+}
+
+func TestSystemProcsNetworkActivity(t *testing.T) {
+	pi := NewPolicyInterpreter(Config{})
+	assert.NoError(t, pi.Compile("../../../resources/policies/runtimeintegrity/runtimeintegrity.yaml"))
+	for _, c := range []struct {
+		exe   string
+		ip    int64
+		match bool
+	}{
+		{"/bin/bash", 10 | 1<<24, true},
+		{"/bin/bash", 127 | 1<<24, false},
+		{"/usr/bin/curl", 10 | 1<<24, false},
+	} {
+		r := newProcRecord(c.exe, "")
+		r.Fr.Ints[0][sfgo.SF_REC_TYPE] = sfgo.NET_FLOW
+		r.Fr.Ints[0][sfgo.PROC_OID_HPID_INT] = 42
+		r.Fr.Ints[0][sfgo.FL_NETW_SIP_INT] = c.ip
+		r.Fr.Ints[0][sfgo.FL_NETW_DIP_INT] = c.ip
+		pi.Process(true, false, r)
+		var rules []string
+		for _, rule := range r.Ctx.GetRules() {
+			rules = append(rules, rule.Name)
+		}
+		if c.match {
+			assert.Contains(t, rules, "System procs network activity", c.exe)
+		} else {
+			assert.NotContains(t, rules, "System procs network activity", c.exe)
+		}
+	}
+}
+
+// TestRuntimeIntegrityTTPs checks that the TTP rules shipped with the runtime
+// integrity policies are copies of the standalone TTP policies, and that the
+// TTP definitions they leave out are the ones redefined by the runtime
+// integrity policies.
+func TestRuntimeIntegrityTTPs(t *testing.T) {
+	dir := "../../../resources/policies/runtimeintegrity"
+	ttps := policyItems(t, "../../../resources/policies/ttps/ttps.yaml")
+	subset := policyItems(t, filepath.Join(dir, "ttps.yaml"))
+	ri := policyItems(t, filepath.Join(dir, "runtimeintegrity.yaml"))
+	for key, item := range subset {
+		assert.Equal(t, ttps[key], item, key)
+	}
+	for key := range ttps {
+		_, inSubset := subset[key]
+		_, inRI := ri[key]
+		assert.True(t, inSubset != inRI, key)
+	}
+	paths, err := ioutils.ListFilePaths(dir, ".yaml")
+	assert.NoError(t, err)
+	assert.NoError(t, NewPolicyInterpreter(Config{}).Compile(paths...))
+}
+
+// policyItems returns the definitions of a policy file, keyed by their first line.
+// Comments and trailing spaces are left out, since the policy files aren't all
+// well-formed YAML.
+func policyItems(t *testing.T, path string) map[string]string {
+	b, err := ioutil.ReadFile(path)
+	assert.NoError(t, err)
+	m := make(map[string]string)
+	var key string
+	for _, line := range strings.Split(string(b), "\n") {
+		line = strings.TrimRight(line, " \t\r")
+		switch {
+		case strings.HasPrefix(line, "- "):
+			key = line
+		case key == "" || line == "" || strings.HasPrefix(line, "#"):
+			continue
+		}
+		m[key] += line + "\n"
+	}
+	return m
+}
+
+func TestEntrypoint(t *testing.T) {
+	pi := NewPolicyInterpreter(Config{})
+	assert.NoError(t, pi.Compile("../../../resources/policies/ttps/ttps.yaml"))
+	tables := cache.GetInstance()
+	for i, c := range []struct {
+		name  string
+		ppid  int64
+		match bool
+	}{
+		{"parent missing", -1, false},
+		{"parent pid zero", 0, true},
+		{"parent present", 1, true},
+	} {
+		oid := sfgo.OID{CreateTS: 2000 + int64(i), Hpid: 42}
+		p := &sfgo.Process{Oid: &oid, Exe: "/bin/bash"}
+		r := newProcRecord("/bin/bash", "")
+		r.Cr = tables
+		if c.ppid >= 0 {
+			poid := sfgo.OID{CreateTS: 1000 + int64(i), Hpid: c.ppid}
+			p.Poid = &sfgo.UnionNullOID{OID: &poid, UnionType: sfgo.UnionNullOIDTypeEnumOID}
+			tables.SetProc(poid, &sfgo.Process{Oid: &poid, Exe: "/usr/bin/node"})
+			r.Fr.Ints[0][sfgo.PROC_POID_HPID_INT] = poid.Hpid
+			r.Fr.Ints[0][sfgo.PROC_POID_CREATETS_INT] = poid.CreateTS
+		}
+		tables.SetProc(oid, p)
+		r.Fr.Ints[0][sfgo.SF_REC_TYPE] = sfgo.PROC_EVT
+		r.Fr.Ints[0][sfgo.EV_PROC_OPFLAGS_INT] = sfgo.OP_EXEC
+		r.Fr.Ints[0][sfgo.PROC_OID_HPID_INT] = oid.Hpid
+		r.Fr.Ints[0][sfgo.PROC_OID_CREATETS_INT] = oid.CreateTS
+		pi.Process(true, false, r)
+		var rules []string
+		for _, rule := range r.Ctx.GetRules() {
+			rules = append(rules, rule.Name)
+		}
+		if c.match {
+			assert.Contains(t, rules, "Suspicious process spawned", c.name)
+		} else {
+			assert.NotContains(t, rules, "Suspicious process spawned", c.name)
+		}
+	}
+}
@@ -0,0 +1,322 @@
+//
+// Copyright (C) 2020 IBM Corporation.
+//
+// Authors:
+// Frederico Araujo <frederico.araujo@ibm.com>
+// Teryl Taylor <terylt@ibm.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+package engine
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/antlr/antlr4/runtime/Go/antlr"
+	"github.com/sysflow-telemetry/sf-processor/core/policyengine/lang/errorhandler"
+	"github.com/sysflow-telemetry/sf-processor/core/policyengine/lang/parser"
+)
+
+// Severities of lint findings.
+const (
+	LintError   = "error"
+	LintWarning = "warning"
+)
+
+// Lint checks.
+const (
+	CheckCompile           = "compile"
+	CheckUnusedMacro       = "unused-macro"
+	CheckUnusedList        = "unused-list"
+	CheckUndefinedMacro    = "undefined-macro"
+	CheckRedefinedList     = "redefined-list"
+	CheckShadowedList      = "shadowed-list"
+	CheckConstantCondition = "constant-condition"
+	CheckDuplicateRule     = "duplicate-rule"
+	CheckUnknownPriority   = "unknown-priority"
+)
+
+// Finding is an issue found by the policy linter.
+type Finding struct {
+	File     string `json:"file"`
+	Line     int    `json:"line"`
+	Column   int    `json:"column"`
+	Severity string `json:"severity"`
+	Check    string `json:"check"`
+	Message  string `json:"message"`
+}
+
+// lintDef is a definition of a list, macro, rule, or filter in a policy file.
+type lintDef struct {
+	kind     string
+	name     string
+	token    antlr.Token
+	appended bool
+	cond     parser.IExpressionContext
+}
+
+// lintListener collects the definitions, and the macro and list references, of policy files.
+// References are keyed by the definition they appear in.
+type lintListener struct {
+	*parser.BaseSfplListener
+	compiler   *sfplListener
+	owner      string
+	defs       []lintDef
+	macroRefs  map[string][]antlr.Token
+	listRefs   map[string][]string
+	priorities []antlr.Token
+}
+
+// ownerKey returns the key of the references of definition name of kind.
+func ownerKey(kind string, name string) string {
+	return kind + " " + name
+}
+
+// EnterPlist is called when production plist is entered.
+func (l *lintListener) EnterPlist(ctx *parser.PlistContext) {
+	name := ctx.Identifier().GetText()
+	l.owner = ownerKey("list", name)
+	l.defs = append(l.defs, lintDef{kind: "list", name: name, token: ctx.Identifier().GetStart(), appended: l.compiler.getAppendFlag(ctx.AllFappend())})
+}
+
+// EnterPmacro is called when production pmacro is entered.
+func (l *lintListener) EnterPmacro(ctx *parser.PmacroContext) {
+	name := ctx.Identifier().GetText()
+	l.owner = ownerKey("macro", name)
+	l.defs = append(l.defs, lintDef{kind: "macro", name: name, token: ctx.Identifier().GetStart(), appended: l.compiler.getAppendFlag(ctx.AllFappend())})
+}
+
+// EnterPfilter is called when production pfilter is entered.
+func (l *lintListener) EnterPfilter(ctx *parser.PfilterContext) {
+	name := ctx.ID().GetText()
+	l.owner = ownerKey("filter", name)
+	l.defs = append(l.defs, lintDef{kind: "filter", name: name, token: ctx.ID().GetSymbol(), cond: ctx.Expression()})
+}
+
+// EnterPrule is called when production prule is entered.
+func (l *lintListener) EnterPrule(ctx *parser.PruleContext) {
+	name := l.compiler.getOffChannelText(ctx.Text(0))
+	l.owner = ownerKey("rule", name)
+	l.defs = append(l.defs, lintDef{kind: "rule", name: name, token: ctx.Text(0).GetStart(), appended: l.compiler.getAppendFlag(ctx.AllFappend())})
+	for _, s := range ctx.AllSeverity() {
+		if _, ok := parsePriority(s.GetText()); !ok {
+			l.priorities = append(l.priorities, s.GetStart())
+		}
+	}
+}
+
+// EnterVariable is called when production variable is entered.
+func (l *lintListener) EnterVariable(ctx *parser.VariableContext) {
+	l.macroRefs[l.owner] = append(l.macroRefs[l.owner], ctx.GetStart())
+}
+
+// EnterAtom is called when production atom is entered. Atoms naming lists are list references.
+func (l *lintListener) EnterAtom(ctx *parser.AtomContext) {
+	if ctx.ID() != nil {
+		l.listRefs[l.owner] = append(l.listRefs[l.owner], ctx.GetText())
+	}
+}
+
+// Lint compiles the policies defined in paths, and reports the issues found in them, including compilation errors.
+// Policies are not loaded into the interpreter.
+func (pi *PolicyInterpreter) Lint(paths ...string) ([]Finding, error) {
+	listener := newSfplListener(pi.version)
+	pfs := make([]*policyFile, 0, len(paths))
+	for _, path := range paths {
+		pf, err := pi.parse(listener, path)
+		if err != nil {
+			return nil, err
+		}
+		pfs = append(pfs, pf)
+	}
+	l := &lintListener{compiler: listener, macroRefs: make(map[string][]antlr.Token), listRefs: make(map[string][]string)}
+	for _, pf := range pfs {
+		antlr.ParseTreeWalkerDefault.Walk(l, pi.walk(listener, pf))
+	}
+	// errors reported while linting were already reported during compilation
+	listener.errors = &errorhandler.SfplErrorListener{}
+
+	findings := l.checkDefinitions()
+	findings = append(findings, l.checkReferences()...)
+	findings = append(findings, l.checkConditions()...)
+	for _, t := range l.priorities {
+		findings = append(findings, newFinding(t, LintWarning, CheckUnknownPriority,
+			fmt.Sprintf("priority %s does not map to a known level, and defaults to %s", t.GetText(), Low.String())))
+	}
+
+	// compilation errors at the position of other findings report the same issue
+	reported := make(map[string]bool)
+	for _, f := range findings {
+		reported[fmt.Sprintf("%s:%d:%d", f.File, f.Line, f.Column)] = true
+	}
+	for _, pf := range pfs {
+		for _, errs := range [][]error{pf.lexerErrors.Errors, pf.parserErrors.Errors, pf.compilerErrors.Errors} {
+			for _, e := range errs {
+				f := Finding{File: pf.path, Severity: LintError, Check: CheckCompile, Message: e.Error()}
+				if se, ok := e.(*errorhandler.SfplSyntaxError); ok {
+					f.Line, f.Column, f.Message = se.Line(), se.Column(), se.Msg()
+				}
+				if !reported[fmt.Sprintf("%s:%d:%d", f.File, f.Line, f.Column)] {
+					findings = append(findings, f)
+				}
+			}
+		}
+	}
+	sort.SliceStable(findings, func(i, j int) bool {
+		a, b := findings[i], findings[j]
+		if a.File != b.File {
+			return a.File < b.File
+		}
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.Column < b.Column
+	})
+	return findings, nil
+}
+
+// checkDefinitions reports duplicate rules, and lists that are redefined, or appended to in other policy files.
+func (l *lintListener) checkDefinitions() []Finding {
+	findings := make([]Finding, 0)
+	first := make(map[string]lintDef)
+	for _, d := range l.defs {
+		key := ownerKey(d.kind, d.name)
+		f, defined := first[key]
+		if !defined {
+			first[key] = d
+			continue
+		}
+		switch {
+		case d.kind == "rule" && !d.appended:
+			findings = append(findings, newFinding(d.token, LintError, CheckDuplicateRule,
+				fmt.Sprintf("rule %s is already defined at %s", d.name, position(f.token))))
+		case d.kind == "list" && !d.appended:
+			findings = append(findings, newFinding(d.token, LintError, CheckRedefinedList,
+				fmt.Sprintf("list %s is already defined at %s", d.name, position(f.token))))
+		case d.kind == "list" && sourceName(d.token) != sourceName(f.token):
+			findings = append(findings, newFinding(d.token, LintWarning, CheckShadowedList,
+				fmt.Sprintf("list %s defined at %s is appended to in another policy file, which changes it for all policies", d.name, position(f.token))))
+		}
+	}
+	return findings
+}
+
+// checkReferences reports references to undefined macros, and macros and lists that are not used,
+// directly or indirectly, by any rule or filter.
+func (l *lintListener) checkReferences() []Finding {
+	findings := make([]Finding, 0)
+	used := make(map[string]bool)
+	var visit func(owner string)
+	visit = func(owner string) {
+		for _, t := range l.macroRefs[owner] {
+			key := ownerKey("macro", t.GetText())
+			if _, ok := l.compiler.macroCtxs[t.GetText()]; ok && !used[key] {
+				used[key] = true
+				visit(key)
+			}
+		}
+		for _, name := range l.listRefs[owner] {
+			key := ownerKey("list", name)
+			if _, ok := l.compiler.lists[name]; ok && !used[key] {
+				used[key] = true
+				visit(key)
+			}
+		}
+	}
+	reported := make(map[string]bool)
+	for _, d := range l.defs {
+		key := ownerKey(d.kind, d.name)
+		if d.kind == "rule" || d.kind == "filter" {
+			visit(key)
+		}
+		if reported[key] {
+			continue
+		}
+		reported[key] = true
+		for _, t := range l.macroRefs[key] {
+			name := t.GetText()
+			if _, ok := l.compiler.macroCtxs[name]; ok {
+				continue
+			}
+			msg := fmt.Sprintf("undefined macro %s", name)
+			if _, ok := l.compiler.lists[name]; ok {
+				msg = fmt.Sprintf("list %s is referenced as a macro", name)
+			}
+			findings = append(findings, newFinding(t, LintError, CheckUndefinedMacro, msg))
+		}
+	}
+	defined := make(map[string]bool)
+	for _, d := range l.defs {
+		key := ownerKey(d.kind, d.name)
+		if defined[key] || used[key] {
+			continue
+		}
+		defined[key] = true
+		switch d.kind {
+		case "macro":
+			findings = append(findings, newFinding(d.token, LintWarning, CheckUnusedMacro, fmt.Sprintf("macro %s is not used by any rule or filter", d.name)))
+		case "list":
+			findings = append(findings, newFinding(d.token, LintWarning, CheckUnusedList, fmt.Sprintf("list %s is not used by any rule or filter", d.name)))
+		}
+	}
+	return findings
+}
+
+// checkConditions reports compiled rules and filters whose conditions are always true or always false
+// after constant folding. Rules with aggregates or exceptions are not always true.
+func (l *lintListener) checkConditions() []Finding {
+	findings := make([]Finding, 0)
+	tokens := make(map[string]antlr.Token)
+	for _, d := range l.defs {
+		key := ownerKey(d.kind, d.name)
+		if _, ok := tokens[key]; !ok {
+			tokens[key] = d.token
+		}
+		if d.kind == "filter" {
+			if e := l.compiler.visitExpr(d.cond).optimize(); e.op == opConst {
+				findings = append(findings, newFinding(d.token, LintWarning, CheckConstantCondition,
+					fmt.Sprintf("condition of filter %s is always %t", d.name, e.value)))
+			}
+		}
+	}
+	for _, r := range l.compiler.rules {
+		ctx, ok := l.compiler.ruleCtxs[r.Name]
+		if !ok || l.compiler.sequences[r.Name] {
+			continue
+		}
+		e, aggs := l.compiler.conditionExpr(ctx)
+		e = e.optimize()
+		if e.op != opConst || (e.value && (len(aggs) > 0 || len(l.compiler.exceptions[r.Name]) > 0)) {
+			continue
+		}
+		findings = append(findings, newFinding(tokens[ownerKey("rule", r.Name)], LintWarning, CheckConstantCondition,
+			fmt.Sprintf("condition of rule %s is always %t", r.Name, e.value)))
+	}
+	return findings
+}
+
+// newFinding creates a finding at token.
+func newFinding(token antlr.Token, severity string, check string, msg string) Finding {
+	return Finding{File: sourceName(token), Line: token.GetLine(), Column: token.GetColumn(), Severity: severity, Check: check, Message: msg}
+}
+
+// sourceName returns the name of the policy file of token.
+func sourceName(token antlr.Token) string {
+	return token.GetInputStream().GetSourceName()
+}
+
+// position returns the position of token, as file:line:column.
+func position(token antlr.Token) string {
+	return fmt.Sprintf("%s:%d:%d", sourceName(token), token.GetLine(), token.GetColumn())
+}
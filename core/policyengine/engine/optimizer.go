@@ -0,0 +1,269 @@
+//
+// Copyright (C) 2020 IBM Corporation.
+//
+// Authors:
+// Frederico Araujo <frederico.araujo@ibm.com>
+// Teryl Taylor <terylt@ibm.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+package engine
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/antlr/antlr4/runtime/Go/antlr"
+	"github.com/sysflow-telemetry/sf-processor/core/policyengine/lang/parser"
+)
+
+// exprOp is the operator of an expression node.
+type exprOp uint8
+
+// Expression operators.
+const (
+	opConst exprOp = iota
+	opPred
+	opNot
+	opAnd
+	opOr
+)
+
+// expr is a node of the expression tree of a condition, which is optimized before being compiled into a criterion.
+// The leaves of the tree are constants and predicates. Predicates must not have side effects, since optimizations
+// remove and reorder them; stateful criteria, such as aggregates, are kept out of expression trees.
+type expr struct {
+	op    exprOp
+	value bool
+	pred  Criterion
+	key   string
+	cost  int
+	args  []*expr
+}
+
+// Evaluation costs of attributes and operators, used to order the terms of conjunctions and disjunctions.
+const (
+	costLiteral = 0
+	costInt     = 1
+	costStr     = 2
+	costSpecial = 4
+	costList    = 8
+	costEq      = 1
+	costSubstr  = 2
+	costRegex   = 4
+)
+
+// constExpr creates a constant expression.
+func constExpr(value bool) *expr {
+	if value {
+		return &expr{op: opConst, value: true, key: "t"}
+	}
+	return &expr{op: opConst, value: false, key: "f"}
+}
+
+// predExpr creates a predicate expression. Predicates with the same text are equivalent.
+func predExpr(pred Criterion, text string, cost int) *expr {
+	return &expr{op: opPred, pred: pred, key: fmt.Sprintf("p%d:%s", len(text), text), cost: cost}
+}
+
+// notExpr creates the negation of expression e.
+func notExpr(e *expr) *expr {
+	return &expr{op: opNot, key: "!" + e.key, cost: e.cost, args: []*expr{e}}
+}
+
+// andExpr creates the conjunction of expressions args.
+func andExpr(args ...*expr) *expr {
+	return naryExpr(opAnd, args)
+}
+
+// orExpr creates the disjunction of expressions args.
+func orExpr(args ...*expr) *expr {
+	return naryExpr(opOr, args)
+}
+
+// naryExpr creates a conjunction or disjunction of expressions args.
+func naryExpr(op exprOp, args []*expr) *expr {
+	var key strings.Builder
+	if op == opAnd {
+		key.WriteString("&")
+	} else {
+		key.WriteString("|")
+	}
+	key.WriteString(fmt.Sprintf("%d:", len(args)))
+	cost := 0
+	for _, a := range args {
+		key.WriteString(a.key)
+		cost += a.cost
+	}
+	return &expr{op: op, key: key.String(), cost: cost, args: args}
+}
+
+// optimize returns an optimized expression equivalent to e. Constants are folded, nested conjunctions and
+// disjunctions are flattened, duplicate terms (e.g., from macros expanded more than once) are removed, and
+// terms are ordered by increasing evaluation cost.
+func (e *expr) optimize() *expr {
+	switch e.op {
+	case opNot:
+		a := e.args[0].optimize()
+		switch a.op {
+		case opConst:
+			return constExpr(!a.value)
+		case opNot:
+			return a.args[0]
+		}
+		return notExpr(a)
+	case opAnd, opOr:
+		// a conjunction is absorbed by false terms, and a disjunction by true terms
+		absorbing := e.op == opOr
+		var args []*expr
+		seen := make(map[string]bool)
+		for _, a := range e.args {
+			a = a.optimize()
+			terms := []*expr{a}
+			if a.op == e.op {
+				terms = a.args
+			} else if a.op == opConst {
+				if a.value == absorbing {
+					return a
+				}
+				continue
+			}
+			for _, t := range terms {
+				if !seen[t.key] {
+					seen[t.key] = true
+					args = append(args, t)
+				}
+			}
+		}
+		switch len(args) {
+		case 0:
+			return constExpr(!absorbing)
+		case 1:
+			return args[0]
+		}
+		sort.SliceStable(args, func(i, j int) bool { return args[i].cost < args[j].cost })
+		return naryExpr(e.op, args)
+	}
+	return e
+}
+
+// compile compiles expression e into a criterion.
+func (e *expr) compile() Criterion {
+	switch e.op {
+	case opConst:
+		if e.value {
+			return True
+		}
+		return False
+	case opNot:
+		return e.args[0].compile().Not()
+	case opAnd, opOr:
+		criteria := make([]Criterion, len(e.args))
+		for i, a := range e.args {
+			criteria[i] = a.compile()
+		}
+		if e.op == opAnd {
+			return All(criteria)
+		}
+		return Any(criteria)
+	}
+	return e.pred
+}
+
+// isLiteral indicates whether operand attr of a term is a literal rather than an attribute.
+func isLiteral(attr string) bool {
+	_, ok := Mapper.Mappers[attr]
+	return !ok
+}
+
+// attrCost returns the cost of retrieving the value of operand attr.
+func attrCost(attr string) int {
+	mapper, ok := Mapper.Mappers[attr]
+	if !ok {
+		return costLiteral
+	}
+	switch mapper.Type {
+	case MapIntVal, MapBoolVal:
+		return costInt
+	case MapStrVal:
+		return costStr
+	case MapArrayStr, MapArrayInt:
+		return costList
+	}
+	return costSpecial
+}
+
+// termCost returns the estimated evaluation cost of term ctx, which is not a macro reference, a negation,
+// or a parenthesized expression.
+func termCost(ctx *parser.TermContext) int {
+	if ctx.Arith_expression(0) != nil {
+		return arithCost(ctx)
+	}
+	if ctx.Atom(0) == nil {
+		return costLiteral
+	}
+	lop := ctx.Atom(0).GetText()
+	cost := attrCost(lop)
+	if opCtx, ok := ctx.Binary_operator().(*parser.Binary_operatorContext); ok {
+		rop := ctx.Atom(1).GetText()
+		cost += attrCost(rop)
+		switch {
+		case opCtx.EQ() != nil || opCtx.NEQ() != nil:
+			if t, _ := comparisonType(lop, rop); t == StrValue {
+				cost += costEq
+			}
+		case opCtx.MATCHES() != nil || opCtx.IMATCHES() != nil:
+			cost += costRegex
+		case opCtx.CONTAINS() != nil || opCtx.ICONTAINS() != nil || opCtx.STARTSWITH() != nil || opCtx.ISTARTSWITH() != nil ||
+			opCtx.ENDSWITH() != nil || opCtx.IENDSWITH() != nil || opCtx.IEQ() != nil:
+			cost += costSubstr
+		}
+	} else if ctx.IN() != nil {
+		if valueTypeOf(lop) == StrValue {
+			cost += costEq
+		}
+	} else if ctx.IIN() != nil || ctx.PMATCH() != nil {
+		cost += costSubstr
+	} else if ctx.INCIDR() != nil {
+		cost += costRegex
+	}
+	return cost
+}
+
+// arithCost returns the estimated evaluation cost of the operands and function calls of arithmetic expressions in ctx.
+func arithCost(ctx antlr.Tree) int {
+	cost := 0
+	switch t := ctx.(type) {
+	case *parser.AtomContext:
+		return attrCost(t.GetText())
+	case *parser.FunctionContext:
+		cost += costSubstr
+	}
+	for _, c := range ctx.GetChildren() {
+		cost += arithCost(c)
+	}
+	return cost
+}
+
+// isConstantTerm indicates whether term ctx, which is not a macro reference, a negation, or a parenthesized
+// expression, only has literal operands. The predicates of such terms don't depend on records.
+func isConstantTerm(ctx *parser.TermContext) bool {
+	if ctx.Aggregate() != nil || ctx.Atom(0) == nil || !isLiteral(ctx.Atom(0).GetText()) {
+		return false
+	}
+	if ctx.Binary_operator() != nil {
+		return isLiteral(ctx.Atom(1).GetText())
+	}
+	return true
+}
@@ -0,0 +1,61 @@
+//
+// Copyright (C) 2020 IBM Corporation.
+//
+// Authors:
+// Frederico Araujo <frederico.araujo@ibm.com>
+// Teryl Taylor <terylt@ibm.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+package engine
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOptimize(t *testing.T) {
+	pred := func(text string, cost int) *expr { return predExpr(True, text, cost) }
+	a, b, c := pred("a", 1), pred("b", 1), pred("c", 4)
+	for _, tc := range []struct {
+		name     string
+		in       *expr
+		expected *expr
+	}{
+		{"flattens conjunctions", andExpr(a, andExpr(b, c)), andExpr(a, b, c)},
+		{"flattens disjunctions", orExpr(orExpr(a, b), c), orExpr(a, b, c)},
+		{"keeps mixed operators", andExpr(a, orExpr(b, c)), andExpr(a, orExpr(b, c))},
+		{"removes duplicates", andExpr(a, b, pred("a", 1)), andExpr(a, b)},
+		{"removes flattened duplicates", orExpr(a, orExpr(pred("a", 1), b)), orExpr(a, b)},
+		{"removes duplicate subexpressions", andExpr(orExpr(a, b), orExpr(a, b)), orExpr(a, b)},
+		{"orders by cost", andExpr(c, a, b), andExpr(a, b, c)},
+		{"orders subexpressions by cost", orExpr(andExpr(c, a), b), orExpr(b, andExpr(a, c))},
+		{"folds double negations", notExpr(notExpr(a)), a},
+		{"folds negated constants", notExpr(constExpr(true)), constExpr(false)},
+		{"keeps negations", notExpr(andExpr(c, a)), notExpr(andExpr(a, c))},
+		{"removes neutral constants", andExpr(constExpr(true), a, b), andExpr(a, b)},
+		{"absorbs conjunctions", andExpr(a, constExpr(false)), constExpr(false)},
+		{"absorbs disjunctions", orExpr(a, notExpr(constExpr(false))), constExpr(true)},
+		{"folds empty conjunctions", andExpr(constExpr(true)), constExpr(true)},
+		{"unwraps single terms", orExpr(constExpr(false), a), a},
+	} {
+		assert.Equal(t, tc.expected.key, tc.in.optimize().key, tc.name)
+	}
+}
+
+func TestOptimizeDoubleNegation(t *testing.T) {
+	a := predExpr(True, "a", 1)
+	assert.Same(t, a, notExpr(notExpr(a)).optimize())
+	assert.Same(t, a, andExpr(notExpr(notExpr(a))).optimize())
+}
@@ -0,0 +1,73 @@
+//
+// Copyright (C) 2020 IBM Corporation.
+//
+// Authors:
+// Frederico Araujo <frederico.araujo@ibm.com>
+// Teryl Taylor <terylt@ibm.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+package engine
+
+// OrderedOutput outputs the records evaluated with ProcessOrdered in the order in which they were evaluated,
+// even if their asynchronous actions complete out of order. A record waiting for its actions holds back
+// the records evaluated after it, and evaluation blocks while the configured number of records is pending.
+type OrderedOutput struct {
+	inflight chan *pendingOutput
+	closed   chan struct{}
+}
+
+// pendingOutput is an evaluated record, whose outputs are sent once done is closed.
+type pendingOutput struct {
+	recs []*Record
+	done chan struct{}
+}
+
+// NewOrderedOutput creates an ordered output sending records to out, with up to capacity pending records.
+func NewOrderedOutput(capacity int, out func(r *Record)) *OrderedOutput {
+	o := &OrderedOutput{inflight: make(chan *pendingOutput, capacity), closed: make(chan struct{})}
+	go func() {
+		for p := range o.inflight {
+			<-p.done
+			for _, r := range p.recs {
+				out(r)
+			}
+		}
+		close(o.closed)
+	}()
+	return o
+}
+
+// add queues a new pending record.
+func (o *OrderedOutput) add() *pendingOutput {
+	p := &pendingOutput{done: make(chan struct{})}
+	o.inflight <- p
+	return p
+}
+
+// Close waits until all pending records are output, and stops the ordered output.
+// Records must not be evaluated with o after it is closed.
+func (o *OrderedOutput) Close() {
+	close(o.inflight)
+	<-o.closed
+}
+
+// out records the output of r, which is sent once the pending record completes.
+func (p *pendingOutput) out(r *Record) {
+	p.recs = append(p.recs, r)
+}
+
+// complete marks the pending record as completed.
+func (p *pendingOutput) complete() {
+	close(p.done)
+}
@@ -0,0 +1,81 @@
+//
+// Copyright (C) 2020 IBM Corporation.
+//
+// Authors:
+// Frederico Araujo <frederico.araujo@ibm.com>
+// Teryl Taylor <terylt@ibm.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+package engine
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Regular expression for matching field placeholders in rule output templates (e.g., %sf.proc.exe).
+var placeholderre = regexp.MustCompile(`%[a-zA-Z_][a-zA-Z0-9_.]*(\[[^\]]*\])?`)
+
+// outputTemplate defines a compiled rule output template.
+type outputTemplate []func(r *Record) string
+
+// compileOutput compiles a Falco-style output template, resolving %field placeholders through the field mapper.
+// Placeholders that do not reference a known field are rendered verbatim.
+func compileOutput(tmpl string) outputTemplate {
+	var t outputTemplate
+	pos := 0
+	for _, loc := range placeholderre.FindAllStringIndex(tmpl, -1) {
+		attr := strings.TrimRight(tmpl[loc[0]+1:loc[1]], ".")
+		if _, ok := Mapper.Mappers[attr]; !ok {
+			continue
+		}
+		t = t.appendText(tmpl[pos:loc[0]])
+		t = append(t, Mapper.MapStr(attr))
+		pos = loc[0] + 1 + len(attr)
+	}
+	return t.appendText(tmpl[pos:])
+}
+
+// appendText appends a literal text segment to the template.
+func (t outputTemplate) appendText(s string) outputTemplate {
+	if len(s) == 0 {
+		return t
+	}
+	return append(t, func(r *Record) string { return s })
+}
+
+// render renders the output template for record r.
+func (t outputTemplate) render(r *Record) string {
+	var sb strings.Builder
+	for _, f := range t {
+		sb.WriteString(f(r))
+	}
+	return sb.String()
+}
+
+// compileTags compiles rule tags into templates, so that tag actions can resolve their %field placeholders.
+func compileTags(tags []EnrichmentTag) []outputTemplate {
+	var ts []outputTemplate
+	for _, tag := range tags {
+		switch tag := tag.(type) {
+		case []string:
+			for _, t := range tag {
+				ts = append(ts, compileOutput(t))
+			}
+		case string:
+			ts = append(ts, compileOutput(tag))
+		}
+	}
+	return ts
+}
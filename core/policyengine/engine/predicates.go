@@ -21,7 +21,9 @@ package engine
 
 import (
 	"fmt"
-	"reflect"
+	"net"
+	"regexp"
+	"strconv"
 	"strings"
 )
 
@@ -63,67 +65,126 @@ func (c Criterion) Not() Criterion {
 }
 
 // All derives the conjuctive clause of all predicates in a slice of predicates.
+// Predicates are evaluated in order, until one of them doesn't hold.
 func All(criteria []Criterion) Criterion {
-	all := True
-	for _, c := range criteria {
-		all = all.And(c)
+	switch len(criteria) {
+	case 0:
+		return True
+	case 1:
+		return criteria[0]
 	}
-	return all
+	cs := append([]Criterion(nil), criteria...)
+	p := func(r *Record) bool {
+		for _, c := range cs {
+			if !c.Eval(r) {
+				return false
+			}
+		}
+		return true
+	}
+	return Criterion{p}
 }
 
 // Any derives the disjuntive clause of all predicates in a slice of predicates.
+// Predicates are evaluated in order, until one of them holds.
 func Any(criteria []Criterion) Criterion {
-	any := False
-	for _, c := range criteria {
-		any = any.Or(c)
+	switch len(criteria) {
+	case 0:
+		return False
+	case 1:
+		return criteria[0]
+	}
+	cs := append([]Criterion(nil), criteria...)
+	p := func(r *Record) bool {
+		for _, c := range cs {
+			if c.Eval(r) {
+				return true
+			}
+		}
+		return false
 	}
-	return any
+	return Criterion{p}
 }
 
-// Exists creates a criterion for an existential predicate.
+// Exists creates a criterion for an existential predicate, which holds if attribute attr is present in a record.
+// Present attributes exist even if their values are zero (e.g., uid 0).
 func Exists(attr string) Criterion {
-	m := Mapper.Map(attr)
-	p := func(r *Record) bool { return reflect.ValueOf(m(r)).IsZero() }
-	return Criterion{p}
+	p := Mapper.MapPresence(attr)
+	return Criterion{Predicate(p)}
 }
 
 // Eq creates a criterion for an equality predicate.
-func Eq(lattr string, rattr string) Criterion {
-	ml := Mapper.MapStr(lattr)
-	mr := Mapper.MapStr(rattr)
-	p := func(r *Record) bool { return eval(ml(r), mr(r), ops.eq) }
-	return Criterion{p}
+// Attributes are compared by value type, and literals must be of the type of the attributes they're compared with.
+func Eq(lattr string, rattr string) (Criterion, error) {
+	t, err := comparisonType(lattr, rattr)
+	if err != nil {
+		return False, err
+	}
+	var p Predicate
+	switch t {
+	case IntValue:
+		ml := Mapper.MapInt(lattr)
+		mr := Mapper.MapInt(rattr)
+		p = func(r *Record) bool { return ml(r) == mr(r) }
+	case BoolValue:
+		ml := Mapper.MapBool(lattr)
+		mr := Mapper.MapBool(rattr)
+		p = func(r *Record) bool { return ml(r) == mr(r) }
+	default:
+		ml := Mapper.MapStr(lattr)
+		mr := Mapper.MapStr(rattr)
+		p = func(r *Record) bool { return eval(ml(r), mr(r), ops.eq) }
+	}
+	return Criterion{p}, nil
 }
 
 // NEq creates a criterion for an inequality predicate.
-func NEq(lattr string, rattr string) Criterion {
-	return Eq(lattr, rattr).Not()
+func NEq(lattr string, rattr string) (Criterion, error) {
+	c, err := Eq(lattr, rattr)
+	if err != nil {
+		return False, err
+	}
+	return c.Not(), nil
 }
 
 // Ge creates a criterion for a greater-or-equal predicate.
-func Ge(lattr string, rattr string) Criterion {
+func Ge(lattr string, rattr string) (Criterion, error) {
+	if err := checkInts(lattr, rattr); err != nil {
+		return False, err
+	}
 	ml := Mapper.MapInt(lattr)
 	mr := Mapper.MapInt(rattr)
 	p := func(r *Record) bool { return ml(r) >= mr(r) }
-	return Criterion{p}
+	return Criterion{p}, nil
 }
 
 // Gt creates a criterion for a greater-than predicate.
-func Gt(lattr string, rattr string) Criterion {
+func Gt(lattr string, rattr string) (Criterion, error) {
+	if err := checkInts(lattr, rattr); err != nil {
+		return False, err
+	}
 	ml := Mapper.MapInt(lattr)
 	mr := Mapper.MapInt(rattr)
 	p := func(r *Record) bool { return ml(r) > mr(r) }
-	return Criterion{p}
+	return Criterion{p}, nil
 }
 
 // Le creates a criterion for a lower-or-equal predicate.
-func Le(lattr string, rattr string) Criterion {
-	return Gt(lattr, rattr).Not()
+func Le(lattr string, rattr string) (Criterion, error) {
+	c, err := Gt(lattr, rattr)
+	if err != nil {
+		return False, err
+	}
+	return c.Not(), nil
 }
 
 // Lt creates a criterion for a lower-than predicate.
-func Lt(lattr string, rattr string) Criterion {
-	return Ge(lattr, rattr).Not()
+func Lt(lattr string, rattr string) (Criterion, error) {
+	c, err := Ge(lattr, rattr)
+	if err != nil {
+		return False, err
+	}
+	return c.Not(), nil
 }
 
 // StartsWith creates a criterion for a starts-with predicate.
@@ -152,24 +213,156 @@ func Contains(lattr string, rattr string) Criterion {
 
 // IContains creates a criterion for a case-insensitive contains predicate.
 func IContains(lattr string, rattr string) Criterion {
-	ml := Mapper.MapStr(lattr)
-	mr := Mapper.MapStr(rattr)
-	p := func(r *Record) bool { return eval(ml(r), mr(r), ops.icontains) }
+	return ieval(lattr, rattr, ops.contains)
+}
+
+// IEq creates a criterion for a case-insensitive equality predicate.
+func IEq(lattr string, rattr string) Criterion {
+	return ieval(lattr, rattr, ops.eq)
+}
+
+// IStartsWith creates a criterion for a case-insensitive starts-with predicate.
+func IStartsWith(lattr string, rattr string) Criterion {
+	return ieval(lattr, rattr, ops.startswith)
+}
+
+// IEndsWith creates a criterion for a case-insensitive ends-with predicate.
+func IEndsWith(lattr string, rattr string) Criterion {
+	return ieval(lattr, rattr, ops.endswith)
+}
+
+// ieval creates a criterion comparing the lowercased values of lattr and rattr with operator op.
+func ieval(lattr string, rattr string, op operator) Criterion {
+	ml := mapLower(lattr)
+	mr := mapLower(rattr)
+	p := func(r *Record) bool { return eval(ml(r), mr(r), op) }
 	return Criterion{p}
 }
 
-// In creates a criterion for a list-inclusion predicate.
-func In(attr string, list []string) Criterion {
+// mapLower returns a string field map of the lowercased values of attr.
+// Literals are lowercased once, when the field map is created, and attributes when records are evaluated.
+func mapLower(attr string) StrFieldMap {
 	m := Mapper.MapStr(attr)
+	if _, ok := Mapper.Mappers[attr]; !ok {
+		v := strings.ToLower(m(nil))
+		return func(r *Record) string { return v }
+	}
+	return func(r *Record) string { return strings.ToLower(m(r)) }
+}
+
+// Matches creates a criterion for a regular expression matching predicate.
+// The pattern is compiled once, when the criterion is created. The values of list attributes are matched
+// one by one, and the values of other attributes as a whole, even if they contain commas.
+func Matches(attr string, pattern string) (Criterion, error) {
+	return matches(Mapper.MapStr(attr), pattern, isList(attr))
+}
+
+// IMatches creates a criterion for a case-insensitive regular expression matching predicate.
+func IMatches(attr string, pattern string) (Criterion, error) {
+	return matches(Mapper.MapStr(attr), "(?i)"+pattern, isList(attr))
+}
+
+// matches creates a criterion that matches the value of m, or each of its values if list is set, against the compiled pattern.
+func matches(m StrFieldMap, pattern string, list bool) (Criterion, error) {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return False, err
+	}
+	if !list {
+		p := func(r *Record) bool {
+			return re.MatchString(m(r))
+		}
+		return Criterion{p}, nil
+	}
 	p := func(r *Record) bool {
-		for _, v := range list {
-			if eval(m(r), v, ops.eq) {
+		for _, v := range strings.Split(m(r), LISTSEP) {
+			if re.MatchString(v) {
 				return true
 			}
 		}
 		return false
 	}
-	return Criterion{p}
+	return Criterion{p}, nil
+}
+
+// In creates a criterion for a list-inclusion predicate.
+// List items are compared by the value type of attr.
+func In(attr string, list []string) (Criterion, error) {
+	t := valueTypeOf(attr)
+	for _, v := range list {
+		if err := checkLiteral(attr, t, v); err != nil {
+			return False, err
+		}
+	}
+	var p Predicate
+	switch t {
+	case IntValue:
+		m := Mapper.MapInt(attr)
+		vs := make([]int64, len(list))
+		for i, v := range list {
+			vs[i], _ = strconv.ParseInt(trimBoundingQuotes(v), 10, 64)
+		}
+		p = func(r *Record) bool {
+			x := m(r)
+			for _, v := range vs {
+				if x == v {
+					return true
+				}
+			}
+			return false
+		}
+	case BoolValue:
+		m := Mapper.MapBool(attr)
+		vs := make([]bool, len(list))
+		for i, v := range list {
+			vs[i], _ = strconv.ParseBool(trimBoundingQuotes(v))
+		}
+		p = func(r *Record) bool {
+			x := m(r)
+			for _, v := range vs {
+				if x == v {
+					return true
+				}
+			}
+			return false
+		}
+	default:
+		m := Mapper.MapStr(attr)
+		p = func(r *Record) bool {
+			x := m(r)
+			for _, v := range list {
+				if eval(x, v, ops.eq) {
+					return true
+				}
+			}
+			return false
+		}
+	}
+	return Criterion{p}, nil
+}
+
+// IIn creates a criterion for a case-insensitive list-inclusion predicate.
+// List items are lowercased once, when the criterion is created. Non-string attributes
+// have no case, and are compared by value type as with In.
+func IIn(attr string, list []string) (Criterion, error) {
+	if valueTypeOf(attr) != StrValue {
+		return In(attr, list)
+	}
+	m := mapLower(attr)
+	vs := make([]string, len(list))
+	for i, v := range list {
+		vs[i] = strings.ToLower(v)
+	}
+	p := func(r *Record) bool {
+		x := m(r)
+		for _, v := range vs {
+			if eval(x, v, ops.eq) {
+				return true
+			}
+		}
+		return false
+	}
+	return Criterion{p}, nil
 }
 
 // PMatch creates a criterion for a list-pattern-matching predicate.
@@ -186,6 +379,37 @@ func PMatch(attr string, list []string) Criterion {
 	return Criterion{p}
 }
 
+// InCIDR creates a criterion for a subnet-inclusion predicate.
+// The subnets in list are parsed once, when the criterion is created, and the addresses of
+// IP address attributes are looked up as integers, without formatting and parsing them.
+func InCIDR(attr string, list []string) (Criterion, error) {
+	nets, err := ParseIPNets(list)
+	if err != nil {
+		return False, err
+	}
+	if ips := Mapper.MapIPs(attr); ips != nil {
+		p := func(r *Record) bool {
+			for _, ip := range ips(r) {
+				if nets.ContainsIPv4(ip) {
+					return true
+				}
+			}
+			return false
+		}
+		return Criterion{p}, nil
+	}
+	m := Mapper.MapStr(attr)
+	p := func(r *Record) bool {
+		for _, v := range strings.Split(m(r), LISTSEP) {
+			if ip := net.ParseIP(v); ip != nil && nets.Contains(ip) {
+				return true
+			}
+		}
+		return false
+	}
+	return Criterion{p}, nil
+}
+
 // operator type.
 type operator func(string, string) bool
 
@@ -193,7 +417,6 @@ type operator func(string, string) bool
 type operators struct {
 	eq         operator
 	contains   operator
-	icontains  operator
 	startswith operator
 	endswith   operator
 }
@@ -202,15 +425,82 @@ type operators struct {
 var ops = operators{
 	eq:         func(l string, r string) bool { return l == r },
 	contains:   func(l string, r string) bool { return strings.Contains(l, r) },
-	icontains:  func(l string, r string) bool { return strings.Contains(strings.ToLower(l), strings.ToLower(r)) },
 	startswith: func(l string, r string) bool { return strings.HasPrefix(l, r) },
 	endswith:   func(l string, r string) bool { return strings.HasSuffix(l, r) },
 }
 
-// Eval evaluates a boolean operator over two predicates.
-func eval(l interface{}, r interface{}, op operator) bool {
-	lattrs := strings.Split(fmt.Sprintf("%v", l), LISTSEP)
-	rattrs := strings.Split(fmt.Sprintf("%v", r), LISTSEP)
+// valueTypeOf returns the value type of attr, which is a string for literals.
+func valueTypeOf(attr string) ValueType {
+	if mapper, ok := Mapper.Mappers[attr]; ok {
+		return mapper.Type.valueType()
+	}
+	return StrValue
+}
+
+// isList checks whether attr is mapped to a list of values separated by LISTSEP.
+func isList(attr string) bool {
+	if mapper, ok := Mapper.Mappers[attr]; ok {
+		return mapper.Type == MapArrayStr || mapper.Type == MapArrayInt
+	}
+	return false
+}
+
+// comparisonType returns the value type by which lattr and rattr are compared.
+// Literals are compared as strings with one another, and by type with attributes.
+func comparisonType(lattr string, rattr string) (ValueType, error) {
+	lf, lok := Mapper.Mappers[lattr]
+	rf, rok := Mapper.Mappers[rattr]
+	switch {
+	case lok && rok:
+		lt, rt := lf.Type.valueType(), rf.Type.valueType()
+		if lt != rt {
+			return lt, fmt.Errorf("cannot compare %s attribute %s with %s attribute %s", lt, lattr, rt, rattr)
+		}
+		return lt, nil
+	case lok:
+		return lf.Type.valueType(), checkLiteral(lattr, lf.Type.valueType(), rattr)
+	case rok:
+		return rf.Type.valueType(), checkLiteral(rattr, rf.Type.valueType(), lattr)
+	}
+	return StrValue, nil
+}
+
+// checkLiteral checks that literal lit can be compared with attribute attr of value type t.
+func checkLiteral(attr string, t ValueType, lit string) error {
+	var err error
+	switch t {
+	case IntValue:
+		_, err = strconv.ParseInt(trimBoundingQuotes(lit), 10, 64)
+	case BoolValue:
+		_, err = strconv.ParseBool(trimBoundingQuotes(lit))
+	}
+	if err != nil {
+		return fmt.Errorf("cannot compare %s attribute %s with non-%s value %s", t, attr, t, lit)
+	}
+	return nil
+}
+
+// checkInts checks that lattr and rattr are integer attributes or literals.
+func checkInts(lattr string, rattr string) error {
+	for _, attr := range []string{lattr, rattr} {
+		if mapper, ok := Mapper.Mappers[attr]; ok {
+			if t := mapper.Type.valueType(); t != IntValue {
+				return fmt.Errorf("cannot order %s attribute %s, only int attributes are ordered", t, attr)
+			}
+		} else if _, err := strconv.ParseInt(trimBoundingQuotes(attr), 10, 64); err != nil {
+			return fmt.Errorf("cannot order non-int value %s", attr)
+		}
+	}
+	return nil
+}
+
+// Eval evaluates a boolean operator over two, possibly comma-separated, lists of values.
+func eval(l string, r string, op operator) bool {
+	if !strings.Contains(l, LISTSEP) && !strings.Contains(r, LISTSEP) {
+		return op(l, r)
+	}
+	lattrs := strings.Split(l, LISTSEP)
+	rattrs := strings.Split(r, LISTSEP)
 	for _, lattr := range lattrs {
 		for _, rattr := range rattrs {
 			if op(lattr, rattr) {
@@ -23,6 +23,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/sysflow-telemetry/sf-apis/go/sfgo"
+	"github.com/sysflow-telemetry/sf-processor/core/flattener"
 	. "github.com/sysflow-telemetry/sf-processor/core/policyengine/engine"
 )
 
@@ -65,3 +67,273 @@ func TestAny(t *testing.T) {
 	assert.Equal(t, true, Any([]Criterion{False, True}).Eval(r))
 	assert.Equal(t, false, Any([]Criterion{False, False}).Eval(r))
 }
+
+func TestMatches(t *testing.T) {
+	var r *Record
+	c, err := Matches("/usr/bin/python3.8", "^/usr/bin/python[0-9.]*$")
+	assert.NoError(t, err)
+	assert.Equal(t, true, c.Eval(r))
+	c, err = Matches("/usr/bin/Python3.8", "^/usr/bin/python[0-9.]*$")
+	assert.NoError(t, err)
+	assert.Equal(t, false, c.Eval(r))
+	c, err = IMatches("/usr/bin/Python3.8", "^/usr/bin/python[0-9.]*$")
+	assert.NoError(t, err)
+	assert.Equal(t, true, c.Eval(r))
+	_, err = Matches("/usr/bin/python3.8", "^/usr/bin/(python")
+	assert.Error(t, err)
+
+	// values of list attributes are matched one by one, and values of other attributes as a whole
+	r = newProcRecord("/bin/sh", "-c a,b")
+	r.Fr.Ints[0][sfgo.FL_NETW_SPORT_INT] = 22
+	r.Fr.Ints[0][sfgo.FL_NETW_DPORT_INT] = 80
+	for _, m := range []struct {
+		attr    string
+		pattern string
+		match   bool
+	}{
+		{SF_PROC_ARGS, "^-c a,b$", true},
+		{SF_PROC_ARGS, "^b$", false},
+		{SF_NET_PORT, "^80$", true},
+		{SF_NET_PORT, "^22,80$", false},
+	} {
+		c, err = Matches(m.attr, m.pattern)
+		assert.NoError(t, err)
+		assert.Equal(t, m.match, c.Eval(r), m.attr+" "+m.pattern)
+	}
+}
+
+func TestInCIDR(t *testing.T) {
+	var r *Record
+	c, err := InCIDR("10.1.2.3", []string{"10.0.0.0/8", "192.168.1.0/24"})
+	assert.NoError(t, err)
+	assert.Equal(t, true, c.Eval(r))
+	c, err = InCIDR("172.20.0.1", []string{"172.16.0.0/12"})
+	assert.NoError(t, err)
+	assert.Equal(t, true, c.Eval(r))
+	c, err = InCIDR("172.32.0.1", []string{"172.16.0.0/12"})
+	assert.NoError(t, err)
+	assert.Equal(t, false, c.Eval(r))
+	c, err = InCIDR("fe80::1", []string{"10.0.0.0/8", "fe80::/10"})
+	assert.NoError(t, err)
+	assert.Equal(t, true, c.Eval(r))
+	c, err = InCIDR("8.8.8.8", []string{"8.8.8.8", "::/0"})
+	assert.NoError(t, err)
+	assert.Equal(t, true, c.Eval(r))
+	c, err = InCIDR("8.8.4.4", []string{"8.8.8.8", "::/0"})
+	assert.NoError(t, err)
+	assert.Equal(t, false, c.Eval(r))
+	c, err = InCIDR("10.1.2.3", []string{"::ffff:10.0.0.0/104"})
+	assert.NoError(t, err)
+	assert.Equal(t, true, c.Eval(r))
+	c, err = InCIDR("8.8.8.8", []string{"::ffff:0.0.0.0/96"})
+	assert.NoError(t, err)
+	assert.Equal(t, true, c.Eval(r))
+	_, err = InCIDR("10.1.2.3", []string{"10.0.0.0/33"})
+	assert.Error(t, err)
+}
+
+func TestInCIDRRecord(t *testing.T) {
+	fr := sfgo.FlatRecord{
+		Sources: []sfgo.Source{sfgo.SYSFLOW_SRC},
+		Ints:    [][]int64{make([]int64, sfgo.INT_ARRAY_SIZE)},
+		Strs:    [][]string{make([]string, sfgo.STR_ARRAY_SIZE)},
+	}
+	fr.Ints[0][sfgo.SF_REC_TYPE] = sfgo.NET_FLOW
+	fr.Ints[0][sfgo.FL_NETW_SIP_INT] = 127 | 1<<24
+	fr.Ints[0][sfgo.FL_NETW_DIP_INT] = 10 | 1<<8 | 2<<16 | 3<<24
+	r := NewRecord(fr, nil)
+	assert.Equal(t, "10.1.2.3", Mapper.MapStr(SF_NET_DIP)(r))
+	for _, c := range []struct {
+		attr  string
+		cidrs []string
+		match bool
+	}{
+		{SF_NET_DIP, []string{"10.0.0.0/8"}, true},
+		{SF_NET_DIP, []string{"10.1.2.3"}, true},
+		{SF_NET_DIP, []string{"10.1.2.4/31"}, false},
+		{SF_NET_DIP, []string{"::ffff:10.1.0.0/112"}, true},
+		{SF_NET_DIP, []string{"\"10.1.0.0/16\""}, true},
+		{SF_NET_SIP, []string{"10.0.0.0/8"}, false},
+		{SF_NET_IP, []string{"127.0.0.0/8"}, true},
+		{SF_NET_IP, []string{"fe80::/10"}, false},
+	} {
+		p, err := InCIDR(c.attr, c.cidrs)
+		assert.NoError(t, err)
+		assert.Equal(t, c.match, p.Eval(r), c.attr, c.cidrs)
+	}
+}
+
+func TestFalcoFields(t *testing.T) {
+	r := NewRecord(sfgo.FlatRecord{
+		Sources: []sfgo.Source{sfgo.SYSFLOW_SRC},
+		Ints:    [][]int64{make([]int64, sfgo.INT_ARRAY_SIZE)},
+		Strs:    [][]string{make([]string, sfgo.STR_ARRAY_SIZE)},
+	}, nil)
+	r.Fr.Ints[0][sfgo.SF_REC_TYPE] = sfgo.FILE_FLOW
+	r.Fr.Ints[0][sfgo.TS_INT] = 7000000000
+	r.Fr.Ints[0][sfgo.PROC_OID_CREATETS_INT] = 2000000000
+	r.Fr.Ints[0][sfgo.FILE_RESTYPE_INT] = '4'
+	r.Fr.Ints[0][sfgo.FL_FILE_OPENFLAGS_INT] = sfgo.O_WRONLY | sfgo.O_CREAT | sfgo.O_TRUNC
+	r.Fr.Ints[0][sfgo.FL_NETW_PROTO_INT] = 6
+	r.Fr.Strs[0][sfgo.CONT_IMAGE_STR] = "registry:5000/falcosecurity/falco:0.29.1@sha256:3c4a"
+	r.Fr.Strs[0][sfgo.FILE_PATH_STR] = "/etc/shadow"
+	r.Fr.Strs[0][sfgo.PROC_USERNAME_STR] = "nobody"
+	c, err := Eq(FALCO_EVT_UID, "nobody")
+	assert.NoError(t, err)
+	assert.Equal(t, true, c.Eval(r))
+	assert.Equal(t, "registry:5000/falcosecurity/falco", Mapper.MapStr(FALCO_CONT_IMAGE_REPOSITORY)(r))
+	assert.Equal(t, "/etc/shadow", Mapper.MapStr(FALCO_EVT_PATH)(r))
+	assert.Equal(t, "ipv4", Mapper.MapStr(FALCO_FD_TYPE)(r))
+	assert.Equal(t, "ip", Mapper.MapStr(FALCO_FD_SOCKFAMILY)(r))
+	assert.Equal(t, "tcp", Mapper.MapStr(FALCO_FD_L4PROTO)(r))
+	assert.Equal(t, int64(5000000000), Mapper.MapInt(FALCO_PROC_DURATION)(r))
+	assert.Equal(t, true, Contains(FALCO_EVT_FLAGS, "O_TRUNC").Eval(r))
+	assert.Equal(t, false, Contains(FALCO_EVT_FLAGS, "O_APPEND").Eval(r))
+	r.Fr.Ints[0][sfgo.FILE_RESTYPE_INT] = 'u'
+	r.Fr.Strs[0][sfgo.CONT_IMAGE_STR] = "falcosecurity/falco"
+	assert.Equal(t, "falcosecurity/falco", Mapper.MapStr(FALCO_CONT_IMAGE_REPOSITORY)(r))
+	assert.Equal(t, "unix", Mapper.MapStr(FALCO_FD_SOCKFAMILY)(r))
+	r.Fr.Ints[0][sfgo.FILE_RESTYPE_INT] = 'f'
+	assert.Equal(t, "file", Mapper.MapStr(FALCO_FD_TYPE)(r))
+	assert.Equal(t, "", Mapper.MapStr(FALCO_FD_SOCKFAMILY)(r))
+	assert.Equal(t, false, Mapper.IsUnknownField("proc.aname[2]"))
+	assert.Equal(t, true, Mapper.IsUnknownField("proc.aname[9]"))
+}
+
+func TestTypedComparisons(t *testing.T) {
+	r := NewRecord(sfgo.FlatRecord{}, nil)
+	for _, c := range []struct {
+		pred  func(string, string) (Criterion, error)
+		l, r  string
+		match bool
+	}{
+		{Eq, "sf.proc.pid", "0", true},
+		{Eq, "sf.proc.pid", "\"0\"", true},
+		{NEq, "sf.proc.pid", "1", true},
+		{Eq, "sf.proc.pid", "sf.proc.uid", true},
+		{Eq, "sf.proc.tty", "false", true},
+		{Eq, "sf.proc.tty", "true", false},
+		{Eq, "sf.file.is_open_write", "false", true},
+		{Eq, "sf.proc.exe", "", true},
+		{Eq, "a", "a", true},
+		{Gt, "sf.ts", "-1", true},
+		{Ge, "2", "10", false},
+		{Lt, "sf.net.dport", "1024", true},
+		{Le, "sf.net.dport", "sf.net.sport", true},
+	} {
+		p, err := c.pred(c.l, c.r)
+		assert.NoError(t, err, c.l+" "+c.r)
+		assert.Equal(t, c.match, p.Eval(r), c.l+" "+c.r)
+	}
+	for _, c := range []struct {
+		pred func(string, string) (Criterion, error)
+		l, r string
+	}{
+		{Eq, "sf.proc.pid", "bash"},
+		{NEq, "sf.proc.tty", "yes"},
+		{Eq, "sf.proc.pid", "sf.proc.exe"},
+		{Gt, "sf.proc.exe", "1"},
+		{Ge, "sf.ts", "now"},
+		{Lt, "sf.proc.tty", "1"},
+		{Le, "a", "b"},
+	} {
+		_, err := c.pred(c.l, c.r)
+		assert.Error(t, err, c.l+" "+c.r)
+	}
+}
+
+func TestIn(t *testing.T) {
+	r := NewRecord(sfgo.FlatRecord{}, nil)
+	c, err := In("sf.proc.pid", []string{"1", "0"})
+	assert.NoError(t, err)
+	assert.Equal(t, true, c.Eval(r))
+	c, err = In("sf.proc.tty", []string{"true"})
+	assert.NoError(t, err)
+	assert.Equal(t, false, c.Eval(r))
+	c, err = In("bash", []string{"sh", "bash"})
+	assert.NoError(t, err)
+	assert.Equal(t, true, c.Eval(r))
+	_, err = In("sf.proc.pid", []string{"1", "bash"})
+	assert.Error(t, err)
+}
+
+func TestCaseInsensitive(t *testing.T) {
+	r := NewRecord(sfgo.FlatRecord{
+		Sources: []sfgo.Source{sfgo.SYSFLOW_SRC},
+		Ints:    [][]int64{make([]int64, sfgo.INT_ARRAY_SIZE)},
+		Strs:    [][]string{make([]string, sfgo.STR_ARRAY_SIZE)},
+	}, nil)
+	r.Fr.Strs[0][sfgo.PROC_EXE_STR] = "C:\\Windows\\System32\\CMD.exe"
+	assert.Equal(t, true, IEq(SF_PROC_EXE, "c:\\windows\\system32\\cmd.EXE").Eval(r))
+	assert.Equal(t, true, IEq("c:\\windows\\system32\\cmd.EXE", SF_PROC_EXE).Eval(r))
+	assert.Equal(t, false, IEq(SF_PROC_EXE, "cmd.exe").Eval(r))
+	assert.Equal(t, true, IStartsWith(SF_PROC_EXE, "c:\\WINDOWS").Eval(r))
+	assert.Equal(t, false, StartsWith(SF_PROC_EXE, "c:\\WINDOWS").Eval(r))
+	assert.Equal(t, true, IEndsWith(SF_PROC_EXE, "\\cmd.EXE").Eval(r))
+	assert.Equal(t, false, EndsWith(SF_PROC_EXE, "\\cmd.EXE").Eval(r))
+	assert.Equal(t, true, IContains(SF_PROC_EXE, "system32").Eval(r))
+	c, err := IIn(SF_PROC_EXE, []string{"cmd.exe", "C:\\WINDOWS\\SYSTEM32\\CMD.EXE"})
+	assert.NoError(t, err)
+	assert.Equal(t, true, c.Eval(r))
+	c, err = IIn(SF_PROC_EXE, []string{"cmd.exe", "powershell.exe"})
+	assert.NoError(t, err)
+	assert.Equal(t, false, c.Eval(r))
+	r.Fr.Ints[0][sfgo.PROC_OID_HPID_INT] = 22
+	c, err = IIn("sf.proc.pid", []string{"22", "80"})
+	assert.NoError(t, err)
+	assert.Equal(t, true, c.Eval(r))
+	_, err = IIn("sf.proc.pid", []string{"22", "ssh"})
+	assert.Error(t, err)
+}
+
+func TestExists(t *testing.T) {
+	r := NewRecord(sfgo.FlatRecord{
+		Sources: []sfgo.Source{sfgo.SYSFLOW_SRC},
+		Ints:    [][]int64{make([]int64, sfgo.INT_ARRAY_SIZE)},
+		Strs:    [][]string{make([]string, sfgo.STR_ARRAY_SIZE)},
+	}, nil)
+	r.Fr.Ints[0][sfgo.SF_REC_TYPE] = sfgo.PROC_EVT
+	assert.Equal(t, false, Exists(SF_PROC_UID).Eval(r))
+	assert.Equal(t, true, Exists(SF_TS).Eval(r))
+	assert.Equal(t, true, Exists("root").Eval(r))
+	r.Fr.Ints[0][sfgo.PROC_OID_HPID_INT] = 42
+	assert.Equal(t, true, Exists(SF_PROC_UID).Eval(r))
+	assert.Equal(t, true, Exists(SF_RET).Eval(r))
+	assert.Equal(t, false, Exists(SF_ENDTS).Eval(r))
+	assert.Equal(t, false, Exists(SF_PPROC_PID).Eval(r))
+	assert.Equal(t, false, Exists(SF_PPROC_EXE).Eval(r))
+	assert.Equal(t, false, Exists(SF_CONTAINER_NAME).Eval(r))
+	assert.Equal(t, false, Exists(SF_FILE_PATH).Eval(r))
+	assert.Equal(t, false, Exists(SF_NET_DPORT).Eval(r))
+	r.Fr.Ints[0][sfgo.PROC_POID_HPID_INT] = 1
+	r.Fr.Strs[0][sfgo.CONT_ID_STR] = "3ce8a6fc9c6d"
+	assert.Equal(t, true, Exists(SF_PPROC_PID).Eval(r))
+	assert.Equal(t, true, Exists(SF_CONTAINER_NAME).Eval(r))
+	r.Fr.Ints[0][sfgo.SF_REC_TYPE] = sfgo.NET_FLOW
+	assert.Equal(t, true, Exists(SF_NET_DPORT).Eval(r))
+	assert.Equal(t, true, Exists(SF_ENDTS).Eval(r))
+	assert.Equal(t, false, Exists(SF_RET).Eval(r))
+}
+
+func TestExistsPresence(t *testing.T) {
+	r := NewRecord(sfgo.FlatRecord{
+		Sources: []sfgo.Source{sfgo.SYSFLOW_SRC},
+		Ints:    [][]int64{make([]int64, flattener.PresenceIdx+1)},
+		Strs:    [][]string{make([]string, sfgo.STR_ARRAY_SIZE)},
+	}, nil)
+	r.Fr.Ints[0][sfgo.SF_REC_TYPE] = sfgo.FILE_EVT
+	r.Fr.Ints[0][flattener.PresenceIdx] = flattener.ProcPresent | flattener.PProcPresent
+	r.Fr.Ints[0][sfgo.PROC_OID_HPID_INT] = 1
+	assert.Equal(t, true, Exists(SF_PROC_PID).Eval(r))
+	assert.Equal(t, true, Exists(SF_PPROC_PID).Eval(r))
+	assert.Equal(t, false, Exists(SF_CONTAINER_ID).Eval(r))
+	assert.Equal(t, false, Exists(SF_FILE_PATH).Eval(r))
+	assert.Equal(t, false, Exists(SF_FILE_NEWPATH).Eval(r))
+	r.Fr.Ints[0][flattener.PresenceIdx] = flattener.ContPresent | flattener.FilePresent | flattener.SecFilePresent
+	assert.Equal(t, false, Exists(SF_PROC_PID).Eval(r))
+	assert.Equal(t, false, Exists(SF_PPROC_PID).Eval(r))
+	assert.Equal(t, true, Exists(SF_CONTAINER_ID).Eval(r))
+	assert.Equal(t, true, Exists(SF_FILE_PATH).Eval(r))
+	assert.Equal(t, true, Exists(SF_FILE_NEWPATH).Eval(r))
+	assert.Equal(t, true, Exists(SF_CONTAINER_ID).Eval(r.Copy()))
+}
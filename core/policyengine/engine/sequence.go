@@ -0,0 +1,220 @@
+//
+// Copyright (C) 2020 IBM Corporation.
+//
+// Authors:
+// Frederico Araujo <frederico.araujo@ibm.com>
+// Teryl Taylor <terylt@ibm.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+package engine
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// maxSequenceKeys is the maximum number of keys for which a sequence step keeps partial matches.
+const maxSequenceKeys = 1 << 16
+
+// partialMatch is a sequence matched up to some step, by the records in recs.
+type partialMatch struct {
+	start int64
+	recs  []*Record
+	done  bool
+}
+
+// keyedMatch is a partial match stored for a key.
+type keyedMatch struct {
+	key string
+	p   *partialMatch
+}
+
+// partialMatches holds the partial matches of a sequence step by key, and the keys in the order they were set.
+// The order may hold stale entries for keys that were since deleted or set again, which are skipped.
+type partialMatches struct {
+	byKey map[string]*partialMatch
+	order []keyedMatch
+}
+
+// set stores partial match p for key k. Once maxSequenceKeys is reached, the oldest partial matches are
+// dropped to make room for new keys.
+func (m *partialMatches) set(k string, p *partialMatch) {
+	if _, ok := m.byKey[k]; !ok {
+		for len(m.byKey) >= maxSequenceKeys && len(m.order) > 0 {
+			e := m.order[0]
+			m.order = m.order[1:]
+			if m.byKey[e.key] == e.p {
+				delete(m.byKey, e.key)
+			}
+		}
+	}
+	m.byKey[k] = p
+	m.order = append(m.order, keyedMatch{k, p})
+	if len(m.order) > 2*maxSequenceKeys {
+		m.compact()
+	}
+}
+
+// compact drops the stale entries of the key order.
+func (m *partialMatches) compact() {
+	order := make([]keyedMatch, 0, len(m.byKey))
+	for _, e := range m.order {
+		if m.byKey[e.key] == e.p {
+			order = append(order, e)
+		}
+	}
+	m.order = order
+}
+
+// sequence is a compiled sequence rule. It matches records that satisfy the conditions of its steps in order,
+// within a time window of the record matching the first step, and that are correlated on the values of the
+// steps' key fields. Key values that are lists correlate on any of their elements.
+type sequence struct {
+	name     string
+	steps    []Criterion
+	keys     [][]func(*Record) string
+	window   int64
+	ts       func(*Record) int64
+	mu       sync.Mutex
+	partials []*partialMatches
+	swept    int64
+}
+
+// newSequence creates a sequence with step conditions steps, correlated on the key fields of each step.
+// All steps must have the same number of key fields.
+func newSequence(name string, steps []Criterion, keys [][]string, window time.Duration) (*sequence, error) {
+	if len(steps) < 2 {
+		return nil, fmt.Errorf("sequence %s has %d steps, at least 2 are required", name, len(steps))
+	}
+	if window <= 0 {
+		return nil, fmt.Errorf("sequence %s has no window", name)
+	}
+	s := &sequence{name: name, steps: steps, window: int64(window), ts: Mapper.MapInt(SF_TS)}
+	for i, fields := range keys {
+		if len(fields) == 0 {
+			return nil, fmt.Errorf("step %d of sequence %s has no key", i+1, name)
+		}
+		if len(fields) != len(keys[0]) {
+			return nil, fmt.Errorf("step %d of sequence %s has %d key fields, expected %d", i+1, name, len(fields), len(keys[0]))
+		}
+		var ms []func(*Record) string
+		for _, f := range fields {
+			if _, ok := Mapper.Mappers[f]; !ok {
+				return nil, fmt.Errorf("unknown key field %s in sequence %s", f, name)
+			}
+			ms = append(ms, keyMapper(f))
+		}
+		s.keys = append(s.keys, ms)
+	}
+	for i := 0; i < len(steps)-1; i++ {
+		s.partials = append(s.partials, &partialMatches{byKey: make(map[string]*partialMatch)})
+	}
+	return s, nil
+}
+
+// parseWindow parses a sequence window, given either in seconds or as a duration string such as 5m.
+func parseWindow(w string) (time.Duration, error) {
+	if n, err := strconv.ParseInt(w, 10, 64); err == nil {
+		return time.Duration(n) * time.Second, nil
+	}
+	return time.ParseDuration(w)
+}
+
+// criterion returns a criterion that holds for records completing the sequence. The records contributing to the
+// completed sequence are stored in the record's context.
+func (s *sequence) criterion() Criterion {
+	p := func(r *Record) bool {
+		recs := s.advance(r)
+		if recs == nil {
+			return false
+		}
+		r.Ctx.SetSequence(s.name, recs)
+		return true
+	}
+	return Criterion{p}
+}
+
+// advance updates the partial matches of the sequence with record r, and returns the records of
+// the earliest sequence completed by r, if any. Steps are tried from last to first, so that a record
+// never matches more than one step of the same partial match.
+func (s *sequence) advance(r *Record) []*Record {
+	ts := s.ts(r)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sweep(ts)
+	var completed *partialMatch
+	last := len(s.steps) - 1
+	for i := last; i >= 0; i-- {
+		if !s.steps[i].Eval(r) {
+			continue
+		}
+		if i == 0 {
+			p := &partialMatch{start: ts, recs: []*Record{r}}
+			for _, k := range s.keyValues(0, r) {
+				s.partials[0].set(k, p)
+			}
+			continue
+		}
+		for _, k := range s.keyValues(i, r) {
+			p, ok := s.partials[i-1].byKey[k]
+			if !ok || p.done || ts < p.start || ts-p.start > s.window {
+				continue
+			}
+			delete(s.partials[i-1].byKey, k)
+			p.done = true
+			next := &partialMatch{start: p.start, recs: append(append(make([]*Record, 0, i+1), p.recs...), r)}
+			if i < last {
+				s.partials[i].set(k, next)
+			} else if completed == nil || next.start < completed.start {
+				completed = next
+			}
+		}
+	}
+	if completed == nil {
+		return nil
+	}
+	return completed.recs
+}
+
+// keyValues returns the correlation keys of record r for step i. Empty key values are not correlated.
+func (s *sequence) keyValues(i int, r *Record) []string {
+	sets := make([][]string, len(s.keys[i]))
+	for j, k := range s.keys[i] {
+		for _, v := range strings.Split(k(r), LISTSEP) {
+			if len(v) > 0 {
+				sets[j] = append(sets[j], v)
+			}
+		}
+	}
+	return product(sets)
+}
+
+// sweep drops expired partial matches, at most once per window.
+func (s *sequence) sweep(ts int64) {
+	if ts-s.swept < s.window {
+		return
+	}
+	for _, m := range s.partials {
+		for k, p := range m.byKey {
+			if ts-p.start > s.window {
+				delete(m.byKey, k)
+			}
+		}
+		m.compact()
+	}
+	s.swept = ts
+}
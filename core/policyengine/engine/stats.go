@@ -0,0 +1,101 @@
+//
+// Copyright (C) 2020 IBM Corporation.
+//
+// Authors:
+// Frederico Araujo <frederico.araujo@ibm.com>
+// Teryl Taylor <terylt@ibm.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+package engine
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/sysflow-telemetry/sf-apis/go/logger"
+)
+
+// evalStats holds the counters of the evaluations of a rule or filter condition.
+// Counters are updated atomically, since records may be evaluated by several workers.
+type evalStats struct {
+	evaluations uint64
+	matches     uint64
+	nanos       uint64
+}
+
+// eval evaluates condition c on record r, and counts the evaluation, its outcome, and its duration.
+func (s *evalStats) eval(c Criterion, r *Record) bool {
+	start := time.Now()
+	match := c.Eval(r)
+	atomic.AddUint64(&s.nanos, uint64(time.Since(start)))
+	atomic.AddUint64(&s.evaluations, 1)
+	if match {
+		atomic.AddUint64(&s.matches, 1)
+	}
+	return match
+}
+
+// PolicyStats holds the runtime statistics of a rule or filter.
+type PolicyStats struct {
+	Name        string
+	Evaluations uint64
+	Matches     uint64
+	Time        time.Duration
+}
+
+// newPolicyStats returns the statistics of rule or filter name from counters s.
+func newPolicyStats(name string, s *evalStats) PolicyStats {
+	return PolicyStats{
+		Name:        name,
+		Evaluations: atomic.LoadUint64(&s.evaluations),
+		Matches:     atomic.LoadUint64(&s.matches),
+		Time:        time.Duration(atomic.LoadUint64(&s.nanos)),
+	}
+}
+
+// Stats returns the runtime statistics of the rules and filters of the active policy set, in definition order.
+// Statistics are only collected if enabled in the interpreter's configuration, and are reset when policies
+// are compiled.
+func (pi *PolicyInterpreter) Stats() (rules []PolicyStats, filters []PolicyStats) {
+	ps := pi.getPolicySet()
+	rules = make([]PolicyStats, 0, len(ps.rules))
+	for _, r := range ps.rules {
+		rules = append(rules, newPolicyStats(r.Name, r.stats))
+	}
+	filters = make([]PolicyStats, 0, len(ps.filters))
+	for _, f := range ps.filters {
+		filters = append(filters, newPolicyStats(f.Name, f.stats))
+	}
+	return
+}
+
+// LogStats logs the runtime statistics of the rules and filters that have been evaluated.
+func (pi *PolicyInterpreter) LogStats() {
+	rules, filters := pi.Stats()
+	for _, s := range filters {
+		s.log("Filter")
+	}
+	for _, s := range rules {
+		s.log("Rule")
+	}
+}
+
+// log logs statistics s of a rule or filter, if it has been evaluated.
+func (s PolicyStats) log(kind string) {
+	if s.Evaluations == 0 {
+		return
+	}
+	logger.Info.Printf("%s %s: %d evaluations, %d matches, %v evaluation time (%v per evaluation)\n",
+		kind, s.Name, s.Evaluations, s.Matches, s.Time, s.Time/time.Duration(s.Evaluations))
+}
@@ -0,0 +1,39 @@
+//
+// Copyright (C) 2020 IBM Corporation.
+//
+// Authors:
+// Frederico Araujo <frederico.araujo@ibm.com>
+// Teryl Taylor <terylt@ibm.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+package engine
+
+// tagActuator is the actuator of the tag action, which adds the tags of a rule, with their %field
+// placeholders resolved, to the tags of the matching record.
+type tagActuator struct{}
+
+// newTagActuator creates a new tag actuator.
+func newTagActuator(conf Config) (Actuator, error) {
+	return tagActuator{}, nil
+}
+
+// Handle handles the action on record r. Empty tags are skipped.
+func (tagActuator) Handle(rule Rule, r *Record) error {
+	for _, t := range rule.tags {
+		if tag := t.render(r); len(tag) > 0 {
+			r.Ctx.AddTags(tag)
+		}
+	}
+	return nil
+}
@@ -28,19 +28,19 @@ import (
 	"github.com/sysflow-telemetry/sf-processor/core/cache"
 )
 
-// Action type for enumeration.
-type Action int
+// Action denotes the name of a rule action.
+type Action string
 
-// Action enumeration.
+// Built-in actions.
 const (
-	Alert Action = iota
-	Tag
-	Hash
+	Alert Action = "alert"
+	Tag   Action = "tag"
+	Hash  Action = "hash"
 )
 
 // String returns the string representation of an action instance.
 func (a Action) String() string {
-	return [...]string{"alert", "tag", "hash"}[a]
+	return string(a)
 }
 
 // EnrichmentTag denotes the type for enrichment tags.
@@ -67,23 +67,16 @@ type Rule struct {
 	Desc      string
 	condition Criterion
 	Actions   []Action
+	actuators []Actuator
+	Output    string
+	output    outputTemplate
 	Tags      []EnrichmentTag
+	tags      []outputTemplate
 	Priority  Priority
 	Prefilter []string
 	Enabled   bool
-}
-
-func (s Rule) isApplicable(r *Record) bool {
-	if len(s.Prefilter) == 0 {
-		return true
-	}
-	rtype := Mapper.MapStr(SF_TYPE)(r)
-	for _, pf := range s.Prefilter {
-		if rtype == pf {
-			return true
-		}
-	}
-	return false
+	types     recordTypes
+	stats     *evalStats
 }
 
 // Filter type
@@ -91,6 +84,7 @@ type Filter struct {
 	Name      string
 	condition Criterion
 	Enabled   bool
+	stats     *evalStats
 }
 
 // Record type
@@ -107,10 +101,47 @@ func NewRecord(fr sfgo.FlatRecord, cr *cache.SFTables) *Record {
 	r.Fr = fr
 	r.Cr = cr
 	r.Ptree = make(map[sfgo.OID][]*sfgo.Process)
-	r.Ctx = make(Context, 3)
+	r.Ctx = make(Context, numCtxKeys)
 	return r
 }
 
+// Copy creates a copy of record r, with its own flat record and context, so that the copy can be read and
+// written concurrently with r. The tables of r are shared.
+func (r *Record) Copy() *Record {
+	fr := sfgo.FlatRecord{
+		Sources: append([]sfgo.Source(nil), r.Fr.Sources...),
+		Ints:    make([][]int64, len(r.Fr.Ints)),
+		Strs:    make([][]string, len(r.Fr.Strs)),
+	}
+	for i, ints := range r.Fr.Ints {
+		fr.Ints[i] = append([]int64(nil), ints...)
+	}
+	for i, strs := range r.Fr.Strs {
+		fr.Strs[i] = append([]string(nil), strs...)
+	}
+	c := NewRecord(fr, r.Cr)
+	copy(c.Ctx, r.Ctx)
+	if outputs, ok := r.Ctx[outputCtxKey].(map[string]string); ok {
+		c.Ctx[outputCtxKey] = make(map[string]string, len(outputs))
+		for k, v := range outputs {
+			c.Ctx[outputCtxKey].(map[string]string)[k] = v
+		}
+	}
+	if seqs, ok := r.Ctx[seqCtxKey].(map[string][]*Record); ok {
+		c.Ctx[seqCtxKey] = make(map[string][]*Record, len(seqs))
+		for k, v := range seqs {
+			c.Ctx[seqCtxKey].(map[string][]*Record)[k] = v
+		}
+	}
+	if enrichments, ok := r.Ctx[enrichCtxKey].(map[string]interface{}); ok {
+		c.Ctx[enrichCtxKey] = make(map[string]interface{}, len(enrichments))
+		for k, v := range enrichments {
+			c.Ctx[enrichCtxKey].(map[string]interface{})[k] = v
+		}
+	}
+	return c
+}
+
 // RecordChannel type
 type RecordChannel struct {
 	In chan *Record
@@ -273,6 +304,11 @@ const (
 	ruleCtxKey contextKey = iota
 	tagCtxKey
 	hashCtxKey
+	outputCtxKey
+	seqCtxKey
+	enrichCtxKey
+	bundleCtxKey
+	numCtxKeys
 )
 
 // AddRule stores add a rule instance to the set of rules matching a record.
@@ -296,7 +332,24 @@ func (s Context) SetTags(tags []string) {
 	s[tagCtxKey] = tags
 }
 
-// GetTags retrieves hashes from context object.
+// AddTags adds tags to the tags stored in context object, skipping tags already stored.
+func (s Context) AddTags(tags ...string) {
+	stored := s.GetTags()
+	for _, tag := range tags {
+		dup := false
+		for _, t := range stored {
+			if dup = t == tag; dup {
+				break
+			}
+		}
+		if !dup {
+			stored = append(stored, tag)
+		}
+	}
+	s[tagCtxKey] = stored
+}
+
+// GetTags retrieves tags from context object.
 func (s Context) GetTags() []string {
 	if s[tagCtxKey] != nil {
 		return s[tagCtxKey].([]string)
@@ -317,6 +370,84 @@ func (s Context) GetHashes() HashSet {
 	return HashSet{}
 }
 
+// SetOutput stores the rendered output message of a rule matching a record.
+func (s Context) SetOutput(r Rule, msg string) {
+	if s[outputCtxKey] == nil {
+		s[outputCtxKey] = make(map[string]string)
+	}
+	s[outputCtxKey].(map[string]string)[r.Name] = msg
+}
+
+// GetOutput retrieves the rendered output message of a rule from context object.
+func (s Context) GetOutput(r Rule) string {
+	if s[outputCtxKey] != nil {
+		return s[outputCtxKey].(map[string]string)[r.Name]
+	}
+	return sfgo.Zeros.String
+}
+
+// SetSequence stores the records contributing to a sequence rule matching a record.
+func (s Context) SetSequence(name string, recs []*Record) {
+	if s[seqCtxKey] == nil {
+		s[seqCtxKey] = make(map[string][]*Record)
+	}
+	s[seqCtxKey].(map[string][]*Record)[name] = recs
+}
+
+// GetSequence retrieves the records contributing to a sequence rule from context object.
+// Records are ordered by the steps they matched, and the last one is the record itself.
+func (s Context) GetSequence(r Rule) []*Record {
+	if s[seqCtxKey] != nil {
+		return s[seqCtxKey].(map[string][]*Record)[r.Name]
+	}
+	return nil
+}
+
+// SetEnrichment stores the annotation of enrichment handler name into context object.
+func (s Context) SetEnrichment(name string, o interface{}) {
+	if s[enrichCtxKey] == nil {
+		s[enrichCtxKey] = make(map[string]interface{})
+	}
+	s[enrichCtxKey].(map[string]interface{})[name] = o
+}
+
+// GetEnrichment retrieves the annotation of enrichment handler name from context object.
+func (s Context) GetEnrichment(name string) interface{} {
+	if s[enrichCtxKey] != nil {
+		return s[enrichCtxKey].(map[string]interface{})[name]
+	}
+	return nil
+}
+
+// GetEnrichments retrieves the annotations of enrichment handlers from context object, keyed by handler name.
+func (s Context) GetEnrichments() map[string]interface{} {
+	if s[enrichCtxKey] != nil {
+		return s[enrichCtxKey].(map[string]interface{})
+	}
+	return nil
+}
+
+// SetBundle stores the policy bundle of the rules matching a record into context object.
+func (s Context) SetBundle(b PolicyBundle) {
+	s[bundleCtxKey] = b
+}
+
+// GetBundle retrieves the policy bundle of the rules matching a record from context object.
+// It returns nil if the rules weren't compiled from a policy bundle.
+func (s Context) GetBundle() *PolicyBundle {
+	if s[bundleCtxKey] != nil {
+		b := s[bundleCtxKey].(PolicyBundle)
+		return &b
+	}
+	return nil
+}
+
+// PolicyBundle identifies a verified policy bundle by its manifest version and digest.
+type PolicyBundle struct {
+	Version string
+	Digest  string
+}
+
 // HashSet type
 type HashSet struct {
 	MD5      string
@@ -0,0 +1,107 @@
+//
+// Copyright (C) 2020 IBM Corporation.
+//
+// Authors:
+// Frederico Araujo <frederico.araujo@ibm.com>
+// Teryl Taylor <terylt@ibm.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+package engine
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// version represents a policy engine version of the form major[.minor[.patch]][-prerelease][+build].
+type version struct {
+	core [3]int
+	pre  []string
+}
+
+// parseVersion parses a version string such as 0.2.2, v0.3, 1, or 0.3.0-rc1.
+// Build suffixes (e.g., 0.3.0+5) are ignored, and pre-release suffixes are ordered as in semantic versioning.
+func parseVersion(s string) (version, error) {
+	var v version
+	s = strings.TrimPrefix(strings.TrimSpace(s), "v")
+	if i := strings.Index(s, "+"); i >= 0 {
+		s = s[:i]
+	}
+	core := s
+	if i := strings.Index(s, "-"); i >= 0 {
+		core = s[:i]
+		v.pre = strings.Split(s[i+1:], ".")
+		for _, id := range v.pre {
+			if id == "" {
+				return v, fmt.Errorf("invalid version %s", s)
+			}
+		}
+	}
+	parts := strings.Split(core, ".")
+	if len(parts) > len(v.core) {
+		return v, fmt.Errorf("invalid version %s", s)
+	}
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 0 {
+			return v, fmt.Errorf("invalid version %s", s)
+		}
+		v.core[i] = n
+	}
+	return v, nil
+}
+
+// isFalcoEngineVersion checks whether s is a Falco engine version, which is a plain integer
+// unrelated to SysFlow versions (e.g., 7).
+func isFalcoEngineVersion(s string) bool {
+	n, err := strconv.Atoi(strings.TrimSpace(s))
+	return err == nil && n >= 0
+}
+
+// less returns whether version v precedes version o. Pre-releases precede their release (e.g., 1.2.0-rc1 < 1.2.0),
+// and are ordered by their dot-separated identifiers, numeric identifiers preceding alphanumeric ones.
+func (v version) less(o version) bool {
+	for i := range v.core {
+		if v.core[i] != o.core[i] {
+			return v.core[i] < o.core[i]
+		}
+	}
+	if len(v.pre) == 0 || len(o.pre) == 0 {
+		return len(v.pre) > len(o.pre)
+	}
+	for i := 0; i < len(v.pre) && i < len(o.pre); i++ {
+		if v.pre[i] == o.pre[i] {
+			continue
+		}
+		vn, verr := strconv.Atoi(v.pre[i])
+		on, oerr := strconv.Atoi(o.pre[i])
+		switch {
+		case verr == nil && oerr == nil:
+			return vn < on
+		case verr == nil || oerr == nil:
+			return verr == nil
+		}
+		return v.pre[i] < o.pre[i]
+	}
+	return len(v.pre) < len(o.pre)
+}
+
+func (v version) String() string {
+	s := fmt.Sprintf("%d.%d.%d", v.core[0], v.core[1], v.core[2])
+	if len(v.pre) > 0 {
+		s += "-" + strings.Join(v.pre, ".")
+	}
+	return s
+}
@@ -17,7 +17,17 @@ ENABLED: 'enabled';
 WARNEVTTYPE: 'warn_evttypes';
 SKIPUNKNOWN: 'skip-if-unknown-filter';
 FAPPEND: 'append';
-REQ: 'required_engine_version';
+REQ: 'required_engine_version' | 'required_sysflow_version';
+EXCEPTIONS: 'exceptions';
+FIELDS: 'fields';
+COMPS: 'comps';
+VALUES: 'values';
+SEQUENCE: 'sequence';
+KEY: 'key';
+WINDOW: 'window';
+STEPS: 'steps';
+WITHIN: 'within';
+BY: 'by';
 
 policy
 	: (prule | pfilter | pmacro | plist | preq)+ EOF
@@ -28,11 +38,11 @@ defs
 	;
 
 prule
-	: DECL RULE DEF text DESC DEF text COND DEF expression ((ACTION|OUTPUT) DEF text | PRIORITY DEF severity | TAGS DEF tags | PREFILTER DEF prefilter | ENABLED DEF enabled | WARNEVTTYPE DEF warnevttype | SKIPUNKNOWN DEF skipunknown)*
+	: DECL (RULE|SEQUENCE) DEF text (DESC DEF text)? (FAPPEND DEF fappend)? (COND DEF (OR|AND)? expression)? ((ACTION|OUTPUT) DEF text | PRIORITY DEF severity | TAGS DEF tags | PREFILTER DEF prefilter | ENABLED DEF enabled | WARNEVTTYPE DEF warnevttype | SKIPUNKNOWN DEF skipunknown | EXCEPTIONS DEF exceptions | KEY DEF fields | WINDOW DEF atom | STEPS DEF steps | FAPPEND DEF fappend)*
 	;
 
 srule
-	: DECL RULE DEF text DESC DEF text COND DEF expression ((ACTION|OUTPUT) DEF text | PRIORITY DEF severity | TAGS DEF tags | PREFILTER DEF prefilter | ENABLED DEF enabled | WARNEVTTYPE DEF warnevttype | SKIPUNKNOWN DEF skipunknown)*
+	: DECL (RULE|SEQUENCE) DEF text (DESC DEF text)? (FAPPEND DEF fappend)? (COND DEF (OR|AND)? expression)? ((ACTION|OUTPUT) DEF text | PRIORITY DEF severity | TAGS DEF tags | PREFILTER DEF prefilter | ENABLED DEF enabled | WARNEVTTYPE DEF warnevttype | SKIPUNKNOWN DEF skipunknown | EXCEPTIONS DEF exceptions | KEY DEF fields | WINDOW DEF atom | STEPS DEF steps | FAPPEND DEF fappend)*
 	;
 
 pfilter
@@ -44,11 +54,11 @@ sfilter
 	;
 
 pmacro
-	: DECL MACRO DEF ID COND DEF expression (FAPPEND DEF fappend)?
+	: DECL MACRO DEF identifier (FAPPEND DEF fappend)? COND DEF (OR|AND)? expression (FAPPEND DEF fappend)?
 	;
 
 plist
-	: DECL LIST DEF ID ITEMS DEF items 
+	: DECL LIST DEF identifier (FAPPEND DEF fappend)? ITEMS DEF items (FAPPEND DEF fappend)?
 	;
 
 preq
@@ -72,8 +82,32 @@ term
 	| NOT term
 	| atom unary_operator 
 	| atom binary_operator atom 
-	| atom (IN|PMATCH) LPAREN (atom|items) (LISTSEP (atom|items))* RPAREN 
+	| atom (IN|IIN|PMATCH|INCIDR) LPAREN (atom|items) (LISTSEP (atom|items))* RPAREN 
 	| LPAREN expression RPAREN
+	| aggregate
+	| arith_expression binary_operator arith_expression
+	;
+
+aggregate
+	: ID LPAREN atom? RPAREN binary_operator atom WITHIN atom (BY atom (LISTSEP atom)*)?
+	;
+
+arith_expression
+	: arith_term ((PLUS|DECL) arith_term)*
+	;
+
+arith_term
+	: arith_factor ((STAR|DIV|MOD) arith_factor)*
+	;
+
+arith_factor
+	: function
+	| LPAREN arith_expression RPAREN
+	| atom
+	;
+
+function
+	: ID LPAREN (arith_expression (LISTSEP arith_expression)*)? RPAREN
 	;
 
 items 
@@ -108,8 +142,56 @@ fappend
 	: atom
 	;
 
+exceptions
+	: exception+
+	;
+
+exception
+	: DECL NAME DEF ID (FIELDS DEF fields | COMPS DEF comps | VALUES DEF values)*
+	;
+
+fields
+	: items
+	| atom
+	;
+
+comps
+	: LBRACK comp (LISTSEP comp)* RBRACK
+	| comp
+	;
+
+comp
+	: binary_operator
+	| IN
+	| IIN
+	| PMATCH
+	| INCIDR
+	;
+
+values
+	: LBRACK (value (LISTSEP value)*)? RBRACK
+	| (DECL value)+
+	;
+
+value
+	: tuple
+	| atom
+	;
+
+tuple
+	: LBRACK (atom|items) (LISTSEP (atom|items))* RBRACK
+	;
+
+steps
+	: step+
+	;
+
+step
+	: DECL COND DEF expression (KEY DEF fields)?
+	;
+
 variable
-	: ID
+	: identifier
 	;		
 
 atom 
@@ -120,6 +202,24 @@ atom
 	| STRING	
 	| '<' /* event direction */
 	| '>' /* event direction */
+	| '/' /* root path */
+	| EXCEPTIONS /* keywords usable as identifiers */
+	| FIELDS
+	| COMPS
+	| VALUES
+	| SEQUENCE
+	| KEY
+	| WINDOW
+	| STEPS
+	| WITHIN
+	| BY
+	| IEQ
+	| IIN
+	| ISTARTSWITH
+	| IENDSWITH
+	| MATCHES
+	| IMATCHES
+	| INCIDR
 	;
 
 text
@@ -133,6 +233,10 @@ text
 		  p.GetCurrentToken().GetText() == "enabled" ||
 		  p.GetCurrentToken().GetText() == "warn_evttypes" ||
 		  p.GetCurrentToken().GetText() == "skip-if-unknown-filter" ||
+		  (p.GetCurrentToken().GetText() == "exceptions" && p.GetTokenStream().LA(2) == SfplParserDEF) ||
+		  (p.GetCurrentToken().GetText() == "key" && p.GetTokenStream().LA(2) == SfplParserDEF) ||
+		  (p.GetCurrentToken().GetText() == "window" && p.GetTokenStream().LA(2) == SfplParserDEF) ||
+		  (p.GetCurrentToken().GetText() == "steps" && p.GetTokenStream().LA(2) == SfplParserDEF) ||
 		  p.GetCurrentToken().GetText() == "append")}? .)+
 	;
 	
@@ -142,17 +246,43 @@ binary_operator
 	| GT 
 	| GE 
 	| EQ 
+	| IEQ
 	| NEQ 
 	| CONTAINS 
 	| ICONTAINS
 	| STARTSWITH
+	| ISTARTSWITH
 	| ENDSWITH
+	| IENDSWITH
+	| MATCHES
+	| IMATCHES
 	;
 
 unary_operator 
 	: EXISTS
 	;
 
+identifier
+	: ID
+	| EXCEPTIONS /* keywords usable as macro and list names, as in atom */
+	| FIELDS
+	| COMPS
+	| VALUES
+	| SEQUENCE
+	| KEY
+	| WINDOW
+	| STEPS
+	| WITHIN
+	| BY
+	| IEQ
+	| IIN
+	| ISTARTSWITH
+	| IENDSWITH
+	| MATCHES
+	| IMATCHES
+	| INCIDR
+	;
+
 AND 
 	: 'and'
 	;
@@ -185,6 +315,10 @@ EQ
 	: '='
 	;
 
+IEQ
+	: 'ieq'
+	;
+
 NEQ 
 	: '!='
 	;
@@ -193,6 +327,10 @@ IN
 	: 'in'
 	;
 
+IIN
+	: 'iin'
+	;
+
 CONTAINS 
 	: 'contains'
 	;
@@ -205,18 +343,54 @@ STARTSWITH
 	: 'startswith'
 	;
 
+ISTARTSWITH
+	: 'istartswith'
+	;
+
 ENDSWITH
 	: 'endswith'
 	;
+
+IENDSWITH
+	: 'iendswith'
+	;
+
+MATCHES
+	: 'matches'
+	;
+
+IMATCHES
+	: 'imatches'
+	;
 	
 PMATCH
 	: 'pmatch'
 	;
 
+INCIDR
+	: 'in_cidr'
+	;
+
 EXISTS 
 	: 'exists'
 	;
 
+PLUS
+	: '+'
+	;
+
+STAR
+	: '*'
+	;
+
+DIV
+	: '/'
+	;
+
+MOD
+	: '%'
+	;
+
 LBRACK 
 	: '['
 	;
@@ -277,7 +451,7 @@ NUMBER
 	;
 	
 PATH
-	:  ('a'..'z' | 'A'..'Z' | '/' | '.') ('a'..'z' | 'A'..'Z' | '0'..'9' | '_' | '-' | '.' | '/' | '*' )*	
+	:  ('a'..'z' | 'A'..'Z' | '0'..'9' | '/' | '.') ('a'..'z' | 'A'..'Z' | '0'..'9' | '_' | '-' | '.' | '/' | '*' )*	
 	;
 
 STRING 
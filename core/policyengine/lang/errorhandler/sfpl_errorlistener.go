@@ -18,6 +18,21 @@ func (s *SfplSyntaxError) Error() string {
 	return fmt.Sprintf("line: %d  column: %d %s", s.line, s.column, s.msg)
 }
 
+// Line returns the line of the syntax error
+func (s *SfplSyntaxError) Line() int {
+	return s.line
+}
+
+// Column returns the column of the syntax error
+func (s *SfplSyntaxError) Column() int {
+	return s.column
+}
+
+// Msg returns the message of the syntax error
+func (s *SfplSyntaxError) Msg() string {
+	return s.msg
+}
+
 // SfplErrorListener monitors errors during the policy parsing process
 // and stores them in an error list
 type SfplErrorListener struct {
@@ -33,3 +48,12 @@ func (l *SfplErrorListener) SyntaxError(recognizer antlr.Recognizer, offendingSy
 		msg:    msg,
 	})
 }
+
+// SemanticError is called by the policy compiler when it encounters an error in a parsed token
+func (l *SfplErrorListener) SemanticError(token antlr.Token, msg string) {
+	l.Errors = append(l.Errors, &SfplSyntaxError{
+		line:   token.GetLine(),
+		column: token.GetColumn(),
+		msg:    msg,
+	})
+}
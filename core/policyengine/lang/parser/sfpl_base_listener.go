@@ -98,6 +98,36 @@ func (s *BaseSfplListener) EnterTerm(ctx *TermContext) {}
 // ExitTerm is called when production term is exited.
 func (s *BaseSfplListener) ExitTerm(ctx *TermContext) {}
 
+// EnterAggregate is called when production aggregate is entered.
+func (s *BaseSfplListener) EnterAggregate(ctx *AggregateContext) {}
+
+// ExitAggregate is called when production aggregate is exited.
+func (s *BaseSfplListener) ExitAggregate(ctx *AggregateContext) {}
+
+// EnterArith_expression is called when production arith_expression is entered.
+func (s *BaseSfplListener) EnterArith_expression(ctx *Arith_expressionContext) {}
+
+// ExitArith_expression is called when production arith_expression is exited.
+func (s *BaseSfplListener) ExitArith_expression(ctx *Arith_expressionContext) {}
+
+// EnterArith_term is called when production arith_term is entered.
+func (s *BaseSfplListener) EnterArith_term(ctx *Arith_termContext) {}
+
+// ExitArith_term is called when production arith_term is exited.
+func (s *BaseSfplListener) ExitArith_term(ctx *Arith_termContext) {}
+
+// EnterArith_factor is called when production arith_factor is entered.
+func (s *BaseSfplListener) EnterArith_factor(ctx *Arith_factorContext) {}
+
+// ExitArith_factor is called when production arith_factor is exited.
+func (s *BaseSfplListener) ExitArith_factor(ctx *Arith_factorContext) {}
+
+// EnterFunction is called when production function is entered.
+func (s *BaseSfplListener) EnterFunction(ctx *FunctionContext) {}
+
+// ExitFunction is called when production function is exited.
+func (s *BaseSfplListener) ExitFunction(ctx *FunctionContext) {}
+
 // EnterItems is called when production items is entered.
 func (s *BaseSfplListener) EnterItems(ctx *ItemsContext) {}
 
@@ -146,6 +176,66 @@ func (s *BaseSfplListener) EnterFappend(ctx *FappendContext) {}
 // ExitFappend is called when production fappend is exited.
 func (s *BaseSfplListener) ExitFappend(ctx *FappendContext) {}
 
+// EnterExceptions is called when production exceptions is entered.
+func (s *BaseSfplListener) EnterExceptions(ctx *ExceptionsContext) {}
+
+// ExitExceptions is called when production exceptions is exited.
+func (s *BaseSfplListener) ExitExceptions(ctx *ExceptionsContext) {}
+
+// EnterException is called when production exception is entered.
+func (s *BaseSfplListener) EnterException(ctx *ExceptionContext) {}
+
+// ExitException is called when production exception is exited.
+func (s *BaseSfplListener) ExitException(ctx *ExceptionContext) {}
+
+// EnterFields is called when production fields is entered.
+func (s *BaseSfplListener) EnterFields(ctx *FieldsContext) {}
+
+// ExitFields is called when production fields is exited.
+func (s *BaseSfplListener) ExitFields(ctx *FieldsContext) {}
+
+// EnterComps is called when production comps is entered.
+func (s *BaseSfplListener) EnterComps(ctx *CompsContext) {}
+
+// ExitComps is called when production comps is exited.
+func (s *BaseSfplListener) ExitComps(ctx *CompsContext) {}
+
+// EnterComp is called when production comp is entered.
+func (s *BaseSfplListener) EnterComp(ctx *CompContext) {}
+
+// ExitComp is called when production comp is exited.
+func (s *BaseSfplListener) ExitComp(ctx *CompContext) {}
+
+// EnterValues is called when production values is entered.
+func (s *BaseSfplListener) EnterValues(ctx *ValuesContext) {}
+
+// ExitValues is called when production values is exited.
+func (s *BaseSfplListener) ExitValues(ctx *ValuesContext) {}
+
+// EnterValue is called when production value is entered.
+func (s *BaseSfplListener) EnterValue(ctx *ValueContext) {}
+
+// ExitValue is called when production value is exited.
+func (s *BaseSfplListener) ExitValue(ctx *ValueContext) {}
+
+// EnterTuple is called when production tuple is entered.
+func (s *BaseSfplListener) EnterTuple(ctx *TupleContext) {}
+
+// ExitTuple is called when production tuple is exited.
+func (s *BaseSfplListener) ExitTuple(ctx *TupleContext) {}
+
+// EnterSteps is called when production steps is entered.
+func (s *BaseSfplListener) EnterSteps(ctx *StepsContext) {}
+
+// ExitSteps is called when production steps is exited.
+func (s *BaseSfplListener) ExitSteps(ctx *StepsContext) {}
+
+// EnterStep is called when production step is entered.
+func (s *BaseSfplListener) EnterStep(ctx *StepContext) {}
+
+// ExitStep is called when production step is exited.
+func (s *BaseSfplListener) ExitStep(ctx *StepContext) {}
+
 // EnterVariable is called when production variable is entered.
 func (s *BaseSfplListener) EnterVariable(ctx *VariableContext) {}
 
@@ -175,3 +265,9 @@ func (s *BaseSfplListener) EnterUnary_operator(ctx *Unary_operatorContext) {}
 
 // ExitUnary_operator is called when production unary_operator is exited.
 func (s *BaseSfplListener) ExitUnary_operator(ctx *Unary_operatorContext) {}
+
+// EnterIdentifier is called when production identifier is entered.
+func (s *BaseSfplListener) EnterIdentifier(ctx *IdentifierContext) {}
+
+// ExitIdentifier is called when production identifier is exited.
+func (s *BaseSfplListener) ExitIdentifier(ctx *IdentifierContext) {}
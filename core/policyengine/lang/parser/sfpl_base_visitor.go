@@ -59,6 +59,26 @@ func (v *BaseSfplVisitor) VisitTerm(ctx *TermContext) interface{} {
 	return v.VisitChildren(ctx)
 }
 
+func (v *BaseSfplVisitor) VisitAggregate(ctx *AggregateContext) interface{} {
+	return v.VisitChildren(ctx)
+}
+
+func (v *BaseSfplVisitor) VisitArith_expression(ctx *Arith_expressionContext) interface{} {
+	return v.VisitChildren(ctx)
+}
+
+func (v *BaseSfplVisitor) VisitArith_term(ctx *Arith_termContext) interface{} {
+	return v.VisitChildren(ctx)
+}
+
+func (v *BaseSfplVisitor) VisitArith_factor(ctx *Arith_factorContext) interface{} {
+	return v.VisitChildren(ctx)
+}
+
+func (v *BaseSfplVisitor) VisitFunction(ctx *FunctionContext) interface{} {
+	return v.VisitChildren(ctx)
+}
+
 func (v *BaseSfplVisitor) VisitItems(ctx *ItemsContext) interface{} {
 	return v.VisitChildren(ctx)
 }
@@ -91,6 +111,46 @@ func (v *BaseSfplVisitor) VisitFappend(ctx *FappendContext) interface{} {
 	return v.VisitChildren(ctx)
 }
 
+func (v *BaseSfplVisitor) VisitExceptions(ctx *ExceptionsContext) interface{} {
+	return v.VisitChildren(ctx)
+}
+
+func (v *BaseSfplVisitor) VisitException(ctx *ExceptionContext) interface{} {
+	return v.VisitChildren(ctx)
+}
+
+func (v *BaseSfplVisitor) VisitFields(ctx *FieldsContext) interface{} {
+	return v.VisitChildren(ctx)
+}
+
+func (v *BaseSfplVisitor) VisitComps(ctx *CompsContext) interface{} {
+	return v.VisitChildren(ctx)
+}
+
+func (v *BaseSfplVisitor) VisitComp(ctx *CompContext) interface{} {
+	return v.VisitChildren(ctx)
+}
+
+func (v *BaseSfplVisitor) VisitValues(ctx *ValuesContext) interface{} {
+	return v.VisitChildren(ctx)
+}
+
+func (v *BaseSfplVisitor) VisitValue(ctx *ValueContext) interface{} {
+	return v.VisitChildren(ctx)
+}
+
+func (v *BaseSfplVisitor) VisitTuple(ctx *TupleContext) interface{} {
+	return v.VisitChildren(ctx)
+}
+
+func (v *BaseSfplVisitor) VisitSteps(ctx *StepsContext) interface{} {
+	return v.VisitChildren(ctx)
+}
+
+func (v *BaseSfplVisitor) VisitStep(ctx *StepContext) interface{} {
+	return v.VisitChildren(ctx)
+}
+
 func (v *BaseSfplVisitor) VisitVariable(ctx *VariableContext) interface{} {
 	return v.VisitChildren(ctx)
 }
@@ -110,3 +170,7 @@ func (v *BaseSfplVisitor) VisitBinary_operator(ctx *Binary_operatorContext) inte
 func (v *BaseSfplVisitor) VisitUnary_operator(ctx *Unary_operatorContext) interface{} {
 	return v.VisitChildren(ctx)
 }
+
+func (v *BaseSfplVisitor) VisitIdentifier(ctx *IdentifierContext) interface{} {
+	return v.VisitChildren(ctx)
+}
@@ -46,6 +46,21 @@ type SfplListener interface {
 	// EnterTerm is called when entering the term production.
 	EnterTerm(c *TermContext)
 
+	// EnterAggregate is called when entering the aggregate production.
+	EnterAggregate(c *AggregateContext)
+
+	// EnterArith_expression is called when entering the arith_expression production.
+	EnterArith_expression(c *Arith_expressionContext)
+
+	// EnterArith_term is called when entering the arith_term production.
+	EnterArith_term(c *Arith_termContext)
+
+	// EnterArith_factor is called when entering the arith_factor production.
+	EnterArith_factor(c *Arith_factorContext)
+
+	// EnterFunction is called when entering the function production.
+	EnterFunction(c *FunctionContext)
+
 	// EnterItems is called when entering the items production.
 	EnterItems(c *ItemsContext)
 
@@ -70,6 +85,36 @@ type SfplListener interface {
 	// EnterFappend is called when entering the fappend production.
 	EnterFappend(c *FappendContext)
 
+	// EnterExceptions is called when entering the exceptions production.
+	EnterExceptions(c *ExceptionsContext)
+
+	// EnterException is called when entering the exception production.
+	EnterException(c *ExceptionContext)
+
+	// EnterFields is called when entering the fields production.
+	EnterFields(c *FieldsContext)
+
+	// EnterComps is called when entering the comps production.
+	EnterComps(c *CompsContext)
+
+	// EnterComp is called when entering the comp production.
+	EnterComp(c *CompContext)
+
+	// EnterValues is called when entering the values production.
+	EnterValues(c *ValuesContext)
+
+	// EnterValue is called when entering the value production.
+	EnterValue(c *ValueContext)
+
+	// EnterTuple is called when entering the tuple production.
+	EnterTuple(c *TupleContext)
+
+	// EnterSteps is called when entering the steps production.
+	EnterSteps(c *StepsContext)
+
+	// EnterStep is called when entering the step production.
+	EnterStep(c *StepContext)
+
 	// EnterVariable is called when entering the variable production.
 	EnterVariable(c *VariableContext)
 
@@ -85,6 +130,9 @@ type SfplListener interface {
 	// EnterUnary_operator is called when entering the unary_operator production.
 	EnterUnary_operator(c *Unary_operatorContext)
 
+	// EnterIdentifier is called when entering the identifier production.
+	EnterIdentifier(c *IdentifierContext)
+
 	// ExitPolicy is called when exiting the policy production.
 	ExitPolicy(c *PolicyContext)
 
@@ -124,6 +172,21 @@ type SfplListener interface {
 	// ExitTerm is called when exiting the term production.
 	ExitTerm(c *TermContext)
 
+	// ExitAggregate is called when exiting the aggregate production.
+	ExitAggregate(c *AggregateContext)
+
+	// ExitArith_expression is called when exiting the arith_expression production.
+	ExitArith_expression(c *Arith_expressionContext)
+
+	// ExitArith_term is called when exiting the arith_term production.
+	ExitArith_term(c *Arith_termContext)
+
+	// ExitArith_factor is called when exiting the arith_factor production.
+	ExitArith_factor(c *Arith_factorContext)
+
+	// ExitFunction is called when exiting the function production.
+	ExitFunction(c *FunctionContext)
+
 	// ExitItems is called when exiting the items production.
 	ExitItems(c *ItemsContext)
 
@@ -148,6 +211,36 @@ type SfplListener interface {
 	// ExitFappend is called when exiting the fappend production.
 	ExitFappend(c *FappendContext)
 
+	// ExitExceptions is called when exiting the exceptions production.
+	ExitExceptions(c *ExceptionsContext)
+
+	// ExitException is called when exiting the exception production.
+	ExitException(c *ExceptionContext)
+
+	// ExitFields is called when exiting the fields production.
+	ExitFields(c *FieldsContext)
+
+	// ExitComps is called when exiting the comps production.
+	ExitComps(c *CompsContext)
+
+	// ExitComp is called when exiting the comp production.
+	ExitComp(c *CompContext)
+
+	// ExitValues is called when exiting the values production.
+	ExitValues(c *ValuesContext)
+
+	// ExitValue is called when exiting the value production.
+	ExitValue(c *ValueContext)
+
+	// ExitTuple is called when exiting the tuple production.
+	ExitTuple(c *TupleContext)
+
+	// ExitSteps is called when exiting the steps production.
+	ExitSteps(c *StepsContext)
+
+	// ExitStep is called when exiting the step production.
+	ExitStep(c *StepContext)
+
 	// ExitVariable is called when exiting the variable production.
 	ExitVariable(c *VariableContext)
 
@@ -162,4 +255,7 @@ type SfplListener interface {
 
 	// ExitUnary_operator is called when exiting the unary_operator production.
 	ExitUnary_operator(c *Unary_operatorContext)
+
+	// ExitIdentifier is called when exiting the identifier production.
+	ExitIdentifier(c *IdentifierContext)
 }
@@ -15,141 +15,273 @@ var _ = reflect.Copy
 var _ = strconv.Itoa
 
 var parserATN = []uint16{
-	3, 24715, 42794, 33075, 47597, 16764, 15335, 30598, 22884, 3, 55, 310,
+	3, 24715, 42794, 33075, 47597, 16764, 15335, 30598, 22884, 3, 76, 582,
 	4, 2, 9, 2, 4, 3, 9, 3, 4, 4, 9, 4, 4, 5, 9, 5, 4, 6, 9, 6, 4, 7, 9, 7,
 	4, 8, 9, 8, 4, 9, 9, 9, 4, 10, 9, 10, 4, 11, 9, 11, 4, 12, 9, 12, 4, 13,
 	9, 13, 4, 14, 9, 14, 4, 15, 9, 15, 4, 16, 9, 16, 4, 17, 9, 17, 4, 18, 9,
 	18, 4, 19, 9, 19, 4, 20, 9, 20, 4, 21, 9, 21, 4, 22, 9, 22, 4, 23, 9, 23,
-	4, 24, 9, 24, 4, 25, 9, 25, 4, 26, 9, 26, 4, 27, 9, 27, 3, 2, 3, 2, 3,
-	2, 3, 2, 3, 2, 6, 2, 60, 10, 2, 13, 2, 14, 2, 61, 3, 2, 3, 2, 3, 3, 3,
-	3, 3, 3, 3, 3, 3, 3, 7, 3, 71, 10, 3, 12, 3, 14, 3, 74, 11, 3, 3, 3, 3,
-	3, 3, 4, 3, 4, 3, 4, 3, 4, 3, 4, 3, 4, 3, 4, 3, 4, 3, 4, 3, 4, 3, 4, 3,
+	4, 24, 9, 24, 4, 25, 9, 25, 4, 26, 9, 26, 4, 27, 9, 27, 4, 28, 9, 28, 4,
+	29, 9, 29, 4, 30, 9, 30, 4, 31, 9, 31, 4, 32, 9, 32, 4, 33, 9, 33, 4, 34,
+	9, 34, 4, 35, 9, 35, 4, 36, 9, 36, 4, 37, 9, 37, 4, 38, 9, 38, 4, 39, 9,
+	39, 4, 40, 9, 40, 4, 41, 9, 41, 4, 42, 9, 42, 4, 43, 9, 43, 3, 2, 3, 2,
+	3, 2, 3, 2, 3, 2, 6, 2, 92, 10, 2, 13, 2, 14, 2, 93, 3, 2, 3, 2, 3, 3,
+	3, 3, 3, 3, 3, 3, 3, 3, 7, 3, 103, 10, 3, 12, 3, 14, 3, 106, 11, 3, 3,
+	3, 3, 3, 3, 4, 3, 4, 3, 4, 3, 4, 3, 4, 3, 4, 3, 4, 5, 4, 117, 10, 4, 3,
+	4, 3, 4, 3, 4, 5, 4, 122, 10, 4, 3, 4, 3, 4, 3, 4, 5, 4, 127, 10, 4, 3,
+	4, 5, 4, 130, 10, 4, 3, 4, 3, 4, 3, 4, 3, 4, 3, 4, 3, 4, 3, 4, 3, 4, 3,
 	4, 3, 4, 3, 4, 3, 4, 3, 4, 3, 4, 3, 4, 3, 4, 3, 4, 3, 4, 3, 4, 3, 4, 3,
-	4, 3, 4, 3, 4, 3, 4, 3, 4, 3, 4, 3, 4, 3, 4, 7, 4, 109, 10, 4, 12, 4, 14,
-	4, 112, 11, 4, 3, 5, 3, 5, 3, 5, 3, 5, 3, 5, 3, 5, 3, 5, 3, 5, 3, 5, 3,
-	5, 3, 5, 3, 5, 3, 5, 3, 5, 3, 5, 3, 5, 3, 5, 3, 5, 3, 5, 3, 5, 3, 5, 3,
-	5, 3, 5, 3, 5, 3, 5, 3, 5, 3, 5, 3, 5, 3, 5, 3, 5, 3, 5, 7, 5, 145, 10,
-	5, 12, 5, 14, 5, 148, 11, 5, 3, 6, 3, 6, 3, 6, 3, 6, 3, 6, 3, 6, 3, 6,
-	3, 6, 3, 6, 3, 6, 5, 6, 160, 10, 6, 3, 7, 3, 7, 3, 7, 3, 7, 3, 7, 3, 7,
-	3, 7, 3, 7, 3, 7, 3, 7, 5, 7, 172, 10, 7, 3, 8, 3, 8, 3, 8, 3, 8, 3, 8,
-	3, 8, 3, 8, 3, 8, 3, 8, 3, 8, 5, 8, 184, 10, 8, 3, 9, 3, 9, 3, 9, 3, 9,
-	3, 9, 3, 9, 3, 9, 3, 9, 3, 10, 3, 10, 3, 10, 3, 10, 3, 10, 3, 11, 3, 11,
-	3, 12, 3, 12, 3, 12, 7, 12, 204, 10, 12, 12, 12, 14, 12, 207, 11, 12, 3,
-	13, 3, 13, 3, 13, 7, 13, 212, 10, 13, 12, 13, 14, 13, 215, 11, 13, 3, 14,
-	3, 14, 3, 14, 3, 14, 3, 14, 3, 14, 3, 14, 3, 14, 3, 14, 3, 14, 3, 14, 3,
-	14, 3, 14, 3, 14, 3, 14, 5, 14, 232, 10, 14, 3, 14, 3, 14, 3, 14, 5, 14,
-	237, 10, 14, 7, 14, 239, 10, 14, 12, 14, 14, 14, 242, 11, 14, 3, 14, 3,
-	14, 3, 14, 3, 14, 3, 14, 3, 14, 5, 14, 250, 10, 14, 3, 15, 3, 15, 3, 15,
-	3, 15, 7, 15, 256, 10, 15, 12, 15, 14, 15, 259, 11, 15, 5, 15, 261, 10,
-	15, 3, 15, 5, 15, 264, 10, 15, 3, 15, 3, 15, 3, 16, 3, 16, 3, 16, 3, 16,
-	7, 16, 272, 10, 16, 12, 16, 14, 16, 275, 11, 16, 5, 16, 277, 10, 16, 3,
-	16, 5, 16, 280, 10, 16, 3, 16, 3, 16, 3, 17, 3, 17, 3, 18, 3, 18, 3, 19,
-	3, 19, 3, 20, 3, 20, 3, 21, 3, 21, 3, 22, 3, 22, 3, 23, 3, 23, 3, 24, 3,
-	24, 3, 25, 3, 25, 6, 25, 302, 10, 25, 13, 25, 14, 25, 303, 3, 26, 3, 26,
-	3, 27, 3, 27, 3, 27, 2, 2, 28, 2, 4, 6, 8, 10, 12, 14, 16, 18, 20, 22,
-	24, 26, 28, 30, 32, 34, 36, 38, 40, 42, 44, 46, 48, 50, 52, 2, 6, 3, 2,
-	11, 12, 4, 2, 30, 30, 35, 35, 5, 2, 24, 24, 26, 26, 47, 51, 4, 2, 24, 29,
-	31, 34, 2, 327, 2, 59, 3, 2, 2, 2, 4, 72, 3, 2, 2, 2, 6, 77, 3, 2, 2, 2,
-	8, 113, 3, 2, 2, 2, 10, 149, 3, 2, 2, 2, 12, 161, 3, 2, 2, 2, 14, 173,
-	3, 2, 2, 2, 16, 185, 3, 2, 2, 2, 18, 193, 3, 2, 2, 2, 20, 198, 3, 2, 2,
-	2, 22, 200, 3, 2, 2, 2, 24, 208, 3, 2, 2, 2, 26, 249, 3, 2, 2, 2, 28, 251,
-	3, 2, 2, 2, 30, 267, 3, 2, 2, 2, 32, 283, 3, 2, 2, 2, 34, 285, 3, 2, 2,
-	2, 36, 287, 3, 2, 2, 2, 38, 289, 3, 2, 2, 2, 40, 291, 3, 2, 2, 2, 42, 293,
-	3, 2, 2, 2, 44, 295, 3, 2, 2, 2, 46, 297, 3, 2, 2, 2, 48, 301, 3, 2, 2,
-	2, 50, 305, 3, 2, 2, 2, 52, 307, 3, 2, 2, 2, 54, 60, 5, 6, 4, 2, 55, 60,
-	5, 10, 6, 2, 56, 60, 5, 14, 8, 2, 57, 60, 5, 16, 9, 2, 58, 60, 5, 18, 10,
-	2, 59, 54, 3, 2, 2, 2, 59, 55, 3, 2, 2, 2, 59, 56, 3, 2, 2, 2, 59, 57,
-	3, 2, 2, 2, 59, 58, 3, 2, 2, 2, 60, 61, 3, 2, 2, 2, 61, 59, 3, 2, 2, 2,
-	61, 62, 3, 2, 2, 2, 62, 63, 3, 2, 2, 2, 63, 64, 7, 2, 2, 3, 64, 3, 3, 2,
-	2, 2, 65, 71, 5, 8, 5, 2, 66, 71, 5, 12, 7, 2, 67, 71, 5, 14, 8, 2, 68,
-	71, 5, 16, 9, 2, 69, 71, 5, 18, 10, 2, 70, 65, 3, 2, 2, 2, 70, 66, 3, 2,
-	2, 2, 70, 67, 3, 2, 2, 2, 70, 68, 3, 2, 2, 2, 70, 69, 3, 2, 2, 2, 71, 74,
-	3, 2, 2, 2, 72, 70, 3, 2, 2, 2, 72, 73, 3, 2, 2, 2, 73, 75, 3, 2, 2, 2,
-	74, 72, 3, 2, 2, 2, 75, 76, 7, 2, 2, 3, 76, 5, 3, 2, 2, 2, 77, 78, 7, 42,
-	2, 2, 78, 79, 7, 3, 2, 2, 79, 80, 7, 43, 2, 2, 80, 81, 5, 48, 25, 2, 81,
-	82, 7, 10, 2, 2, 82, 83, 7, 43, 2, 2, 83, 84, 5, 48, 25, 2, 84, 85, 7,
-	9, 2, 2, 85, 86, 7, 43, 2, 2, 86, 110, 5, 20, 11, 2, 87, 88, 9, 2, 2, 2,
-	88, 89, 7, 43, 2, 2, 89, 109, 5, 48, 25, 2, 90, 91, 7, 13, 2, 2, 91, 92,
-	7, 43, 2, 2, 92, 109, 5, 34, 18, 2, 93, 94, 7, 14, 2, 2, 94, 95, 7, 43,
-	2, 2, 95, 109, 5, 30, 16, 2, 96, 97, 7, 15, 2, 2, 97, 98, 7, 43, 2, 2,
-	98, 109, 5, 32, 17, 2, 99, 100, 7, 16, 2, 2, 100, 101, 7, 43, 2, 2, 101,
-	109, 5, 36, 19, 2, 102, 103, 7, 17, 2, 2, 103, 104, 7, 43, 2, 2, 104, 109,
-	5, 38, 20, 2, 105, 106, 7, 18, 2, 2, 106, 107, 7, 43, 2, 2, 107, 109, 5,
-	40, 21, 2, 108, 87, 3, 2, 2, 2, 108, 90, 3, 2, 2, 2, 108, 93, 3, 2, 2,
-	2, 108, 96, 3, 2, 2, 2, 108, 99, 3, 2, 2, 2, 108, 102, 3, 2, 2, 2, 108,
-	105, 3, 2, 2, 2, 109, 112, 3, 2, 2, 2, 110, 108, 3, 2, 2, 2, 110, 111,
-	3, 2, 2, 2, 111, 7, 3, 2, 2, 2, 112, 110, 3, 2, 2, 2, 113, 114, 7, 42,
-	2, 2, 114, 115, 7, 3, 2, 2, 115, 116, 7, 43, 2, 2, 116, 117, 5, 48, 25,
-	2, 117, 118, 7, 10, 2, 2, 118, 119, 7, 43, 2, 2, 119, 120, 5, 48, 25, 2,
-	120, 121, 7, 9, 2, 2, 121, 122, 7, 43, 2, 2, 122, 146, 5, 20, 11, 2, 123,
-	124, 9, 2, 2, 2, 124, 125, 7, 43, 2, 2, 125, 145, 5, 48, 25, 2, 126, 127,
-	7, 13, 2, 2, 127, 128, 7, 43, 2, 2, 128, 145, 5, 34, 18, 2, 129, 130, 7,
-	14, 2, 2, 130, 131, 7, 43, 2, 2, 131, 145, 5, 30, 16, 2, 132, 133, 7, 15,
-	2, 2, 133, 134, 7, 43, 2, 2, 134, 145, 5, 32, 17, 2, 135, 136, 7, 16, 2,
-	2, 136, 137, 7, 43, 2, 2, 137, 145, 5, 36, 19, 2, 138, 139, 7, 17, 2, 2,
-	139, 140, 7, 43, 2, 2, 140, 145, 5, 38, 20, 2, 141, 142, 7, 18, 2, 2, 142,
-	143, 7, 43, 2, 2, 143, 145, 5, 40, 21, 2, 144, 123, 3, 2, 2, 2, 144, 126,
-	3, 2, 2, 2, 144, 129, 3, 2, 2, 2, 144, 132, 3, 2, 2, 2, 144, 135, 3, 2,
-	2, 2, 144, 138, 3, 2, 2, 2, 144, 141, 3, 2, 2, 2, 145, 148, 3, 2, 2, 2,
-	146, 144, 3, 2, 2, 2, 146, 147, 3, 2, 2, 2, 147, 9, 3, 2, 2, 2, 148, 146,
-	3, 2, 2, 2, 149, 150, 7, 42, 2, 2, 150, 151, 7, 4, 2, 2, 151, 152, 7, 43,
-	2, 2, 152, 153, 7, 47, 2, 2, 153, 154, 7, 9, 2, 2, 154, 155, 7, 43, 2,
-	2, 155, 159, 5, 20, 11, 2, 156, 157, 7, 16, 2, 2, 157, 158, 7, 43, 2, 2,
-	158, 160, 5, 36, 19, 2, 159, 156, 3, 2, 2, 2, 159, 160, 3, 2, 2, 2, 160,
-	11, 3, 2, 2, 2, 161, 162, 7, 42, 2, 2, 162, 163, 7, 4, 2, 2, 163, 164,
-	7, 43, 2, 2, 164, 165, 7, 47, 2, 2, 165, 166, 7, 9, 2, 2, 166, 167, 7,
-	43, 2, 2, 167, 171, 5, 20, 11, 2, 168, 169, 7, 16, 2, 2, 169, 170, 7, 43,
-	2, 2, 170, 172, 5, 36, 19, 2, 171, 168, 3, 2, 2, 2, 171, 172, 3, 2, 2,
-	2, 172, 13, 3, 2, 2, 2, 173, 174, 7, 42, 2, 2, 174, 175, 7, 5, 2, 2, 175,
-	176, 7, 43, 2, 2, 176, 177, 7, 47, 2, 2, 177, 178, 7, 9, 2, 2, 178, 179,
-	7, 43, 2, 2, 179, 183, 5, 20, 11, 2, 180, 181, 7, 19, 2, 2, 181, 182, 7,
-	43, 2, 2, 182, 184, 5, 42, 22, 2, 183, 180, 3, 2, 2, 2, 183, 184, 3, 2,
-	2, 2, 184, 15, 3, 2, 2, 2, 185, 186, 7, 42, 2, 2, 186, 187, 7, 6, 2, 2,
-	187, 188, 7, 43, 2, 2, 188, 189, 7, 47, 2, 2, 189, 190, 7, 8, 2, 2, 190,
-	191, 7, 43, 2, 2, 191, 192, 5, 28, 15, 2, 192, 17, 3, 2, 2, 2, 193, 194,
-	7, 42, 2, 2, 194, 195, 7, 20, 2, 2, 195, 196, 7, 43, 2, 2, 196, 197, 5,
-	46, 24, 2, 197, 19, 3, 2, 2, 2, 198, 199, 5, 22, 12, 2, 199, 21, 3, 2,
-	2, 2, 200, 205, 5, 24, 13, 2, 201, 202, 7, 22, 2, 2, 202, 204, 5, 24, 13,
-	2, 203, 201, 3, 2, 2, 2, 204, 207, 3, 2, 2, 2, 205, 203, 3, 2, 2, 2, 205,
-	206, 3, 2, 2, 2, 206, 23, 3, 2, 2, 2, 207, 205, 3, 2, 2, 2, 208, 213, 5,
-	26, 14, 2, 209, 210, 7, 21, 2, 2, 210, 212, 5, 26, 14, 2, 211, 209, 3,
-	2, 2, 2, 212, 215, 3, 2, 2, 2, 213, 211, 3, 2, 2, 2, 213, 214, 3, 2, 2,
-	2, 214, 25, 3, 2, 2, 2, 215, 213, 3, 2, 2, 2, 216, 250, 5, 44, 23, 2, 217,
-	218, 7, 23, 2, 2, 218, 250, 5, 26, 14, 2, 219, 220, 5, 46, 24, 2, 220,
-	221, 5, 52, 27, 2, 221, 250, 3, 2, 2, 2, 222, 223, 5, 46, 24, 2, 223, 224,
-	5, 50, 26, 2, 224, 225, 5, 46, 24, 2, 225, 250, 3, 2, 2, 2, 226, 227, 5,
-	46, 24, 2, 227, 228, 9, 3, 2, 2, 228, 231, 7, 39, 2, 2, 229, 232, 5, 46,
-	24, 2, 230, 232, 5, 28, 15, 2, 231, 229, 3, 2, 2, 2, 231, 230, 3, 2, 2,
-	2, 232, 240, 3, 2, 2, 2, 233, 236, 7, 41, 2, 2, 234, 237, 5, 46, 24, 2,
-	235, 237, 5, 28, 15, 2, 236, 234, 3, 2, 2, 2, 236, 235, 3, 2, 2, 2, 237,
-	239, 3, 2, 2, 2, 238, 233, 3, 2, 2, 2, 239, 242, 3, 2, 2, 2, 240, 238,
-	3, 2, 2, 2, 240, 241, 3, 2, 2, 2, 241, 243, 3, 2, 2, 2, 242, 240, 3, 2,
-	2, 2, 243, 244, 7, 40, 2, 2, 244, 250, 3, 2, 2, 2, 245, 246, 7, 39, 2,
-	2, 246, 247, 5, 20, 11, 2, 247, 248, 7, 40, 2, 2, 248, 250, 3, 2, 2, 2,
-	249, 216, 3, 2, 2, 2, 249, 217, 3, 2, 2, 2, 249, 219, 3, 2, 2, 2, 249,
-	222, 3, 2, 2, 2, 249, 226, 3, 2, 2, 2, 249, 245, 3, 2, 2, 2, 250, 27, 3,
-	2, 2, 2, 251, 260, 7, 37, 2, 2, 252, 257, 5, 46, 24, 2, 253, 254, 7, 41,
-	2, 2, 254, 256, 5, 46, 24, 2, 255, 253, 3, 2, 2, 2, 256, 259, 3, 2, 2,
-	2, 257, 255, 3, 2, 2, 2, 257, 258, 3, 2, 2, 2, 258, 261, 3, 2, 2, 2, 259,
-	257, 3, 2, 2, 2, 260, 252, 3, 2, 2, 2, 260, 261, 3, 2, 2, 2, 261, 263,
-	3, 2, 2, 2, 262, 264, 7, 41, 2, 2, 263, 262, 3, 2, 2, 2, 263, 264, 3, 2,
-	2, 2, 264, 265, 3, 2, 2, 2, 265, 266, 7, 38, 2, 2, 266, 29, 3, 2, 2, 2,
-	267, 276, 7, 37, 2, 2, 268, 273, 5, 46, 24, 2, 269, 270, 7, 41, 2, 2, 270,
-	272, 5, 46, 24, 2, 271, 269, 3, 2, 2, 2, 272, 275, 3, 2, 2, 2, 273, 271,
-	3, 2, 2, 2, 273, 274, 3, 2, 2, 2, 274, 277, 3, 2, 2, 2, 275, 273, 3, 2,
-	2, 2, 276, 268, 3, 2, 2, 2, 276, 277, 3, 2, 2, 2, 277, 279, 3, 2, 2, 2,
-	278, 280, 7, 41, 2, 2, 279, 278, 3, 2, 2, 2, 279, 280, 3, 2, 2, 2, 280,
-	281, 3, 2, 2, 2, 281, 282, 7, 38, 2, 2, 282, 31, 3, 2, 2, 2, 283, 284,
-	5, 28, 15, 2, 284, 33, 3, 2, 2, 2, 285, 286, 7, 44, 2, 2, 286, 35, 3, 2,
-	2, 2, 287, 288, 5, 46, 24, 2, 288, 37, 3, 2, 2, 2, 289, 290, 5, 46, 24,
-	2, 290, 39, 3, 2, 2, 2, 291, 292, 5, 46, 24, 2, 292, 41, 3, 2, 2, 2, 293,
-	294, 5, 46, 24, 2, 294, 43, 3, 2, 2, 2, 295, 296, 7, 47, 2, 2, 296, 45,
-	3, 2, 2, 2, 297, 298, 9, 4, 2, 2, 298, 47, 3, 2, 2, 2, 299, 300, 6, 25,
-	2, 2, 300, 302, 11, 2, 2, 2, 301, 299, 3, 2, 2, 2, 302, 303, 3, 2, 2, 2,
-	303, 301, 3, 2, 2, 2, 303, 304, 3, 2, 2, 2, 304, 49, 3, 2, 2, 2, 305, 306,
-	9, 5, 2, 2, 306, 51, 3, 2, 2, 2, 307, 308, 7, 36, 2, 2, 308, 53, 3, 2,
-	2, 2, 26, 59, 61, 70, 72, 108, 110, 144, 146, 159, 171, 183, 205, 213,
-	231, 236, 240, 249, 257, 260, 263, 273, 276, 279, 303,
+	4, 3, 4, 3, 4, 3, 4, 3, 4, 3, 4, 3, 4, 3, 4, 3, 4, 3, 4, 3, 4, 3, 4, 3,
+	4, 3, 4, 3, 4, 3, 4, 7, 4, 168, 10, 4, 12, 4, 14, 4, 171, 11, 4, 3, 5,
+	3, 5, 3, 5, 3, 5, 3, 5, 3, 5, 3, 5, 5, 5, 180, 10, 5, 3, 5, 3, 5, 3, 5,
+	5, 5, 185, 10, 5, 3, 5, 3, 5, 3, 5, 5, 5, 190, 10, 5, 3, 5, 5, 5, 193,
+	10, 5, 3, 5, 3, 5, 3, 5, 3, 5, 3, 5, 3, 5, 3, 5, 3, 5, 3, 5, 3, 5, 3, 5,
+	3, 5, 3, 5, 3, 5, 3, 5, 3, 5, 3, 5, 3, 5, 3, 5, 3, 5, 3, 5, 3, 5, 3, 5,
+	3, 5, 3, 5, 3, 5, 3, 5, 3, 5, 3, 5, 3, 5, 3, 5, 3, 5, 3, 5, 3, 5, 3, 5,
+	3, 5, 7, 5, 231, 10, 5, 12, 5, 14, 5, 234, 11, 5, 3, 6, 3, 6, 3, 6, 3,
+	6, 3, 6, 3, 6, 3, 6, 3, 6, 3, 6, 3, 6, 5, 6, 246, 10, 6, 3, 7, 3, 7, 3,
+	7, 3, 7, 3, 7, 3, 7, 3, 7, 3, 7, 3, 7, 3, 7, 5, 7, 258, 10, 7, 3, 8, 3,
+	8, 3, 8, 3, 8, 3, 8, 3, 8, 3, 8, 5, 8, 267, 10, 8, 3, 8, 3, 8, 3, 8, 5,
+	8, 272, 10, 8, 3, 8, 3, 8, 3, 8, 3, 8, 5, 8, 278, 10, 8, 3, 9, 3, 9, 3,
+	9, 3, 9, 3, 9, 3, 9, 3, 9, 5, 9, 287, 10, 9, 3, 9, 3, 9, 3, 9, 3, 9, 3,
+	9, 3, 9, 5, 9, 295, 10, 9, 3, 10, 3, 10, 3, 10, 3, 10, 3, 10, 3, 11, 3,
+	11, 3, 12, 3, 12, 3, 12, 7, 12, 307, 10, 12, 12, 12, 14, 12, 310, 11, 12,
+	3, 13, 3, 13, 3, 13, 7, 13, 315, 10, 13, 12, 13, 14, 13, 318, 11, 13, 3,
+	14, 3, 14, 3, 14, 3, 14, 3, 14, 3, 14, 3, 14, 3, 14, 3, 14, 3, 14, 3, 14,
+	3, 14, 3, 14, 3, 14, 3, 14, 5, 14, 335, 10, 14, 3, 14, 3, 14, 3, 14, 5,
+	14, 340, 10, 14, 7, 14, 342, 10, 14, 12, 14, 14, 14, 345, 11, 14, 3, 14,
+	3, 14, 3, 14, 3, 14, 3, 14, 3, 14, 3, 14, 3, 14, 3, 14, 3, 14, 3, 14, 5,
+	14, 358, 10, 14, 3, 15, 3, 15, 3, 15, 5, 15, 363, 10, 15, 3, 15, 3, 15,
+	3, 15, 3, 15, 3, 15, 3, 15, 3, 15, 3, 15, 3, 15, 7, 15, 374, 10, 15, 12,
+	15, 14, 15, 377, 11, 15, 5, 15, 379, 10, 15, 3, 16, 3, 16, 3, 16, 7, 16,
+	384, 10, 16, 12, 16, 14, 16, 387, 11, 16, 3, 17, 3, 17, 3, 17, 7, 17, 392,
+	10, 17, 12, 17, 14, 17, 395, 11, 17, 3, 18, 3, 18, 3, 18, 3, 18, 3, 18,
+	3, 18, 5, 18, 403, 10, 18, 3, 19, 3, 19, 3, 19, 3, 19, 3, 19, 7, 19, 410,
+	10, 19, 12, 19, 14, 19, 413, 11, 19, 5, 19, 415, 10, 19, 3, 19, 3, 19,
+	3, 20, 3, 20, 3, 20, 3, 20, 7, 20, 423, 10, 20, 12, 20, 14, 20, 426, 11,
+	20, 5, 20, 428, 10, 20, 3, 20, 5, 20, 431, 10, 20, 3, 20, 3, 20, 3, 21,
+	3, 21, 3, 21, 3, 21, 7, 21, 439, 10, 21, 12, 21, 14, 21, 442, 11, 21, 5,
+	21, 444, 10, 21, 3, 21, 5, 21, 447, 10, 21, 3, 21, 3, 21, 3, 22, 3, 22,
+	3, 23, 3, 23, 3, 24, 3, 24, 3, 25, 3, 25, 3, 26, 3, 26, 3, 27, 3, 27, 3,
+	28, 6, 28, 464, 10, 28, 13, 28, 14, 28, 465, 3, 29, 3, 29, 3, 29, 3, 29,
+	3, 29, 3, 29, 3, 29, 3, 29, 3, 29, 3, 29, 3, 29, 3, 29, 3, 29, 7, 29, 481,
+	10, 29, 12, 29, 14, 29, 484, 11, 29, 3, 30, 3, 30, 5, 30, 488, 10, 30,
+	3, 31, 3, 31, 3, 31, 3, 31, 7, 31, 494, 10, 31, 12, 31, 14, 31, 497, 11,
+	31, 3, 31, 3, 31, 3, 31, 5, 31, 502, 10, 31, 3, 32, 3, 32, 3, 32, 3, 32,
+	3, 32, 5, 32, 509, 10, 32, 3, 33, 3, 33, 3, 33, 3, 33, 7, 33, 515, 10,
+	33, 12, 33, 14, 33, 518, 11, 33, 5, 33, 520, 10, 33, 3, 33, 3, 33, 3, 33,
+	6, 33, 525, 10, 33, 13, 33, 14, 33, 526, 5, 33, 529, 10, 33, 3, 34, 3,
+	34, 5, 34, 533, 10, 34, 3, 35, 3, 35, 3, 35, 5, 35, 538, 10, 35, 3, 35,
+	3, 35, 3, 35, 5, 35, 543, 10, 35, 7, 35, 545, 10, 35, 12, 35, 14, 35, 548,
+	11, 35, 3, 35, 3, 35, 3, 36, 6, 36, 553, 10, 36, 13, 36, 14, 36, 554, 3,
+	37, 3, 37, 3, 37, 3, 37, 3, 37, 3, 37, 3, 37, 5, 37, 564, 10, 37, 3, 38,
+	3, 38, 3, 39, 3, 39, 3, 40, 3, 40, 6, 40, 572, 10, 40, 13, 40, 14, 40,
+	573, 3, 41, 3, 41, 3, 42, 3, 42, 3, 43, 3, 43, 3, 43, 2, 2, 44, 2, 4, 6,
+	8, 10, 12, 14, 16, 18, 20, 22, 24, 26, 28, 30, 32, 34, 36, 38, 40, 42,
+	44, 46, 48, 50, 52, 54, 56, 58, 60, 62, 64, 66, 68, 70, 72, 74, 76, 78,
+	80, 82, 84, 2, 11, 4, 2, 3, 3, 25, 25, 3, 2, 31, 32, 3, 2, 11, 12, 4, 2,
+	41, 42, 51, 52, 4, 2, 54, 54, 63, 63, 3, 2, 55, 57, 12, 2, 21, 30, 34,
+	34, 36, 36, 39, 39, 42, 42, 46, 46, 48, 50, 52, 52, 56, 56, 68, 72, 4,
+	2, 34, 40, 43, 50, 9, 2, 21, 30, 39, 39, 42, 42, 46, 46, 48, 50, 52, 52,
+	68, 68, 2, 637, 2, 91, 3, 2, 2, 2, 4, 104, 3, 2, 2, 2, 6, 109, 3, 2, 2,
+	2, 8, 172, 3, 2, 2, 2, 10, 235, 3, 2, 2, 2, 12, 247, 3, 2, 2, 2, 14, 259,
+	3, 2, 2, 2, 16, 279, 3, 2, 2, 2, 18, 296, 3, 2, 2, 2, 20, 301, 3, 2, 2,
+	2, 22, 303, 3, 2, 2, 2, 24, 311, 3, 2, 2, 2, 26, 357, 3, 2, 2, 2, 28, 359,
+	3, 2, 2, 2, 30, 380, 3, 2, 2, 2, 32, 388, 3, 2, 2, 2, 34, 402, 3, 2, 2,
+	2, 36, 404, 3, 2, 2, 2, 38, 418, 3, 2, 2, 2, 40, 434, 3, 2, 2, 2, 42, 450,
+	3, 2, 2, 2, 44, 452, 3, 2, 2, 2, 46, 454, 3, 2, 2, 2, 48, 456, 3, 2, 2,
+	2, 50, 458, 3, 2, 2, 2, 52, 460, 3, 2, 2, 2, 54, 463, 3, 2, 2, 2, 56, 467,
+	3, 2, 2, 2, 58, 487, 3, 2, 2, 2, 60, 501, 3, 2, 2, 2, 62, 508, 3, 2, 2,
+	2, 64, 528, 3, 2, 2, 2, 66, 532, 3, 2, 2, 2, 68, 534, 3, 2, 2, 2, 70, 552,
+	3, 2, 2, 2, 72, 556, 3, 2, 2, 2, 74, 565, 3, 2, 2, 2, 76, 567, 3, 2, 2,
+	2, 78, 571, 3, 2, 2, 2, 80, 575, 3, 2, 2, 2, 82, 577, 3, 2, 2, 2, 84, 579,
+	3, 2, 2, 2, 86, 92, 5, 6, 4, 2, 87, 92, 5, 10, 6, 2, 88, 92, 5, 14, 8,
+	2, 89, 92, 5, 16, 9, 2, 90, 92, 5, 18, 10, 2, 91, 86, 3, 2, 2, 2, 91, 87,
+	3, 2, 2, 2, 91, 88, 3, 2, 2, 2, 91, 89, 3, 2, 2, 2, 91, 90, 3, 2, 2, 2,
+	92, 93, 3, 2, 2, 2, 93, 91, 3, 2, 2, 2, 93, 94, 3, 2, 2, 2, 94, 95, 3,
+	2, 2, 2, 95, 96, 7, 2, 2, 3, 96, 3, 3, 2, 2, 2, 97, 103, 5, 8, 5, 2, 98,
+	103, 5, 12, 7, 2, 99, 103, 5, 14, 8, 2, 100, 103, 5, 16, 9, 2, 101, 103,
+	5, 18, 10, 2, 102, 97, 3, 2, 2, 2, 102, 98, 3, 2, 2, 2, 102, 99, 3, 2,
+	2, 2, 102, 100, 3, 2, 2, 2, 102, 101, 3, 2, 2, 2, 103, 106, 3, 2, 2, 2,
+	104, 102, 3, 2, 2, 2, 104, 105, 3, 2, 2, 2, 105, 107, 3, 2, 2, 2, 106,
+	104, 3, 2, 2, 2, 107, 108, 7, 2, 2, 3, 108, 5, 3, 2, 2, 2, 109, 110, 7,
+	63, 2, 2, 110, 111, 9, 2, 2, 2, 111, 112, 7, 64, 2, 2, 112, 116, 5, 78,
+	40, 2, 113, 114, 7, 10, 2, 2, 114, 115, 7, 64, 2, 2, 115, 117, 5, 78, 40,
+	2, 116, 113, 3, 2, 2, 2, 116, 117, 3, 2, 2, 2, 117, 121, 3, 2, 2, 2, 118,
+	119, 7, 19, 2, 2, 119, 120, 7, 64, 2, 2, 120, 122, 5, 52, 27, 2, 121, 118,
+	3, 2, 2, 2, 121, 122, 3, 2, 2, 2, 122, 129, 3, 2, 2, 2, 123, 124, 7, 9,
+	2, 2, 124, 126, 7, 64, 2, 2, 125, 127, 9, 3, 2, 2, 126, 125, 3, 2, 2, 2,
+	126, 127, 3, 2, 2, 2, 127, 128, 3, 2, 2, 2, 128, 130, 5, 20, 11, 2, 129,
+	123, 3, 2, 2, 2, 129, 130, 3, 2, 2, 2, 130, 169, 3, 2, 2, 2, 131, 132,
+	9, 4, 2, 2, 132, 133, 7, 64, 2, 2, 133, 168, 5, 78, 40, 2, 134, 135, 7,
+	13, 2, 2, 135, 136, 7, 64, 2, 2, 136, 168, 5, 44, 23, 2, 137, 138, 7, 14,
+	2, 2, 138, 139, 7, 64, 2, 2, 139, 168, 5, 40, 21, 2, 140, 141, 7, 15, 2,
+	2, 141, 142, 7, 64, 2, 2, 142, 168, 5, 42, 22, 2, 143, 144, 7, 16, 2, 2,
+	144, 145, 7, 64, 2, 2, 145, 168, 5, 46, 24, 2, 146, 147, 7, 17, 2, 2, 147,
+	148, 7, 64, 2, 2, 148, 168, 5, 48, 25, 2, 149, 150, 7, 18, 2, 2, 150, 151,
+	7, 64, 2, 2, 151, 168, 5, 50, 26, 2, 152, 153, 7, 21, 2, 2, 153, 154, 7,
+	64, 2, 2, 154, 168, 5, 54, 28, 2, 155, 156, 7, 26, 2, 2, 156, 157, 7, 64,
+	2, 2, 157, 168, 5, 58, 30, 2, 158, 159, 7, 27, 2, 2, 159, 160, 7, 64, 2,
+	2, 160, 168, 5, 76, 39, 2, 161, 162, 7, 28, 2, 2, 162, 163, 7, 64, 2, 2,
+	163, 168, 5, 70, 36, 2, 164, 165, 7, 19, 2, 2, 165, 166, 7, 64, 2, 2, 166,
+	168, 5, 52, 27, 2, 167, 131, 3, 2, 2, 2, 167, 134, 3, 2, 2, 2, 167, 137,
+	3, 2, 2, 2, 167, 140, 3, 2, 2, 2, 167, 143, 3, 2, 2, 2, 167, 146, 3, 2,
+	2, 2, 167, 149, 3, 2, 2, 2, 167, 152, 3, 2, 2, 2, 167, 155, 3, 2, 2, 2,
+	167, 158, 3, 2, 2, 2, 167, 161, 3, 2, 2, 2, 167, 164, 3, 2, 2, 2, 168,
+	171, 3, 2, 2, 2, 169, 167, 3, 2, 2, 2, 169, 170, 3, 2, 2, 2, 170, 7, 3,
+	2, 2, 2, 171, 169, 3, 2, 2, 2, 172, 173, 7, 63, 2, 2, 173, 174, 9, 2, 2,
+	2, 174, 175, 7, 64, 2, 2, 175, 179, 5, 78, 40, 2, 176, 177, 7, 10, 2, 2,
+	177, 178, 7, 64, 2, 2, 178, 180, 5, 78, 40, 2, 179, 176, 3, 2, 2, 2, 179,
+	180, 3, 2, 2, 2, 180, 184, 3, 2, 2, 2, 181, 182, 7, 19, 2, 2, 182, 183,
+	7, 64, 2, 2, 183, 185, 5, 52, 27, 2, 184, 181, 3, 2, 2, 2, 184, 185, 3,
+	2, 2, 2, 185, 192, 3, 2, 2, 2, 186, 187, 7, 9, 2, 2, 187, 189, 7, 64, 2,
+	2, 188, 190, 9, 3, 2, 2, 189, 188, 3, 2, 2, 2, 189, 190, 3, 2, 2, 2, 190,
+	191, 3, 2, 2, 2, 191, 193, 5, 20, 11, 2, 192, 186, 3, 2, 2, 2, 192, 193,
+	3, 2, 2, 2, 193, 232, 3, 2, 2, 2, 194, 195, 9, 4, 2, 2, 195, 196, 7, 64,
+	2, 2, 196, 231, 5, 78, 40, 2, 197, 198, 7, 13, 2, 2, 198, 199, 7, 64, 2,
+	2, 199, 231, 5, 44, 23, 2, 200, 201, 7, 14, 2, 2, 201, 202, 7, 64, 2, 2,
+	202, 231, 5, 40, 21, 2, 203, 204, 7, 15, 2, 2, 204, 205, 7, 64, 2, 2, 205,
+	231, 5, 42, 22, 2, 206, 207, 7, 16, 2, 2, 207, 208, 7, 64, 2, 2, 208, 231,
+	5, 46, 24, 2, 209, 210, 7, 17, 2, 2, 210, 211, 7, 64, 2, 2, 211, 231, 5,
+	48, 25, 2, 212, 213, 7, 18, 2, 2, 213, 214, 7, 64, 2, 2, 214, 231, 5, 50,
+	26, 2, 215, 216, 7, 21, 2, 2, 216, 217, 7, 64, 2, 2, 217, 231, 5, 54, 28,
+	2, 218, 219, 7, 26, 2, 2, 219, 220, 7, 64, 2, 2, 220, 231, 5, 58, 30, 2,
+	221, 222, 7, 27, 2, 2, 222, 223, 7, 64, 2, 2, 223, 231, 5, 76, 39, 2, 224,
+	225, 7, 28, 2, 2, 225, 226, 7, 64, 2, 2, 226, 231, 5, 70, 36, 2, 227, 228,
+	7, 19, 2, 2, 228, 229, 7, 64, 2, 2, 229, 231, 5, 52, 27, 2, 230, 194, 3,
+	2, 2, 2, 230, 197, 3, 2, 2, 2, 230, 200, 3, 2, 2, 2, 230, 203, 3, 2, 2,
+	2, 230, 206, 3, 2, 2, 2, 230, 209, 3, 2, 2, 2, 230, 212, 3, 2, 2, 2, 230,
+	215, 3, 2, 2, 2, 230, 218, 3, 2, 2, 2, 230, 221, 3, 2, 2, 2, 230, 224,
+	3, 2, 2, 2, 230, 227, 3, 2, 2, 2, 231, 234, 3, 2, 2, 2, 232, 230, 3, 2,
+	2, 2, 232, 233, 3, 2, 2, 2, 233, 9, 3, 2, 2, 2, 234, 232, 3, 2, 2, 2, 235,
+	236, 7, 63, 2, 2, 236, 237, 7, 4, 2, 2, 237, 238, 7, 64, 2, 2, 238, 239,
+	7, 68, 2, 2, 239, 240, 7, 9, 2, 2, 240, 241, 7, 64, 2, 2, 241, 245, 5,
+	20, 11, 2, 242, 243, 7, 16, 2, 2, 243, 244, 7, 64, 2, 2, 244, 246, 5, 46,
+	24, 2, 245, 242, 3, 2, 2, 2, 245, 246, 3, 2, 2, 2, 246, 11, 3, 2, 2, 2,
+	247, 248, 7, 63, 2, 2, 248, 249, 7, 4, 2, 2, 249, 250, 7, 64, 2, 2, 250,
+	251, 7, 68, 2, 2, 251, 252, 7, 9, 2, 2, 252, 253, 7, 64, 2, 2, 253, 257,
+	5, 20, 11, 2, 254, 255, 7, 16, 2, 2, 255, 256, 7, 64, 2, 2, 256, 258, 5,
+	46, 24, 2, 257, 254, 3, 2, 2, 2, 257, 258, 3, 2, 2, 2, 258, 13, 3, 2, 2,
+	2, 259, 260, 7, 63, 2, 2, 260, 261, 7, 5, 2, 2, 261, 262, 7, 64, 2, 2,
+	262, 266, 5, 84, 43, 2, 263, 264, 7, 19, 2, 2, 264, 265, 7, 64, 2, 2, 265,
+	267, 5, 52, 27, 2, 266, 263, 3, 2, 2, 2, 266, 267, 3, 2, 2, 2, 267, 268,
+	3, 2, 2, 2, 268, 269, 7, 9, 2, 2, 269, 271, 7, 64, 2, 2, 270, 272, 9, 3,
+	2, 2, 271, 270, 3, 2, 2, 2, 271, 272, 3, 2, 2, 2, 272, 273, 3, 2, 2, 2,
+	273, 277, 5, 20, 11, 2, 274, 275, 7, 19, 2, 2, 275, 276, 7, 64, 2, 2, 276,
+	278, 5, 52, 27, 2, 277, 274, 3, 2, 2, 2, 277, 278, 3, 2, 2, 2, 278, 15,
+	3, 2, 2, 2, 279, 280, 7, 63, 2, 2, 280, 281, 7, 6, 2, 2, 281, 282, 7, 64,
+	2, 2, 282, 286, 5, 84, 43, 2, 283, 284, 7, 19, 2, 2, 284, 285, 7, 64, 2,
+	2, 285, 287, 5, 52, 27, 2, 286, 283, 3, 2, 2, 2, 286, 287, 3, 2, 2, 2,
+	287, 288, 3, 2, 2, 2, 288, 289, 7, 8, 2, 2, 289, 290, 7, 64, 2, 2, 290,
+	294, 5, 38, 20, 2, 291, 292, 7, 19, 2, 2, 292, 293, 7, 64, 2, 2, 293, 295,
+	5, 52, 27, 2, 294, 291, 3, 2, 2, 2, 294, 295, 3, 2, 2, 2, 295, 17, 3, 2,
+	2, 2, 296, 297, 7, 63, 2, 2, 297, 298, 7, 20, 2, 2, 298, 299, 7, 64, 2,
+	2, 299, 300, 5, 76, 39, 2, 300, 19, 3, 2, 2, 2, 301, 302, 5, 22, 12, 2,
+	302, 21, 3, 2, 2, 2, 303, 308, 5, 24, 13, 2, 304, 305, 7, 32, 2, 2, 305,
+	307, 5, 24, 13, 2, 306, 304, 3, 2, 2, 2, 307, 310, 3, 2, 2, 2, 308, 306,
+	3, 2, 2, 2, 308, 309, 3, 2, 2, 2, 309, 23, 3, 2, 2, 2, 310, 308, 3, 2,
+	2, 2, 311, 316, 5, 26, 14, 2, 312, 313, 7, 31, 2, 2, 313, 315, 5, 26, 14,
+	2, 314, 312, 3, 2, 2, 2, 315, 318, 3, 2, 2, 2, 316, 314, 3, 2, 2, 2, 316,
+	317, 3, 2, 2, 2, 317, 25, 3, 2, 2, 2, 318, 316, 3, 2, 2, 2, 319, 358, 5,
+	74, 38, 2, 320, 321, 7, 33, 2, 2, 321, 358, 5, 26, 14, 2, 322, 323, 5,
+	76, 39, 2, 323, 324, 5, 82, 42, 2, 324, 358, 3, 2, 2, 2, 325, 326, 5, 76,
+	39, 2, 326, 327, 5, 80, 41, 2, 327, 328, 5, 76, 39, 2, 328, 358, 3, 2,
+	2, 2, 329, 330, 5, 76, 39, 2, 330, 331, 9, 5, 2, 2, 331, 334, 7, 60, 2,
+	2, 332, 335, 5, 76, 39, 2, 333, 335, 5, 38, 20, 2, 334, 332, 3, 2, 2, 2,
+	334, 333, 3, 2, 2, 2, 335, 343, 3, 2, 2, 2, 336, 339, 7, 62, 2, 2, 337,
+	340, 5, 76, 39, 2, 338, 340, 5, 38, 20, 2, 339, 337, 3, 2, 2, 2, 339, 338,
+	3, 2, 2, 2, 340, 342, 3, 2, 2, 2, 341, 336, 3, 2, 2, 2, 342, 345, 3, 2,
+	2, 2, 343, 341, 3, 2, 2, 2, 343, 344, 3, 2, 2, 2, 344, 346, 3, 2, 2, 2,
+	345, 343, 3, 2, 2, 2, 346, 347, 7, 61, 2, 2, 347, 358, 3, 2, 2, 2, 348,
+	349, 7, 60, 2, 2, 349, 350, 5, 20, 11, 2, 350, 351, 7, 61, 2, 2, 351, 358,
+	3, 2, 2, 2, 352, 358, 5, 28, 15, 2, 353, 354, 5, 30, 16, 2, 354, 355, 5,
+	80, 41, 2, 355, 356, 5, 30, 16, 2, 356, 358, 3, 2, 2, 2, 357, 319, 3, 2,
+	2, 2, 357, 320, 3, 2, 2, 2, 357, 322, 3, 2, 2, 2, 357, 325, 3, 2, 2, 2,
+	357, 329, 3, 2, 2, 2, 357, 348, 3, 2, 2, 2, 357, 352, 3, 2, 2, 2, 357,
+	353, 3, 2, 2, 2, 358, 27, 3, 2, 2, 2, 359, 360, 7, 68, 2, 2, 360, 362,
+	7, 60, 2, 2, 361, 363, 5, 76, 39, 2, 362, 361, 3, 2, 2, 2, 362, 363, 3,
+	2, 2, 2, 363, 364, 3, 2, 2, 2, 364, 365, 7, 61, 2, 2, 365, 366, 5, 80,
+	41, 2, 366, 367, 5, 76, 39, 2, 367, 368, 7, 29, 2, 2, 368, 378, 5, 76,
+	39, 2, 369, 370, 7, 30, 2, 2, 370, 375, 5, 76, 39, 2, 371, 372, 7, 62,
+	2, 2, 372, 374, 5, 76, 39, 2, 373, 371, 3, 2, 2, 2, 374, 377, 3, 2, 2,
+	2, 375, 373, 3, 2, 2, 2, 375, 376, 3, 2, 2, 2, 376, 379, 3, 2, 2, 2, 377,
+	375, 3, 2, 2, 2, 378, 369, 3, 2, 2, 2, 378, 379, 3, 2, 2, 2, 379, 29, 3,
+	2, 2, 2, 380, 385, 5, 32, 17, 2, 381, 382, 9, 6, 2, 2, 382, 384, 5, 32,
+	17, 2, 383, 381, 3, 2, 2, 2, 384, 387, 3, 2, 2, 2, 385, 383, 3, 2, 2, 2,
+	385, 386, 3, 2, 2, 2, 386, 31, 3, 2, 2, 2, 387, 385, 3, 2, 2, 2, 388, 393,
+	5, 34, 18, 2, 389, 390, 9, 7, 2, 2, 390, 392, 5, 34, 18, 2, 391, 389, 3,
+	2, 2, 2, 392, 395, 3, 2, 2, 2, 393, 391, 3, 2, 2, 2, 393, 394, 3, 2, 2,
+	2, 394, 33, 3, 2, 2, 2, 395, 393, 3, 2, 2, 2, 396, 403, 5, 36, 19, 2, 397,
+	398, 7, 60, 2, 2, 398, 399, 5, 30, 16, 2, 399, 400, 7, 61, 2, 2, 400, 403,
+	3, 2, 2, 2, 401, 403, 5, 76, 39, 2, 402, 396, 3, 2, 2, 2, 402, 397, 3,
+	2, 2, 2, 402, 401, 3, 2, 2, 2, 403, 35, 3, 2, 2, 2, 404, 405, 7, 68, 2,
+	2, 405, 414, 7, 60, 2, 2, 406, 411, 5, 30, 16, 2, 407, 408, 7, 62, 2, 2,
+	408, 410, 5, 30, 16, 2, 409, 407, 3, 2, 2, 2, 410, 413, 3, 2, 2, 2, 411,
+	409, 3, 2, 2, 2, 411, 412, 3, 2, 2, 2, 412, 415, 3, 2, 2, 2, 413, 411,
+	3, 2, 2, 2, 414, 406, 3, 2, 2, 2, 414, 415, 3, 2, 2, 2, 415, 416, 3, 2,
+	2, 2, 416, 417, 7, 61, 2, 2, 417, 37, 3, 2, 2, 2, 418, 427, 7, 58, 2, 2,
+	419, 424, 5, 76, 39, 2, 420, 421, 7, 62, 2, 2, 421, 423, 5, 76, 39, 2,
+	422, 420, 3, 2, 2, 2, 423, 426, 3, 2, 2, 2, 424, 422, 3, 2, 2, 2, 424,
+	425, 3, 2, 2, 2, 425, 428, 3, 2, 2, 2, 426, 424, 3, 2, 2, 2, 427, 419,
+	3, 2, 2, 2, 427, 428, 3, 2, 2, 2, 428, 430, 3, 2, 2, 2, 429, 431, 7, 62,
+	2, 2, 430, 429, 3, 2, 2, 2, 430, 431, 3, 2, 2, 2, 431, 432, 3, 2, 2, 2,
+	432, 433, 7, 59, 2, 2, 433, 39, 3, 2, 2, 2, 434, 443, 7, 58, 2, 2, 435,
+	440, 5, 76, 39, 2, 436, 437, 7, 62, 2, 2, 437, 439, 5, 76, 39, 2, 438,
+	436, 3, 2, 2, 2, 439, 442, 3, 2, 2, 2, 440, 438, 3, 2, 2, 2, 440, 441,
+	3, 2, 2, 2, 441, 444, 3, 2, 2, 2, 442, 440, 3, 2, 2, 2, 443, 435, 3, 2,
+	2, 2, 443, 444, 3, 2, 2, 2, 444, 446, 3, 2, 2, 2, 445, 447, 7, 62, 2, 2,
+	446, 445, 3, 2, 2, 2, 446, 447, 3, 2, 2, 2, 447, 448, 3, 2, 2, 2, 448,
+	449, 7, 59, 2, 2, 449, 41, 3, 2, 2, 2, 450, 451, 5, 38, 20, 2, 451, 43,
+	3, 2, 2, 2, 452, 453, 7, 65, 2, 2, 453, 45, 3, 2, 2, 2, 454, 455, 5, 76,
+	39, 2, 455, 47, 3, 2, 2, 2, 456, 457, 5, 76, 39, 2, 457, 49, 3, 2, 2, 2,
+	458, 459, 5, 76, 39, 2, 459, 51, 3, 2, 2, 2, 460, 461, 5, 76, 39, 2, 461,
+	53, 3, 2, 2, 2, 462, 464, 5, 56, 29, 2, 463, 462, 3, 2, 2, 2, 464, 465,
+	3, 2, 2, 2, 465, 463, 3, 2, 2, 2, 465, 466, 3, 2, 2, 2, 466, 55, 3, 2,
+	2, 2, 467, 468, 7, 63, 2, 2, 468, 469, 7, 7, 2, 2, 469, 470, 7, 64, 2,
+	2, 470, 482, 7, 68, 2, 2, 471, 472, 7, 22, 2, 2, 472, 473, 7, 64, 2, 2,
+	473, 481, 5, 58, 30, 2, 474, 475, 7, 23, 2, 2, 475, 476, 7, 64, 2, 2, 476,
+	481, 5, 60, 31, 2, 477, 478, 7, 24, 2, 2, 478, 479, 7, 64, 2, 2, 479, 481,
+	5, 64, 33, 2, 480, 471, 3, 2, 2, 2, 480, 474, 3, 2, 2, 2, 480, 477, 3,
+	2, 2, 2, 481, 484, 3, 2, 2, 2, 482, 480, 3, 2, 2, 2, 482, 483, 3, 2, 2,
+	2, 483, 57, 3, 2, 2, 2, 484, 482, 3, 2, 2, 2, 485, 488, 5, 38, 20, 2, 486,
+	488, 5, 76, 39, 2, 487, 485, 3, 2, 2, 2, 487, 486, 3, 2, 2, 2, 488, 59,
+	3, 2, 2, 2, 489, 490, 7, 58, 2, 2, 490, 495, 5, 62, 32, 2, 491, 492, 7,
+	62, 2, 2, 492, 494, 5, 62, 32, 2, 493, 491, 3, 2, 2, 2, 494, 497, 3, 2,
+	2, 2, 495, 493, 3, 2, 2, 2, 495, 496, 3, 2, 2, 2, 496, 498, 3, 2, 2, 2,
+	497, 495, 3, 2, 2, 2, 498, 499, 7, 59, 2, 2, 499, 502, 3, 2, 2, 2, 500,
+	502, 5, 62, 32, 2, 501, 489, 3, 2, 2, 2, 501, 500, 3, 2, 2, 2, 502, 61,
+	3, 2, 2, 2, 503, 509, 5, 80, 41, 2, 504, 509, 7, 41, 2, 2, 505, 509, 7,
+	42, 2, 2, 506, 509, 7, 51, 2, 2, 507, 509, 7, 52, 2, 2, 508, 503, 3, 2,
+	2, 2, 508, 504, 3, 2, 2, 2, 508, 505, 3, 2, 2, 2, 508, 506, 3, 2, 2, 2,
+	508, 507, 3, 2, 2, 2, 509, 63, 3, 2, 2, 2, 510, 519, 7, 58, 2, 2, 511,
+	516, 5, 66, 34, 2, 512, 513, 7, 62, 2, 2, 513, 515, 5, 66, 34, 2, 514,
+	512, 3, 2, 2, 2, 515, 518, 3, 2, 2, 2, 516, 514, 3, 2, 2, 2, 516, 517,
+	3, 2, 2, 2, 517, 520, 3, 2, 2, 2, 518, 516, 3, 2, 2, 2, 519, 511, 3, 2,
+	2, 2, 519, 520, 3, 2, 2, 2, 520, 521, 3, 2, 2, 2, 521, 529, 7, 59, 2, 2,
+	522, 523, 7, 63, 2, 2, 523, 525, 5, 66, 34, 2, 524, 522, 3, 2, 2, 2, 525,
+	526, 3, 2, 2, 2, 526, 524, 3, 2, 2, 2, 526, 527, 3, 2, 2, 2, 527, 529,
+	3, 2, 2, 2, 528, 510, 3, 2, 2, 2, 528, 524, 3, 2, 2, 2, 529, 65, 3, 2,
+	2, 2, 530, 533, 5, 68, 35, 2, 531, 533, 5, 76, 39, 2, 532, 530, 3, 2, 2,
+	2, 532, 531, 3, 2, 2, 2, 533, 67, 3, 2, 2, 2, 534, 537, 7, 58, 2, 2, 535,
+	538, 5, 76, 39, 2, 536, 538, 5, 38, 20, 2, 537, 535, 3, 2, 2, 2, 537, 536,
+	3, 2, 2, 2, 538, 546, 3, 2, 2, 2, 539, 542, 7, 62, 2, 2, 540, 543, 5, 76,
+	39, 2, 541, 543, 5, 38, 20, 2, 542, 540, 3, 2, 2, 2, 542, 541, 3, 2, 2,
+	2, 543, 545, 3, 2, 2, 2, 544, 539, 3, 2, 2, 2, 545, 548, 3, 2, 2, 2, 546,
+	544, 3, 2, 2, 2, 546, 547, 3, 2, 2, 2, 547, 549, 3, 2, 2, 2, 548, 546,
+	3, 2, 2, 2, 549, 550, 7, 59, 2, 2, 550, 69, 3, 2, 2, 2, 551, 553, 5, 72,
+	37, 2, 552, 551, 3, 2, 2, 2, 553, 554, 3, 2, 2, 2, 554, 552, 3, 2, 2, 2,
+	554, 555, 3, 2, 2, 2, 555, 71, 3, 2, 2, 2, 556, 557, 7, 63, 2, 2, 557,
+	558, 7, 9, 2, 2, 558, 559, 7, 64, 2, 2, 559, 563, 5, 20, 11, 2, 560, 561,
+	7, 26, 2, 2, 561, 562, 7, 64, 2, 2, 562, 564, 5, 58, 30, 2, 563, 560, 3,
+	2, 2, 2, 563, 564, 3, 2, 2, 2, 564, 73, 3, 2, 2, 2, 565, 566, 5, 84, 43,
+	2, 566, 75, 3, 2, 2, 2, 567, 568, 9, 8, 2, 2, 568, 77, 3, 2, 2, 2, 569,
+	570, 6, 40, 2, 2, 570, 572, 11, 2, 2, 2, 571, 569, 3, 2, 2, 2, 572, 573,
+	3, 2, 2, 2, 573, 571, 3, 2, 2, 2, 573, 574, 3, 2, 2, 2, 574, 79, 3, 2,
+	2, 2, 575, 576, 9, 9, 2, 2, 576, 81, 3, 2, 2, 2, 577, 578, 7, 53, 2, 2,
+	578, 83, 3, 2, 2, 2, 579, 580, 9, 10, 2, 2, 580, 85, 3, 2, 2, 2, 63, 91,
+	93, 102, 104, 116, 121, 126, 129, 167, 169, 179, 184, 189, 192, 230, 232,
+	245, 257, 266, 271, 277, 286, 294, 308, 316, 334, 339, 343, 357, 362, 375,
+	378, 385, 393, 402, 411, 414, 424, 427, 430, 440, 443, 446, 465, 480, 482,
+	487, 495, 501, 508, 516, 519, 526, 528, 532, 537, 542, 546, 554, 563, 573,
 }
 var deserializer = antlr.NewATNDeserializer(nil)
 var deserializedATN = deserializer.DeserializeFromUInt16(parserATN)
@@ -158,26 +290,34 @@ var literalNames = []string{
 	"", "'rule'", "'filter'", "'macro'", "'list'", "'name'", "'items'", "'condition'",
 	"'desc'", "'action'", "'output'", "'priority'", "'tags'", "'prefilter'",
 	"'enabled'", "'warn_evttypes'", "'skip-if-unknown-filter'", "'append'",
-	"'required_engine_version'", "'and'", "'or'", "'not'", "'<'", "'<='", "'>'",
-	"'>='", "'='", "'!='", "'in'", "'contains'", "'icontains'", "'startswith'",
-	"'endswith'", "'pmatch'", "'exists'", "'['", "']'", "'('", "')'", "','",
-	"'-'",
+	"", "'exceptions'", "'fields'", "'comps'", "'values'", "'sequence'", "'key'",
+	"'window'", "'steps'", "'within'", "'by'", "'and'", "'or'", "'not'", "'<'",
+	"'<='", "'>'", "'>='", "'='", "'ieq'", "'!='", "'in'", "'iin'", "'contains'",
+	"'icontains'", "'startswith'", "'istartswith'", "'endswith'", "'iendswith'",
+	"'matches'", "'imatches'", "'pmatch'", "'in_cidr'", "'exists'", "'+'",
+	"'*'", "'/'", "'%'", "'['", "']'", "'('", "')'", "','", "'-'",
 }
 var symbolicNames = []string{
 	"", "RULE", "FILTER", "MACRO", "LIST", "NAME", "ITEMS", "COND", "DESC",
 	"ACTION", "OUTPUT", "PRIORITY", "TAGS", "PREFILTER", "ENABLED", "WARNEVTTYPE",
-	"SKIPUNKNOWN", "FAPPEND", "REQ", "AND", "OR", "NOT", "LT", "LE", "GT",
-	"GE", "EQ", "NEQ", "IN", "CONTAINS", "ICONTAINS", "STARTSWITH", "ENDSWITH",
-	"PMATCH", "EXISTS", "LBRACK", "RBRACK", "LPAREN", "RPAREN", "LISTSEP",
-	"DECL", "DEF", "SEVERITY", "SFSEVERITY", "FSEVERITY", "ID", "NUMBER", "PATH",
-	"STRING", "TAG", "WS", "NL", "COMMENT", "ANY",
+	"SKIPUNKNOWN", "FAPPEND", "REQ", "EXCEPTIONS", "FIELDS", "COMPS", "VALUES",
+	"SEQUENCE", "KEY", "WINDOW", "STEPS", "WITHIN", "BY", "AND", "OR", "NOT",
+	"LT", "LE", "GT", "GE", "EQ", "IEQ", "NEQ", "IN", "IIN", "CONTAINS", "ICONTAINS",
+	"STARTSWITH", "ISTARTSWITH", "ENDSWITH", "IENDSWITH", "MATCHES", "IMATCHES",
+	"PMATCH", "INCIDR", "EXISTS", "PLUS", "STAR", "DIV", "MOD", "LBRACK", "RBRACK",
+	"LPAREN", "RPAREN", "LISTSEP", "DECL", "DEF", "SEVERITY", "SFSEVERITY",
+	"FSEVERITY", "ID", "NUMBER", "PATH", "STRING", "TAG", "WS", "NL", "COMMENT",
+	"ANY",
 }
 
 var ruleNames = []string{
 	"policy", "defs", "prule", "srule", "pfilter", "sfilter", "pmacro", "plist",
-	"preq", "expression", "or_expression", "and_expression", "term", "items",
+	"preq", "expression", "or_expression", "and_expression", "term", "aggregate",
+	"arith_expression", "arith_term", "arith_factor", "function", "items",
 	"tags", "prefilter", "severity", "enabled", "warnevttype", "skipunknown",
-	"fappend", "variable", "atom", "text", "binary_operator", "unary_operator",
+	"fappend", "exceptions", "exception", "fields", "comps", "comp", "values",
+	"value", "tuple", "steps", "step", "variable", "atom", "text", "binary_operator",
+	"unary_operator", "identifier",
 }
 var decisionToDFA = make([]*antlr.DFA, len(deserializedATN.DecisionToState))
 
@@ -226,71 +366,108 @@ const (
 	SfplParserSKIPUNKNOWN = 16
 	SfplParserFAPPEND     = 17
 	SfplParserREQ         = 18
-	SfplParserAND         = 19
-	SfplParserOR          = 20
-	SfplParserNOT         = 21
-	SfplParserLT          = 22
-	SfplParserLE          = 23
-	SfplParserGT          = 24
-	SfplParserGE          = 25
-	SfplParserEQ          = 26
-	SfplParserNEQ         = 27
-	SfplParserIN          = 28
-	SfplParserCONTAINS    = 29
-	SfplParserICONTAINS   = 30
-	SfplParserSTARTSWITH  = 31
-	SfplParserENDSWITH    = 32
-	SfplParserPMATCH      = 33
-	SfplParserEXISTS      = 34
-	SfplParserLBRACK      = 35
-	SfplParserRBRACK      = 36
-	SfplParserLPAREN      = 37
-	SfplParserRPAREN      = 38
-	SfplParserLISTSEP     = 39
-	SfplParserDECL        = 40
-	SfplParserDEF         = 41
-	SfplParserSEVERITY    = 42
-	SfplParserSFSEVERITY  = 43
-	SfplParserFSEVERITY   = 44
-	SfplParserID          = 45
-	SfplParserNUMBER      = 46
-	SfplParserPATH        = 47
-	SfplParserSTRING      = 48
-	SfplParserTAG         = 49
-	SfplParserWS          = 50
-	SfplParserNL          = 51
-	SfplParserCOMMENT     = 52
-	SfplParserANY         = 53
+	SfplParserEXCEPTIONS  = 19
+	SfplParserFIELDS      = 20
+	SfplParserCOMPS       = 21
+	SfplParserVALUES      = 22
+	SfplParserSEQUENCE    = 23
+	SfplParserKEY         = 24
+	SfplParserWINDOW      = 25
+	SfplParserSTEPS       = 26
+	SfplParserWITHIN      = 27
+	SfplParserBY          = 28
+	SfplParserAND         = 29
+	SfplParserOR          = 30
+	SfplParserNOT         = 31
+	SfplParserLT          = 32
+	SfplParserLE          = 33
+	SfplParserGT          = 34
+	SfplParserGE          = 35
+	SfplParserEQ          = 36
+	SfplParserIEQ         = 37
+	SfplParserNEQ         = 38
+	SfplParserIN          = 39
+	SfplParserIIN         = 40
+	SfplParserCONTAINS    = 41
+	SfplParserICONTAINS   = 42
+	SfplParserSTARTSWITH  = 43
+	SfplParserISTARTSWITH = 44
+	SfplParserENDSWITH    = 45
+	SfplParserIENDSWITH   = 46
+	SfplParserMATCHES     = 47
+	SfplParserIMATCHES    = 48
+	SfplParserPMATCH      = 49
+	SfplParserINCIDR      = 50
+	SfplParserEXISTS      = 51
+	SfplParserPLUS        = 52
+	SfplParserSTAR        = 53
+	SfplParserDIV         = 54
+	SfplParserMOD         = 55
+	SfplParserLBRACK      = 56
+	SfplParserRBRACK      = 57
+	SfplParserLPAREN      = 58
+	SfplParserRPAREN      = 59
+	SfplParserLISTSEP     = 60
+	SfplParserDECL        = 61
+	SfplParserDEF         = 62
+	SfplParserSEVERITY    = 63
+	SfplParserSFSEVERITY  = 64
+	SfplParserFSEVERITY   = 65
+	SfplParserID          = 66
+	SfplParserNUMBER      = 67
+	SfplParserPATH        = 68
+	SfplParserSTRING      = 69
+	SfplParserTAG         = 70
+	SfplParserWS          = 71
+	SfplParserNL          = 72
+	SfplParserCOMMENT     = 73
+	SfplParserANY         = 74
 )
 
 // SfplParser rules.
 const (
-	SfplParserRULE_policy          = 0
-	SfplParserRULE_defs            = 1
-	SfplParserRULE_prule           = 2
-	SfplParserRULE_srule           = 3
-	SfplParserRULE_pfilter         = 4
-	SfplParserRULE_sfilter         = 5
-	SfplParserRULE_pmacro          = 6
-	SfplParserRULE_plist           = 7
-	SfplParserRULE_preq            = 8
-	SfplParserRULE_expression      = 9
-	SfplParserRULE_or_expression   = 10
-	SfplParserRULE_and_expression  = 11
-	SfplParserRULE_term            = 12
-	SfplParserRULE_items           = 13
-	SfplParserRULE_tags            = 14
-	SfplParserRULE_prefilter       = 15
-	SfplParserRULE_severity        = 16
-	SfplParserRULE_enabled         = 17
-	SfplParserRULE_warnevttype     = 18
-	SfplParserRULE_skipunknown     = 19
-	SfplParserRULE_fappend         = 20
-	SfplParserRULE_variable        = 21
-	SfplParserRULE_atom            = 22
-	SfplParserRULE_text            = 23
-	SfplParserRULE_binary_operator = 24
-	SfplParserRULE_unary_operator  = 25
+	SfplParserRULE_policy           = 0
+	SfplParserRULE_defs             = 1
+	SfplParserRULE_prule            = 2
+	SfplParserRULE_srule            = 3
+	SfplParserRULE_pfilter          = 4
+	SfplParserRULE_sfilter          = 5
+	SfplParserRULE_pmacro           = 6
+	SfplParserRULE_plist            = 7
+	SfplParserRULE_preq             = 8
+	SfplParserRULE_expression       = 9
+	SfplParserRULE_or_expression    = 10
+	SfplParserRULE_and_expression   = 11
+	SfplParserRULE_term             = 12
+	SfplParserRULE_aggregate        = 13
+	SfplParserRULE_arith_expression = 14
+	SfplParserRULE_arith_term       = 15
+	SfplParserRULE_arith_factor     = 16
+	SfplParserRULE_function         = 17
+	SfplParserRULE_items            = 18
+	SfplParserRULE_tags             = 19
+	SfplParserRULE_prefilter        = 20
+	SfplParserRULE_severity         = 21
+	SfplParserRULE_enabled          = 22
+	SfplParserRULE_warnevttype      = 23
+	SfplParserRULE_skipunknown      = 24
+	SfplParserRULE_fappend          = 25
+	SfplParserRULE_exceptions       = 26
+	SfplParserRULE_exception        = 27
+	SfplParserRULE_fields           = 28
+	SfplParserRULE_comps            = 29
+	SfplParserRULE_comp             = 30
+	SfplParserRULE_values           = 31
+	SfplParserRULE_value            = 32
+	SfplParserRULE_tuple            = 33
+	SfplParserRULE_steps            = 34
+	SfplParserRULE_step             = 35
+	SfplParserRULE_variable         = 36
+	SfplParserRULE_atom             = 37
+	SfplParserRULE_text             = 38
+	SfplParserRULE_binary_operator  = 39
+	SfplParserRULE_unary_operator   = 40
+	SfplParserRULE_identifier       = 41
 )
 
 // IPolicyContext is an interface to support dynamic dispatch.
@@ -502,52 +679,52 @@ func (p *SfplParser) Policy() (localctx IPolicyContext) {
 	}()
 
 	p.EnterOuterAlt(localctx, 1)
-	p.SetState(57)
+	p.SetState(89)
 	p.GetErrorHandler().Sync(p)
 	_la = p.GetTokenStream().LA(1)
 
 	for ok := true; ok; ok = _la == SfplParserDECL {
-		p.SetState(57)
+		p.SetState(89)
 		p.GetErrorHandler().Sync(p)
 		switch p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 0, p.GetParserRuleContext()) {
 		case 1:
 			{
-				p.SetState(52)
+				p.SetState(84)
 				p.Prule()
 			}
 
 		case 2:
 			{
-				p.SetState(53)
+				p.SetState(85)
 				p.Pfilter()
 			}
 
 		case 3:
 			{
-				p.SetState(54)
+				p.SetState(86)
 				p.Pmacro()
 			}
 
 		case 4:
 			{
-				p.SetState(55)
+				p.SetState(87)
 				p.Plist()
 			}
 
 		case 5:
 			{
-				p.SetState(56)
+				p.SetState(88)
 				p.Preq()
 			}
 
 		}
 
-		p.SetState(59)
+		p.SetState(91)
 		p.GetErrorHandler().Sync(p)
 		_la = p.GetTokenStream().LA(1)
 	}
 	{
-		p.SetState(61)
+		p.SetState(93)
 		p.Match(SfplParserEOF)
 	}
 
@@ -763,52 +940,52 @@ func (p *SfplParser) Defs() (localctx IDefsContext) {
 	}()
 
 	p.EnterOuterAlt(localctx, 1)
-	p.SetState(70)
+	p.SetState(102)
 	p.GetErrorHandler().Sync(p)
 	_la = p.GetTokenStream().LA(1)
 
 	for _la == SfplParserDECL {
-		p.SetState(68)
+		p.SetState(100)
 		p.GetErrorHandler().Sync(p)
 		switch p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 2, p.GetParserRuleContext()) {
 		case 1:
 			{
-				p.SetState(63)
+				p.SetState(95)
 				p.Srule()
 			}
 
 		case 2:
 			{
-				p.SetState(64)
+				p.SetState(96)
 				p.Sfilter()
 			}
 
 		case 3:
 			{
-				p.SetState(65)
+				p.SetState(97)
 				p.Pmacro()
 			}
 
 		case 4:
 			{
-				p.SetState(66)
+				p.SetState(98)
 				p.Plist()
 			}
 
 		case 5:
 			{
-				p.SetState(67)
+				p.SetState(99)
 				p.Preq()
 			}
 
 		}
 
-		p.SetState(72)
+		p.SetState(104)
 		p.GetErrorHandler().Sync(p)
 		_la = p.GetTokenStream().LA(1)
 	}
 	{
-		p.SetState(73)
+		p.SetState(105)
 		p.Match(SfplParserEOF)
 	}
 
@@ -861,6 +1038,10 @@ func (s *PruleContext) RULE() antlr.TerminalNode {
 	return s.GetToken(SfplParserRULE, 0)
 }
 
+func (s *PruleContext) SEQUENCE() antlr.TerminalNode {
+	return s.GetToken(SfplParserSEQUENCE, 0)
+}
+
 func (s *PruleContext) AllDEF() []antlr.TerminalNode {
 	return s.GetTokens(SfplParserDEF)
 }
@@ -896,6 +1077,37 @@ func (s *PruleContext) DESC() antlr.TerminalNode {
 	return s.GetToken(SfplParserDESC, 0)
 }
 
+func (s *PruleContext) AllFAPPEND() []antlr.TerminalNode {
+	return s.GetTokens(SfplParserFAPPEND)
+}
+
+func (s *PruleContext) FAPPEND(i int) antlr.TerminalNode {
+	return s.GetToken(SfplParserFAPPEND, i)
+}
+
+func (s *PruleContext) AllFappend() []IFappendContext {
+	var ts = s.GetTypedRuleContexts(reflect.TypeOf((*IFappendContext)(nil)).Elem())
+	var tst = make([]IFappendContext, len(ts))
+
+	for i, t := range ts {
+		if t != nil {
+			tst[i] = t.(IFappendContext)
+		}
+	}
+
+	return tst
+}
+
+func (s *PruleContext) Fappend(i int) IFappendContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IFappendContext)(nil)).Elem(), i)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IFappendContext)
+}
+
 func (s *PruleContext) COND() antlr.TerminalNode {
 	return s.GetToken(SfplParserCOND, 0)
 }
@@ -1096,6 +1308,138 @@ func (s *PruleContext) Skipunknown(i int) ISkipunknownContext {
 	return t.(ISkipunknownContext)
 }
 
+func (s *PruleContext) AllEXCEPTIONS() []antlr.TerminalNode {
+	return s.GetTokens(SfplParserEXCEPTIONS)
+}
+
+func (s *PruleContext) EXCEPTIONS(i int) antlr.TerminalNode {
+	return s.GetToken(SfplParserEXCEPTIONS, i)
+}
+
+func (s *PruleContext) AllExceptions() []IExceptionsContext {
+	var ts = s.GetTypedRuleContexts(reflect.TypeOf((*IExceptionsContext)(nil)).Elem())
+	var tst = make([]IExceptionsContext, len(ts))
+
+	for i, t := range ts {
+		if t != nil {
+			tst[i] = t.(IExceptionsContext)
+		}
+	}
+
+	return tst
+}
+
+func (s *PruleContext) Exceptions(i int) IExceptionsContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IExceptionsContext)(nil)).Elem(), i)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IExceptionsContext)
+}
+
+func (s *PruleContext) AllKEY() []antlr.TerminalNode {
+	return s.GetTokens(SfplParserKEY)
+}
+
+func (s *PruleContext) KEY(i int) antlr.TerminalNode {
+	return s.GetToken(SfplParserKEY, i)
+}
+
+func (s *PruleContext) AllFields() []IFieldsContext {
+	var ts = s.GetTypedRuleContexts(reflect.TypeOf((*IFieldsContext)(nil)).Elem())
+	var tst = make([]IFieldsContext, len(ts))
+
+	for i, t := range ts {
+		if t != nil {
+			tst[i] = t.(IFieldsContext)
+		}
+	}
+
+	return tst
+}
+
+func (s *PruleContext) Fields(i int) IFieldsContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IFieldsContext)(nil)).Elem(), i)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IFieldsContext)
+}
+
+func (s *PruleContext) AllWINDOW() []antlr.TerminalNode {
+	return s.GetTokens(SfplParserWINDOW)
+}
+
+func (s *PruleContext) WINDOW(i int) antlr.TerminalNode {
+	return s.GetToken(SfplParserWINDOW, i)
+}
+
+func (s *PruleContext) AllAtom() []IAtomContext {
+	var ts = s.GetTypedRuleContexts(reflect.TypeOf((*IAtomContext)(nil)).Elem())
+	var tst = make([]IAtomContext, len(ts))
+
+	for i, t := range ts {
+		if t != nil {
+			tst[i] = t.(IAtomContext)
+		}
+	}
+
+	return tst
+}
+
+func (s *PruleContext) Atom(i int) IAtomContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IAtomContext)(nil)).Elem(), i)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IAtomContext)
+}
+
+func (s *PruleContext) AllSTEPS() []antlr.TerminalNode {
+	return s.GetTokens(SfplParserSTEPS)
+}
+
+func (s *PruleContext) STEPS(i int) antlr.TerminalNode {
+	return s.GetToken(SfplParserSTEPS, i)
+}
+
+func (s *PruleContext) AllSteps() []IStepsContext {
+	var ts = s.GetTypedRuleContexts(reflect.TypeOf((*IStepsContext)(nil)).Elem())
+	var tst = make([]IStepsContext, len(ts))
+
+	for i, t := range ts {
+		if t != nil {
+			tst[i] = t.(IStepsContext)
+		}
+	}
+
+	return tst
+}
+
+func (s *PruleContext) Steps(i int) IStepsContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IStepsContext)(nil)).Elem(), i)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IStepsContext)
+}
+
+func (s *PruleContext) OR() antlr.TerminalNode {
+	return s.GetToken(SfplParserOR, 0)
+}
+
+func (s *PruleContext) AND() antlr.TerminalNode {
+	return s.GetToken(SfplParserAND, 0)
+}
+
 func (s *PruleContext) AllACTION() []antlr.TerminalNode {
 	return s.GetTokens(SfplParserACTION)
 }
@@ -1165,57 +1509,114 @@ func (p *SfplParser) Prule() (localctx IPruleContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(75)
+		p.SetState(107)
 		p.Match(SfplParserDECL)
 	}
 	{
-		p.SetState(76)
-		p.Match(SfplParserRULE)
-	}
-	{
-		p.SetState(77)
-		p.Match(SfplParserDEF)
-	}
-	{
-		p.SetState(78)
-		p.Text()
-	}
-	{
-		p.SetState(79)
-		p.Match(SfplParserDESC)
+		p.SetState(108)
+		_la = p.GetTokenStream().LA(1)
+
+		if !(_la == SfplParserRULE || _la == SfplParserSEQUENCE) {
+			p.GetErrorHandler().RecoverInline(p)
+		} else {
+			p.GetErrorHandler().ReportMatch(p)
+			p.Consume()
+		}
 	}
 	{
-		p.SetState(80)
+		p.SetState(109)
 		p.Match(SfplParserDEF)
 	}
 	{
-		p.SetState(81)
+		p.SetState(110)
 		p.Text()
 	}
-	{
-		p.SetState(82)
-		p.Match(SfplParserCOND)
+	p.SetState(114)
+	p.GetErrorHandler().Sync(p)
+	_la = p.GetTokenStream().LA(1)
+
+	if _la == SfplParserDESC {
+		{
+			p.SetState(111)
+			p.Match(SfplParserDESC)
+		}
+		{
+			p.SetState(112)
+			p.Match(SfplParserDEF)
+		}
+		{
+			p.SetState(113)
+			p.Text()
+		}
+
 	}
-	{
-		p.SetState(83)
-		p.Match(SfplParserDEF)
+	p.SetState(119)
+	p.GetErrorHandler().Sync(p)
+
+	if p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 5, p.GetParserRuleContext()) == 1 {
+		{
+			p.SetState(116)
+			p.Match(SfplParserFAPPEND)
+		}
+		{
+			p.SetState(117)
+			p.Match(SfplParserDEF)
+		}
+		{
+			p.SetState(118)
+			p.Fappend()
+		}
+
 	}
-	{
-		p.SetState(84)
-		p.Expression()
+	p.SetState(127)
+	p.GetErrorHandler().Sync(p)
+	_la = p.GetTokenStream().LA(1)
+
+	if _la == SfplParserCOND {
+		{
+			p.SetState(121)
+			p.Match(SfplParserCOND)
+		}
+		{
+			p.SetState(122)
+			p.Match(SfplParserDEF)
+		}
+		p.SetState(124)
+		p.GetErrorHandler().Sync(p)
+		_la = p.GetTokenStream().LA(1)
+
+		if _la == SfplParserAND || _la == SfplParserOR {
+			{
+				p.SetState(123)
+				_la = p.GetTokenStream().LA(1)
+
+				if !(_la == SfplParserAND || _la == SfplParserOR) {
+					p.GetErrorHandler().RecoverInline(p)
+				} else {
+					p.GetErrorHandler().ReportMatch(p)
+					p.Consume()
+				}
+			}
+
+		}
+		{
+			p.SetState(126)
+			p.Expression()
+		}
+
 	}
-	p.SetState(108)
+	p.SetState(167)
 	p.GetErrorHandler().Sync(p)
 	_la = p.GetTokenStream().LA(1)
 
-	for ((_la)&-(0x1f+1)) == 0 && ((1<<uint(_la))&((1<<SfplParserACTION)|(1<<SfplParserOUTPUT)|(1<<SfplParserPRIORITY)|(1<<SfplParserTAGS)|(1<<SfplParserPREFILTER)|(1<<SfplParserENABLED)|(1<<SfplParserWARNEVTTYPE)|(1<<SfplParserSKIPUNKNOWN))) != 0 {
-		p.SetState(106)
+	for ((_la)&-(0x1f+1)) == 0 && ((1<<uint(_la))&((1<<SfplParserACTION)|(1<<SfplParserOUTPUT)|(1<<SfplParserPRIORITY)|(1<<SfplParserTAGS)|(1<<SfplParserPREFILTER)|(1<<SfplParserENABLED)|(1<<SfplParserWARNEVTTYPE)|(1<<SfplParserSKIPUNKNOWN)|(1<<SfplParserFAPPEND)|(1<<SfplParserEXCEPTIONS)|(1<<SfplParserKEY)|(1<<SfplParserWINDOW)|(1<<SfplParserSTEPS))) != 0 {
+		p.SetState(165)
 		p.GetErrorHandler().Sync(p)
 
 		switch p.GetTokenStream().LA(1) {
 		case SfplParserACTION, SfplParserOUTPUT:
 			{
-				p.SetState(85)
+				p.SetState(129)
 				_la = p.GetTokenStream().LA(1)
 
 				if !(_la == SfplParserACTION || _la == SfplParserOUTPUT) {
@@ -1226,118 +1627,188 @@ func (p *SfplParser) Prule() (localctx IPruleContext) {
 				}
 			}
 			{
-				p.SetState(86)
+				p.SetState(130)
 				p.Match(SfplParserDEF)
 			}
 			{
-				p.SetState(87)
+				p.SetState(131)
 				p.Text()
 			}
 
 		case SfplParserPRIORITY:
 			{
-				p.SetState(88)
+				p.SetState(132)
 				p.Match(SfplParserPRIORITY)
 			}
 			{
-				p.SetState(89)
+				p.SetState(133)
 				p.Match(SfplParserDEF)
 			}
 			{
-				p.SetState(90)
+				p.SetState(134)
 				p.Severity()
 			}
 
 		case SfplParserTAGS:
 			{
-				p.SetState(91)
+				p.SetState(135)
 				p.Match(SfplParserTAGS)
 			}
 			{
-				p.SetState(92)
+				p.SetState(136)
 				p.Match(SfplParserDEF)
 			}
 			{
-				p.SetState(93)
+				p.SetState(137)
 				p.Tags()
 			}
 
 		case SfplParserPREFILTER:
 			{
-				p.SetState(94)
+				p.SetState(138)
 				p.Match(SfplParserPREFILTER)
 			}
 			{
-				p.SetState(95)
+				p.SetState(139)
 				p.Match(SfplParserDEF)
 			}
 			{
-				p.SetState(96)
+				p.SetState(140)
 				p.Prefilter()
 			}
 
 		case SfplParserENABLED:
 			{
-				p.SetState(97)
+				p.SetState(141)
 				p.Match(SfplParserENABLED)
 			}
 			{
-				p.SetState(98)
+				p.SetState(142)
 				p.Match(SfplParserDEF)
 			}
 			{
-				p.SetState(99)
+				p.SetState(143)
 				p.Enabled()
 			}
 
 		case SfplParserWARNEVTTYPE:
 			{
-				p.SetState(100)
+				p.SetState(144)
 				p.Match(SfplParserWARNEVTTYPE)
 			}
 			{
-				p.SetState(101)
+				p.SetState(145)
 				p.Match(SfplParserDEF)
 			}
 			{
-				p.SetState(102)
+				p.SetState(146)
 				p.Warnevttype()
 			}
 
 		case SfplParserSKIPUNKNOWN:
 			{
-				p.SetState(103)
+				p.SetState(147)
 				p.Match(SfplParserSKIPUNKNOWN)
 			}
 			{
-				p.SetState(104)
+				p.SetState(148)
 				p.Match(SfplParserDEF)
 			}
 			{
-				p.SetState(105)
+				p.SetState(149)
 				p.Skipunknown()
 			}
 
-		default:
-			panic(antlr.NewNoViableAltException(p, nil, nil, nil, nil, nil))
-		}
-
-		p.SetState(110)
-		p.GetErrorHandler().Sync(p)
-		_la = p.GetTokenStream().LA(1)
-	}
-
-	return localctx
-}
+		case SfplParserEXCEPTIONS:
+			{
+				p.SetState(150)
+				p.Match(SfplParserEXCEPTIONS)
+			}
+			{
+				p.SetState(151)
+				p.Match(SfplParserDEF)
+			}
+			{
+				p.SetState(152)
+				p.Exceptions()
+			}
 
-// ISruleContext is an interface to support dynamic dispatch.
-type ISruleContext interface {
-	antlr.ParserRuleContext
+		case SfplParserKEY:
+			{
+				p.SetState(153)
+				p.Match(SfplParserKEY)
+			}
+			{
+				p.SetState(154)
+				p.Match(SfplParserDEF)
+			}
+			{
+				p.SetState(155)
+				p.Fields()
+			}
 
-	// GetParser returns the parser.
-	GetParser() antlr.Parser
+		case SfplParserWINDOW:
+			{
+				p.SetState(156)
+				p.Match(SfplParserWINDOW)
+			}
+			{
+				p.SetState(157)
+				p.Match(SfplParserDEF)
+			}
+			{
+				p.SetState(158)
+				p.Atom()
+			}
 
-	// IsSruleContext differentiates from other interfaces.
+		case SfplParserSTEPS:
+			{
+				p.SetState(159)
+				p.Match(SfplParserSTEPS)
+			}
+			{
+				p.SetState(160)
+				p.Match(SfplParserDEF)
+			}
+			{
+				p.SetState(161)
+				p.Steps()
+			}
+
+		case SfplParserFAPPEND:
+			{
+				p.SetState(162)
+				p.Match(SfplParserFAPPEND)
+			}
+			{
+				p.SetState(163)
+				p.Match(SfplParserDEF)
+			}
+			{
+				p.SetState(164)
+				p.Fappend()
+			}
+
+		default:
+			panic(antlr.NewNoViableAltException(p, nil, nil, nil, nil, nil))
+		}
+
+		p.SetState(169)
+		p.GetErrorHandler().Sync(p)
+		_la = p.GetTokenStream().LA(1)
+	}
+
+	return localctx
+}
+
+// ISruleContext is an interface to support dynamic dispatch.
+type ISruleContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// IsSruleContext differentiates from other interfaces.
 	IsSruleContext()
 }
 
@@ -1376,6 +1847,10 @@ func (s *SruleContext) RULE() antlr.TerminalNode {
 	return s.GetToken(SfplParserRULE, 0)
 }
 
+func (s *SruleContext) SEQUENCE() antlr.TerminalNode {
+	return s.GetToken(SfplParserSEQUENCE, 0)
+}
+
 func (s *SruleContext) AllDEF() []antlr.TerminalNode {
 	return s.GetTokens(SfplParserDEF)
 }
@@ -1411,6 +1886,37 @@ func (s *SruleContext) DESC() antlr.TerminalNode {
 	return s.GetToken(SfplParserDESC, 0)
 }
 
+func (s *SruleContext) AllFAPPEND() []antlr.TerminalNode {
+	return s.GetTokens(SfplParserFAPPEND)
+}
+
+func (s *SruleContext) FAPPEND(i int) antlr.TerminalNode {
+	return s.GetToken(SfplParserFAPPEND, i)
+}
+
+func (s *SruleContext) AllFappend() []IFappendContext {
+	var ts = s.GetTypedRuleContexts(reflect.TypeOf((*IFappendContext)(nil)).Elem())
+	var tst = make([]IFappendContext, len(ts))
+
+	for i, t := range ts {
+		if t != nil {
+			tst[i] = t.(IFappendContext)
+		}
+	}
+
+	return tst
+}
+
+func (s *SruleContext) Fappend(i int) IFappendContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IFappendContext)(nil)).Elem(), i)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IFappendContext)
+}
+
 func (s *SruleContext) COND() antlr.TerminalNode {
 	return s.GetToken(SfplParserCOND, 0)
 }
@@ -1611,6 +2117,138 @@ func (s *SruleContext) Skipunknown(i int) ISkipunknownContext {
 	return t.(ISkipunknownContext)
 }
 
+func (s *SruleContext) AllEXCEPTIONS() []antlr.TerminalNode {
+	return s.GetTokens(SfplParserEXCEPTIONS)
+}
+
+func (s *SruleContext) EXCEPTIONS(i int) antlr.TerminalNode {
+	return s.GetToken(SfplParserEXCEPTIONS, i)
+}
+
+func (s *SruleContext) AllExceptions() []IExceptionsContext {
+	var ts = s.GetTypedRuleContexts(reflect.TypeOf((*IExceptionsContext)(nil)).Elem())
+	var tst = make([]IExceptionsContext, len(ts))
+
+	for i, t := range ts {
+		if t != nil {
+			tst[i] = t.(IExceptionsContext)
+		}
+	}
+
+	return tst
+}
+
+func (s *SruleContext) Exceptions(i int) IExceptionsContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IExceptionsContext)(nil)).Elem(), i)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IExceptionsContext)
+}
+
+func (s *SruleContext) AllKEY() []antlr.TerminalNode {
+	return s.GetTokens(SfplParserKEY)
+}
+
+func (s *SruleContext) KEY(i int) antlr.TerminalNode {
+	return s.GetToken(SfplParserKEY, i)
+}
+
+func (s *SruleContext) AllFields() []IFieldsContext {
+	var ts = s.GetTypedRuleContexts(reflect.TypeOf((*IFieldsContext)(nil)).Elem())
+	var tst = make([]IFieldsContext, len(ts))
+
+	for i, t := range ts {
+		if t != nil {
+			tst[i] = t.(IFieldsContext)
+		}
+	}
+
+	return tst
+}
+
+func (s *SruleContext) Fields(i int) IFieldsContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IFieldsContext)(nil)).Elem(), i)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IFieldsContext)
+}
+
+func (s *SruleContext) AllWINDOW() []antlr.TerminalNode {
+	return s.GetTokens(SfplParserWINDOW)
+}
+
+func (s *SruleContext) WINDOW(i int) antlr.TerminalNode {
+	return s.GetToken(SfplParserWINDOW, i)
+}
+
+func (s *SruleContext) AllAtom() []IAtomContext {
+	var ts = s.GetTypedRuleContexts(reflect.TypeOf((*IAtomContext)(nil)).Elem())
+	var tst = make([]IAtomContext, len(ts))
+
+	for i, t := range ts {
+		if t != nil {
+			tst[i] = t.(IAtomContext)
+		}
+	}
+
+	return tst
+}
+
+func (s *SruleContext) Atom(i int) IAtomContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IAtomContext)(nil)).Elem(), i)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IAtomContext)
+}
+
+func (s *SruleContext) AllSTEPS() []antlr.TerminalNode {
+	return s.GetTokens(SfplParserSTEPS)
+}
+
+func (s *SruleContext) STEPS(i int) antlr.TerminalNode {
+	return s.GetToken(SfplParserSTEPS, i)
+}
+
+func (s *SruleContext) AllSteps() []IStepsContext {
+	var ts = s.GetTypedRuleContexts(reflect.TypeOf((*IStepsContext)(nil)).Elem())
+	var tst = make([]IStepsContext, len(ts))
+
+	for i, t := range ts {
+		if t != nil {
+			tst[i] = t.(IStepsContext)
+		}
+	}
+
+	return tst
+}
+
+func (s *SruleContext) Steps(i int) IStepsContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IStepsContext)(nil)).Elem(), i)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IStepsContext)
+}
+
+func (s *SruleContext) OR() antlr.TerminalNode {
+	return s.GetToken(SfplParserOR, 0)
+}
+
+func (s *SruleContext) AND() antlr.TerminalNode {
+	return s.GetToken(SfplParserAND, 0)
+}
+
 func (s *SruleContext) AllACTION() []antlr.TerminalNode {
 	return s.GetTokens(SfplParserACTION)
 }
@@ -1680,57 +2318,114 @@ func (p *SfplParser) Srule() (localctx ISruleContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(111)
+		p.SetState(170)
 		p.Match(SfplParserDECL)
 	}
 	{
-		p.SetState(112)
-		p.Match(SfplParserRULE)
-	}
-	{
-		p.SetState(113)
-		p.Match(SfplParserDEF)
-	}
-	{
-		p.SetState(114)
-		p.Text()
-	}
-	{
-		p.SetState(115)
-		p.Match(SfplParserDESC)
+		p.SetState(171)
+		_la = p.GetTokenStream().LA(1)
+
+		if !(_la == SfplParserRULE || _la == SfplParserSEQUENCE) {
+			p.GetErrorHandler().RecoverInline(p)
+		} else {
+			p.GetErrorHandler().ReportMatch(p)
+			p.Consume()
+		}
 	}
 	{
-		p.SetState(116)
+		p.SetState(172)
 		p.Match(SfplParserDEF)
 	}
 	{
-		p.SetState(117)
+		p.SetState(173)
 		p.Text()
 	}
-	{
-		p.SetState(118)
-		p.Match(SfplParserCOND)
+	p.SetState(177)
+	p.GetErrorHandler().Sync(p)
+	_la = p.GetTokenStream().LA(1)
+
+	if _la == SfplParserDESC {
+		{
+			p.SetState(174)
+			p.Match(SfplParserDESC)
+		}
+		{
+			p.SetState(175)
+			p.Match(SfplParserDEF)
+		}
+		{
+			p.SetState(176)
+			p.Text()
+		}
+
 	}
-	{
-		p.SetState(119)
-		p.Match(SfplParserDEF)
+	p.SetState(182)
+	p.GetErrorHandler().Sync(p)
+
+	if p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 11, p.GetParserRuleContext()) == 1 {
+		{
+			p.SetState(179)
+			p.Match(SfplParserFAPPEND)
+		}
+		{
+			p.SetState(180)
+			p.Match(SfplParserDEF)
+		}
+		{
+			p.SetState(181)
+			p.Fappend()
+		}
+
 	}
-	{
-		p.SetState(120)
-		p.Expression()
+	p.SetState(190)
+	p.GetErrorHandler().Sync(p)
+	_la = p.GetTokenStream().LA(1)
+
+	if _la == SfplParserCOND {
+		{
+			p.SetState(184)
+			p.Match(SfplParserCOND)
+		}
+		{
+			p.SetState(185)
+			p.Match(SfplParserDEF)
+		}
+		p.SetState(187)
+		p.GetErrorHandler().Sync(p)
+		_la = p.GetTokenStream().LA(1)
+
+		if _la == SfplParserAND || _la == SfplParserOR {
+			{
+				p.SetState(186)
+				_la = p.GetTokenStream().LA(1)
+
+				if !(_la == SfplParserAND || _la == SfplParserOR) {
+					p.GetErrorHandler().RecoverInline(p)
+				} else {
+					p.GetErrorHandler().ReportMatch(p)
+					p.Consume()
+				}
+			}
+
+		}
+		{
+			p.SetState(189)
+			p.Expression()
+		}
+
 	}
-	p.SetState(144)
+	p.SetState(230)
 	p.GetErrorHandler().Sync(p)
 	_la = p.GetTokenStream().LA(1)
 
-	for ((_la)&-(0x1f+1)) == 0 && ((1<<uint(_la))&((1<<SfplParserACTION)|(1<<SfplParserOUTPUT)|(1<<SfplParserPRIORITY)|(1<<SfplParserTAGS)|(1<<SfplParserPREFILTER)|(1<<SfplParserENABLED)|(1<<SfplParserWARNEVTTYPE)|(1<<SfplParserSKIPUNKNOWN))) != 0 {
-		p.SetState(142)
+	for ((_la)&-(0x1f+1)) == 0 && ((1<<uint(_la))&((1<<SfplParserACTION)|(1<<SfplParserOUTPUT)|(1<<SfplParserPRIORITY)|(1<<SfplParserTAGS)|(1<<SfplParserPREFILTER)|(1<<SfplParserENABLED)|(1<<SfplParserWARNEVTTYPE)|(1<<SfplParserSKIPUNKNOWN)|(1<<SfplParserFAPPEND)|(1<<SfplParserEXCEPTIONS)|(1<<SfplParserKEY)|(1<<SfplParserWINDOW)|(1<<SfplParserSTEPS))) != 0 {
+		p.SetState(228)
 		p.GetErrorHandler().Sync(p)
 
 		switch p.GetTokenStream().LA(1) {
 		case SfplParserACTION, SfplParserOUTPUT:
 			{
-				p.SetState(121)
+				p.SetState(192)
 				_la = p.GetTokenStream().LA(1)
 
 				if !(_la == SfplParserACTION || _la == SfplParserOUTPUT) {
@@ -1741,112 +2436,182 @@ func (p *SfplParser) Srule() (localctx ISruleContext) {
 				}
 			}
 			{
-				p.SetState(122)
+				p.SetState(193)
 				p.Match(SfplParserDEF)
 			}
 			{
-				p.SetState(123)
+				p.SetState(194)
 				p.Text()
 			}
 
 		case SfplParserPRIORITY:
 			{
-				p.SetState(124)
+				p.SetState(195)
 				p.Match(SfplParserPRIORITY)
 			}
 			{
-				p.SetState(125)
+				p.SetState(196)
 				p.Match(SfplParserDEF)
 			}
 			{
-				p.SetState(126)
+				p.SetState(197)
 				p.Severity()
 			}
 
 		case SfplParserTAGS:
 			{
-				p.SetState(127)
+				p.SetState(198)
 				p.Match(SfplParserTAGS)
 			}
 			{
-				p.SetState(128)
+				p.SetState(199)
 				p.Match(SfplParserDEF)
 			}
 			{
-				p.SetState(129)
+				p.SetState(200)
 				p.Tags()
 			}
 
 		case SfplParserPREFILTER:
 			{
-				p.SetState(130)
+				p.SetState(201)
 				p.Match(SfplParserPREFILTER)
 			}
 			{
-				p.SetState(131)
+				p.SetState(202)
 				p.Match(SfplParserDEF)
 			}
 			{
-				p.SetState(132)
+				p.SetState(203)
 				p.Prefilter()
 			}
 
 		case SfplParserENABLED:
 			{
-				p.SetState(133)
+				p.SetState(204)
 				p.Match(SfplParserENABLED)
 			}
 			{
-				p.SetState(134)
+				p.SetState(205)
 				p.Match(SfplParserDEF)
 			}
 			{
-				p.SetState(135)
+				p.SetState(206)
 				p.Enabled()
 			}
 
 		case SfplParserWARNEVTTYPE:
 			{
-				p.SetState(136)
+				p.SetState(207)
 				p.Match(SfplParserWARNEVTTYPE)
 			}
 			{
-				p.SetState(137)
+				p.SetState(208)
 				p.Match(SfplParserDEF)
 			}
 			{
-				p.SetState(138)
+				p.SetState(209)
 				p.Warnevttype()
 			}
 
 		case SfplParserSKIPUNKNOWN:
 			{
-				p.SetState(139)
+				p.SetState(210)
 				p.Match(SfplParserSKIPUNKNOWN)
 			}
 			{
-				p.SetState(140)
+				p.SetState(211)
 				p.Match(SfplParserDEF)
 			}
 			{
-				p.SetState(141)
+				p.SetState(212)
 				p.Skipunknown()
 			}
 
-		default:
-			panic(antlr.NewNoViableAltException(p, nil, nil, nil, nil, nil))
-		}
-
-		p.SetState(146)
-		p.GetErrorHandler().Sync(p)
-		_la = p.GetTokenStream().LA(1)
-	}
-
-	return localctx
-}
+		case SfplParserEXCEPTIONS:
+			{
+				p.SetState(213)
+				p.Match(SfplParserEXCEPTIONS)
+			}
+			{
+				p.SetState(214)
+				p.Match(SfplParserDEF)
+			}
+			{
+				p.SetState(215)
+				p.Exceptions()
+			}
 
-// IPfilterContext is an interface to support dynamic dispatch.
-type IPfilterContext interface {
+		case SfplParserKEY:
+			{
+				p.SetState(216)
+				p.Match(SfplParserKEY)
+			}
+			{
+				p.SetState(217)
+				p.Match(SfplParserDEF)
+			}
+			{
+				p.SetState(218)
+				p.Fields()
+			}
+
+		case SfplParserWINDOW:
+			{
+				p.SetState(219)
+				p.Match(SfplParserWINDOW)
+			}
+			{
+				p.SetState(220)
+				p.Match(SfplParserDEF)
+			}
+			{
+				p.SetState(221)
+				p.Atom()
+			}
+
+		case SfplParserSTEPS:
+			{
+				p.SetState(222)
+				p.Match(SfplParserSTEPS)
+			}
+			{
+				p.SetState(223)
+				p.Match(SfplParserDEF)
+			}
+			{
+				p.SetState(224)
+				p.Steps()
+			}
+
+		case SfplParserFAPPEND:
+			{
+				p.SetState(225)
+				p.Match(SfplParserFAPPEND)
+			}
+			{
+				p.SetState(226)
+				p.Match(SfplParserDEF)
+			}
+			{
+				p.SetState(227)
+				p.Fappend()
+			}
+
+		default:
+			panic(antlr.NewNoViableAltException(p, nil, nil, nil, nil, nil))
+		}
+
+		p.SetState(232)
+		p.GetErrorHandler().Sync(p)
+		_la = p.GetTokenStream().LA(1)
+	}
+
+	return localctx
+}
+
+// IPfilterContext is an interface to support dynamic dispatch.
+type IPfilterContext interface {
 	antlr.ParserRuleContext
 
 	// GetParser returns the parser.
@@ -1984,48 +2749,48 @@ func (p *SfplParser) Pfilter() (localctx IPfilterContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(147)
+		p.SetState(233)
 		p.Match(SfplParserDECL)
 	}
 	{
-		p.SetState(148)
+		p.SetState(234)
 		p.Match(SfplParserFILTER)
 	}
 	{
-		p.SetState(149)
+		p.SetState(235)
 		p.Match(SfplParserDEF)
 	}
 	{
-		p.SetState(150)
+		p.SetState(236)
 		p.Match(SfplParserID)
 	}
 	{
-		p.SetState(151)
+		p.SetState(237)
 		p.Match(SfplParserCOND)
 	}
 	{
-		p.SetState(152)
+		p.SetState(238)
 		p.Match(SfplParserDEF)
 	}
 	{
-		p.SetState(153)
+		p.SetState(239)
 		p.Expression()
 	}
-	p.SetState(157)
+	p.SetState(243)
 	p.GetErrorHandler().Sync(p)
 	_la = p.GetTokenStream().LA(1)
 
 	if _la == SfplParserENABLED {
 		{
-			p.SetState(154)
+			p.SetState(240)
 			p.Match(SfplParserENABLED)
 		}
 		{
-			p.SetState(155)
+			p.SetState(241)
 			p.Match(SfplParserDEF)
 		}
 		{
-			p.SetState(156)
+			p.SetState(242)
 			p.Enabled()
 		}
 
@@ -2173,48 +2938,48 @@ func (p *SfplParser) Sfilter() (localctx ISfilterContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(159)
+		p.SetState(245)
 		p.Match(SfplParserDECL)
 	}
 	{
-		p.SetState(160)
+		p.SetState(246)
 		p.Match(SfplParserFILTER)
 	}
 	{
-		p.SetState(161)
+		p.SetState(247)
 		p.Match(SfplParserDEF)
 	}
 	{
-		p.SetState(162)
+		p.SetState(248)
 		p.Match(SfplParserID)
 	}
 	{
-		p.SetState(163)
+		p.SetState(249)
 		p.Match(SfplParserCOND)
 	}
 	{
-		p.SetState(164)
+		p.SetState(250)
 		p.Match(SfplParserDEF)
 	}
 	{
-		p.SetState(165)
+		p.SetState(251)
 		p.Expression()
 	}
-	p.SetState(169)
+	p.SetState(255)
 	p.GetErrorHandler().Sync(p)
 	_la = p.GetTokenStream().LA(1)
 
 	if _la == SfplParserENABLED {
 		{
-			p.SetState(166)
+			p.SetState(252)
 			p.Match(SfplParserENABLED)
 		}
 		{
-			p.SetState(167)
+			p.SetState(253)
 			p.Match(SfplParserDEF)
 		}
 		{
-			p.SetState(168)
+			p.SetState(254)
 			p.Enabled()
 		}
 
@@ -2277,8 +3042,14 @@ func (s *PmacroContext) DEF(i int) antlr.TerminalNode {
 	return s.GetToken(SfplParserDEF, i)
 }
 
-func (s *PmacroContext) ID() antlr.TerminalNode {
-	return s.GetToken(SfplParserID, 0)
+func (s *PmacroContext) Identifier() IIdentifierContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IIdentifierContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IIdentifierContext)
 }
 
 func (s *PmacroContext) COND() antlr.TerminalNode {
@@ -2295,12 +3066,29 @@ func (s *PmacroContext) Expression() IExpressionContext {
 	return t.(IExpressionContext)
 }
 
-func (s *PmacroContext) FAPPEND() antlr.TerminalNode {
-	return s.GetToken(SfplParserFAPPEND, 0)
+func (s *PmacroContext) AllFAPPEND() []antlr.TerminalNode {
+	return s.GetTokens(SfplParserFAPPEND)
+}
+
+func (s *PmacroContext) FAPPEND(i int) antlr.TerminalNode {
+	return s.GetToken(SfplParserFAPPEND, i)
+}
+
+func (s *PmacroContext) AllFappend() []IFappendContext {
+	var ts = s.GetTypedRuleContexts(reflect.TypeOf((*IFappendContext)(nil)).Elem())
+	var tst = make([]IFappendContext, len(ts))
+
+	for i, t := range ts {
+		if t != nil {
+			tst[i] = t.(IFappendContext)
+		}
+	}
+
+	return tst
 }
 
-func (s *PmacroContext) Fappend() IFappendContext {
-	var t = s.GetTypedRuleContext(reflect.TypeOf((*IFappendContext)(nil)).Elem(), 0)
+func (s *PmacroContext) Fappend(i int) IFappendContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IFappendContext)(nil)).Elem(), i)
 
 	if t == nil {
 		return nil
@@ -2309,6 +3097,14 @@ func (s *PmacroContext) Fappend() IFappendContext {
 	return t.(IFappendContext)
 }
 
+func (s *PmacroContext) OR() antlr.TerminalNode {
+	return s.GetToken(SfplParserOR, 0)
+}
+
+func (s *PmacroContext) AND() antlr.TerminalNode {
+	return s.GetToken(SfplParserAND, 0)
+}
+
 func (s *PmacroContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
@@ -2362,48 +3158,85 @@ func (p *SfplParser) Pmacro() (localctx IPmacroContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(171)
+		p.SetState(257)
 		p.Match(SfplParserDECL)
 	}
 	{
-		p.SetState(172)
+		p.SetState(258)
 		p.Match(SfplParserMACRO)
 	}
 	{
-		p.SetState(173)
+		p.SetState(259)
 		p.Match(SfplParserDEF)
 	}
 	{
-		p.SetState(174)
-		p.Match(SfplParserID)
+		p.SetState(260)
+		p.Identifier()
+	}
+	p.SetState(264)
+	p.GetErrorHandler().Sync(p)
+	_la = p.GetTokenStream().LA(1)
+
+	if _la == SfplParserFAPPEND {
+		{
+			p.SetState(261)
+			p.Match(SfplParserFAPPEND)
+		}
+		{
+			p.SetState(262)
+			p.Match(SfplParserDEF)
+		}
+		{
+			p.SetState(263)
+			p.Fappend()
+		}
+
 	}
 	{
-		p.SetState(175)
+		p.SetState(266)
 		p.Match(SfplParserCOND)
 	}
 	{
-		p.SetState(176)
+		p.SetState(267)
 		p.Match(SfplParserDEF)
 	}
+	p.SetState(269)
+	p.GetErrorHandler().Sync(p)
+	_la = p.GetTokenStream().LA(1)
+
+	if _la == SfplParserAND || _la == SfplParserOR {
+		{
+			p.SetState(268)
+			_la = p.GetTokenStream().LA(1)
+
+			if !(_la == SfplParserAND || _la == SfplParserOR) {
+				p.GetErrorHandler().RecoverInline(p)
+			} else {
+				p.GetErrorHandler().ReportMatch(p)
+				p.Consume()
+			}
+		}
+
+	}
 	{
-		p.SetState(177)
+		p.SetState(271)
 		p.Expression()
 	}
-	p.SetState(181)
+	p.SetState(275)
 	p.GetErrorHandler().Sync(p)
 	_la = p.GetTokenStream().LA(1)
 
 	if _la == SfplParserFAPPEND {
 		{
-			p.SetState(178)
+			p.SetState(272)
 			p.Match(SfplParserFAPPEND)
 		}
 		{
-			p.SetState(179)
+			p.SetState(273)
 			p.Match(SfplParserDEF)
 		}
 		{
-			p.SetState(180)
+			p.SetState(274)
 			p.Fappend()
 		}
 
@@ -2466,8 +3299,14 @@ func (s *PlistContext) DEF(i int) antlr.TerminalNode {
 	return s.GetToken(SfplParserDEF, i)
 }
 
-func (s *PlistContext) ID() antlr.TerminalNode {
-	return s.GetToken(SfplParserID, 0)
+func (s *PlistContext) Identifier() IIdentifierContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IIdentifierContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IIdentifierContext)
 }
 
 func (s *PlistContext) ITEMS() antlr.TerminalNode {
@@ -2484,6 +3323,37 @@ func (s *PlistContext) Items() IItemsContext {
 	return t.(IItemsContext)
 }
 
+func (s *PlistContext) AllFAPPEND() []antlr.TerminalNode {
+	return s.GetTokens(SfplParserFAPPEND)
+}
+
+func (s *PlistContext) FAPPEND(i int) antlr.TerminalNode {
+	return s.GetToken(SfplParserFAPPEND, i)
+}
+
+func (s *PlistContext) AllFappend() []IFappendContext {
+	var ts = s.GetTypedRuleContexts(reflect.TypeOf((*IFappendContext)(nil)).Elem())
+	var tst = make([]IFappendContext, len(ts))
+
+	for i, t := range ts {
+		if t != nil {
+			tst[i] = t.(IFappendContext)
+		}
+	}
+
+	return tst
+}
+
+func (s *PlistContext) Fappend(i int) IFappendContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IFappendContext)(nil)).Elem(), i)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IFappendContext)
+}
+
 func (s *PlistContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
@@ -2517,6 +3387,7 @@ func (s *PlistContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
 func (p *SfplParser) Plist() (localctx IPlistContext) {
 	localctx = NewPlistContext(p, p.GetParserRuleContext(), p.GetState())
 	p.EnterRule(localctx, 14, SfplParserRULE_plist)
+	var _la int
 
 	defer func() {
 		p.ExitRule()
@@ -2536,33 +3407,71 @@ func (p *SfplParser) Plist() (localctx IPlistContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(183)
+		p.SetState(277)
 		p.Match(SfplParserDECL)
 	}
 	{
-		p.SetState(184)
+		p.SetState(278)
 		p.Match(SfplParserLIST)
 	}
 	{
-		p.SetState(185)
+		p.SetState(279)
 		p.Match(SfplParserDEF)
 	}
 	{
-		p.SetState(186)
-		p.Match(SfplParserID)
+		p.SetState(280)
+		p.Identifier()
+	}
+	p.SetState(284)
+	p.GetErrorHandler().Sync(p)
+	_la = p.GetTokenStream().LA(1)
+
+	if _la == SfplParserFAPPEND {
+		{
+			p.SetState(281)
+			p.Match(SfplParserFAPPEND)
+		}
+		{
+			p.SetState(282)
+			p.Match(SfplParserDEF)
+		}
+		{
+			p.SetState(283)
+			p.Fappend()
+		}
+
 	}
 	{
-		p.SetState(187)
+		p.SetState(286)
 		p.Match(SfplParserITEMS)
 	}
 	{
-		p.SetState(188)
+		p.SetState(287)
 		p.Match(SfplParserDEF)
 	}
 	{
-		p.SetState(189)
+		p.SetState(288)
 		p.Items()
 	}
+	p.SetState(292)
+	p.GetErrorHandler().Sync(p)
+	_la = p.GetTokenStream().LA(1)
+
+	if _la == SfplParserFAPPEND {
+		{
+			p.SetState(289)
+			p.Match(SfplParserFAPPEND)
+		}
+		{
+			p.SetState(290)
+			p.Match(SfplParserDEF)
+		}
+		{
+			p.SetState(291)
+			p.Fappend()
+		}
+
+	}
 
 	return localctx
 }
@@ -2679,19 +3588,19 @@ func (p *SfplParser) Preq() (localctx IPreqContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(191)
+		p.SetState(294)
 		p.Match(SfplParserDECL)
 	}
 	{
-		p.SetState(192)
+		p.SetState(295)
 		p.Match(SfplParserREQ)
 	}
 	{
-		p.SetState(193)
+		p.SetState(296)
 		p.Match(SfplParserDEF)
 	}
 	{
-		p.SetState(194)
+		p.SetState(297)
 		p.Atom()
 	}
 
@@ -2798,7 +3707,7 @@ func (p *SfplParser) Expression() (localctx IExpressionContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(196)
+		p.SetState(299)
 		p.Or_expression()
 	}
 
@@ -2927,24 +3836,24 @@ func (p *SfplParser) Or_expression() (localctx IOr_expressionContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(198)
+		p.SetState(301)
 		p.And_expression()
 	}
-	p.SetState(203)
+	p.SetState(306)
 	p.GetErrorHandler().Sync(p)
 	_la = p.GetTokenStream().LA(1)
 
 	for _la == SfplParserOR {
 		{
-			p.SetState(199)
+			p.SetState(302)
 			p.Match(SfplParserOR)
 		}
 		{
-			p.SetState(200)
+			p.SetState(303)
 			p.And_expression()
 		}
 
-		p.SetState(205)
+		p.SetState(308)
 		p.GetErrorHandler().Sync(p)
 		_la = p.GetTokenStream().LA(1)
 	}
@@ -3074,24 +3983,24 @@ func (p *SfplParser) And_expression() (localctx IAnd_expressionContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(206)
+		p.SetState(309)
 		p.Term()
 	}
-	p.SetState(211)
+	p.SetState(314)
 	p.GetErrorHandler().Sync(p)
 	_la = p.GetTokenStream().LA(1)
 
 	for _la == SfplParserAND {
 		{
-			p.SetState(207)
+			p.SetState(310)
 			p.Match(SfplParserAND)
 		}
 		{
-			p.SetState(208)
+			p.SetState(311)
 			p.Term()
 		}
 
-		p.SetState(213)
+		p.SetState(316)
 		p.GetErrorHandler().Sync(p)
 		_la = p.GetTokenStream().LA(1)
 	}
@@ -3216,10 +4125,18 @@ func (s *TermContext) IN() antlr.TerminalNode {
 	return s.GetToken(SfplParserIN, 0)
 }
 
+func (s *TermContext) IIN() antlr.TerminalNode {
+	return s.GetToken(SfplParserIIN, 0)
+}
+
 func (s *TermContext) PMATCH() antlr.TerminalNode {
 	return s.GetToken(SfplParserPMATCH, 0)
 }
 
+func (s *TermContext) INCIDR() antlr.TerminalNode {
+	return s.GetToken(SfplParserINCIDR, 0)
+}
+
 func (s *TermContext) AllItems() []IItemsContext {
 	var ts = s.GetTypedRuleContexts(reflect.TypeOf((*IItemsContext)(nil)).Elem())
 	var tst = make([]IItemsContext, len(ts))
@@ -3261,6 +4178,39 @@ func (s *TermContext) Expression() IExpressionContext {
 	return t.(IExpressionContext)
 }
 
+func (s *TermContext) Aggregate() IAggregateContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IAggregateContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IAggregateContext)
+}
+
+func (s *TermContext) AllArith_expression() []IArith_expressionContext {
+	var ts = s.GetTypedRuleContexts(reflect.TypeOf((*IArith_expressionContext)(nil)).Elem())
+	var tst = make([]IArith_expressionContext, len(ts))
+
+	for i, t := range ts {
+		if t != nil {
+			tst[i] = t.(IArith_expressionContext)
+		}
+	}
+
+	return tst
+}
+
+func (s *TermContext) Arith_expression(i int) IArith_expressionContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IArith_expressionContext)(nil)).Elem(), i)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IArith_expressionContext)
+}
+
 func (s *TermContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
@@ -3312,64 +4262,64 @@ func (p *SfplParser) Term() (localctx ITermContext) {
 		}
 	}()
 
-	p.SetState(247)
+	p.SetState(355)
 	p.GetErrorHandler().Sync(p)
-	switch p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 16, p.GetParserRuleContext()) {
+	switch p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 28, p.GetParserRuleContext()) {
 	case 1:
 		p.EnterOuterAlt(localctx, 1)
 		{
-			p.SetState(214)
+			p.SetState(317)
 			p.Variable()
 		}
 
 	case 2:
 		p.EnterOuterAlt(localctx, 2)
 		{
-			p.SetState(215)
+			p.SetState(318)
 			p.Match(SfplParserNOT)
 		}
 		{
-			p.SetState(216)
+			p.SetState(319)
 			p.Term()
 		}
 
 	case 3:
 		p.EnterOuterAlt(localctx, 3)
 		{
-			p.SetState(217)
+			p.SetState(320)
 			p.Atom()
 		}
 		{
-			p.SetState(218)
+			p.SetState(321)
 			p.Unary_operator()
 		}
 
 	case 4:
 		p.EnterOuterAlt(localctx, 4)
 		{
-			p.SetState(220)
+			p.SetState(323)
 			p.Atom()
 		}
 		{
-			p.SetState(221)
+			p.SetState(324)
 			p.Binary_operator()
 		}
 		{
-			p.SetState(222)
+			p.SetState(325)
 			p.Atom()
 		}
 
 	case 5:
 		p.EnterOuterAlt(localctx, 5)
 		{
-			p.SetState(224)
+			p.SetState(327)
 			p.Atom()
 		}
 		{
-			p.SetState(225)
+			p.SetState(328)
 			_la = p.GetTokenStream().LA(1)
 
-			if !(_la == SfplParserIN || _la == SfplParserPMATCH) {
+			if !(((_la-39)&-(0x1f+1)) == 0 && ((1<<uint((_la-39)))&((1<<(SfplParserIN-39))|(1<<(SfplParserIIN-39))|(1<<(SfplParserPMATCH-39))|(1<<(SfplParserINCIDR-39)))) != 0) {
 				p.GetErrorHandler().RecoverInline(p)
 			} else {
 				p.GetErrorHandler().ReportMatch(p)
@@ -3377,50 +4327,50 @@ func (p *SfplParser) Term() (localctx ITermContext) {
 			}
 		}
 		{
-			p.SetState(226)
+			p.SetState(329)
 			p.Match(SfplParserLPAREN)
 		}
-		p.SetState(229)
+		p.SetState(332)
 		p.GetErrorHandler().Sync(p)
 
 		switch p.GetTokenStream().LA(1) {
-		case SfplParserLT, SfplParserGT, SfplParserID, SfplParserNUMBER, SfplParserPATH, SfplParserSTRING, SfplParserTAG:
+		case SfplParserEXCEPTIONS, SfplParserFIELDS, SfplParserCOMPS, SfplParserVALUES, SfplParserSEQUENCE, SfplParserKEY, SfplParserWINDOW, SfplParserSTEPS, SfplParserWITHIN, SfplParserBY, SfplParserLT, SfplParserGT, SfplParserIEQ, SfplParserIIN, SfplParserISTARTSWITH, SfplParserIENDSWITH, SfplParserMATCHES, SfplParserIMATCHES, SfplParserINCIDR, SfplParserDIV, SfplParserID, SfplParserNUMBER, SfplParserPATH, SfplParserSTRING, SfplParserTAG:
 			{
-				p.SetState(227)
+				p.SetState(330)
 				p.Atom()
 			}
 
 		case SfplParserLBRACK:
 			{
-				p.SetState(228)
+				p.SetState(331)
 				p.Items()
 			}
 
 		default:
 			panic(antlr.NewNoViableAltException(p, nil, nil, nil, nil, nil))
 		}
-		p.SetState(238)
+		p.SetState(341)
 		p.GetErrorHandler().Sync(p)
 		_la = p.GetTokenStream().LA(1)
 
 		for _la == SfplParserLISTSEP {
 			{
-				p.SetState(231)
+				p.SetState(334)
 				p.Match(SfplParserLISTSEP)
 			}
-			p.SetState(234)
+			p.SetState(337)
 			p.GetErrorHandler().Sync(p)
 
 			switch p.GetTokenStream().LA(1) {
-			case SfplParserLT, SfplParserGT, SfplParserID, SfplParserNUMBER, SfplParserPATH, SfplParserSTRING, SfplParserTAG:
+			case SfplParserEXCEPTIONS, SfplParserFIELDS, SfplParserCOMPS, SfplParserVALUES, SfplParserSEQUENCE, SfplParserKEY, SfplParserWINDOW, SfplParserSTEPS, SfplParserWITHIN, SfplParserBY, SfplParserLT, SfplParserGT, SfplParserIEQ, SfplParserIIN, SfplParserISTARTSWITH, SfplParserIENDSWITH, SfplParserMATCHES, SfplParserIMATCHES, SfplParserINCIDR, SfplParserDIV, SfplParserID, SfplParserNUMBER, SfplParserPATH, SfplParserSTRING, SfplParserTAG:
 				{
-					p.SetState(232)
+					p.SetState(335)
 					p.Atom()
 				}
 
 			case SfplParserLBRACK:
 				{
-					p.SetState(233)
+					p.SetState(336)
 					p.Items()
 				}
 
@@ -3428,82 +4378,118 @@ func (p *SfplParser) Term() (localctx ITermContext) {
 				panic(antlr.NewNoViableAltException(p, nil, nil, nil, nil, nil))
 			}
 
-			p.SetState(240)
+			p.SetState(343)
 			p.GetErrorHandler().Sync(p)
 			_la = p.GetTokenStream().LA(1)
 		}
 		{
-			p.SetState(241)
+			p.SetState(344)
 			p.Match(SfplParserRPAREN)
 		}
 
 	case 6:
 		p.EnterOuterAlt(localctx, 6)
 		{
-			p.SetState(243)
+			p.SetState(346)
 			p.Match(SfplParserLPAREN)
 		}
 		{
-			p.SetState(244)
+			p.SetState(347)
 			p.Expression()
 		}
 		{
-			p.SetState(245)
+			p.SetState(348)
 			p.Match(SfplParserRPAREN)
 		}
 
+	case 7:
+		p.EnterOuterAlt(localctx, 7)
+		{
+			p.SetState(350)
+			p.Aggregate()
+		}
+
+	case 8:
+		p.EnterOuterAlt(localctx, 8)
+		{
+			p.SetState(351)
+			p.Arith_expression()
+		}
+		{
+			p.SetState(352)
+			p.Binary_operator()
+		}
+		{
+			p.SetState(353)
+			p.Arith_expression()
+		}
+
 	}
 
 	return localctx
 }
 
-// IItemsContext is an interface to support dynamic dispatch.
-type IItemsContext interface {
+// IAggregateContext is an interface to support dynamic dispatch.
+type IAggregateContext interface {
 	antlr.ParserRuleContext
 
 	// GetParser returns the parser.
 	GetParser() antlr.Parser
 
-	// IsItemsContext differentiates from other interfaces.
-	IsItemsContext()
+	// IsAggregateContext differentiates from other interfaces.
+	IsAggregateContext()
 }
 
-type ItemsContext struct {
+type AggregateContext struct {
 	*antlr.BaseParserRuleContext
 	parser antlr.Parser
 }
 
-func NewEmptyItemsContext() *ItemsContext {
-	var p = new(ItemsContext)
+func NewEmptyAggregateContext() *AggregateContext {
+	var p = new(AggregateContext)
 	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
-	p.RuleIndex = SfplParserRULE_items
+	p.RuleIndex = SfplParserRULE_aggregate
 	return p
 }
 
-func (*ItemsContext) IsItemsContext() {}
+func (*AggregateContext) IsAggregateContext() {}
 
-func NewItemsContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *ItemsContext {
-	var p = new(ItemsContext)
+func NewAggregateContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *AggregateContext {
+	var p = new(AggregateContext)
 
 	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
 
 	p.parser = parser
-	p.RuleIndex = SfplParserRULE_items
+	p.RuleIndex = SfplParserRULE_aggregate
 
 	return p
 }
 
-func (s *ItemsContext) GetParser() antlr.Parser { return s.parser }
+func (s *AggregateContext) GetParser() antlr.Parser { return s.parser }
 
-func (s *ItemsContext) LBRACK() antlr.TerminalNode {
-	return s.GetToken(SfplParserLBRACK, 0)
+func (s *AggregateContext) ID() antlr.TerminalNode {
+	return s.GetToken(SfplParserID, 0)
 }
 
-func (s *ItemsContext) RBRACK() antlr.TerminalNode {
-	return s.GetToken(SfplParserRBRACK, 0)
+func (s *AggregateContext) LPAREN() antlr.TerminalNode {
+	return s.GetToken(SfplParserLPAREN, 0)
 }
 
-func (s *ItemsContext) AllAtom() []IAtomContext {
+func (s *AggregateContext) RPAREN() antlr.TerminalNode {
+	return s.GetToken(SfplParserRPAREN, 0)
+}
+
+func (s *AggregateContext) Binary_operator() IBinary_operatorContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IBinary_operatorContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IBinary_operatorContext)
+}
+
+func (s *AggregateContext) AllAtom() []IAtomContext {
 	var ts = s.GetTypedRuleContexts(reflect.TypeOf((*IAtomContext)(nil)).Elem())
 	var tst = make([]IAtomContext, len(ts))
 
@@ -3516,7 +4502,7 @@ func (s *ItemsContext) AllAtom() []IAtomContext {
 	return tst
 }
 
-func (s *ItemsContext) Atom(i int) IAtomContext {
+func (s *AggregateContext) Atom(i int) IAtomContext {
 	var t = s.GetTypedRuleContext(reflect.TypeOf((*IAtomContext)(nil)).Elem(), i)
 
 	if t == nil {
@@ -3526,47 +4512,55 @@ func (s *ItemsContext) Atom(i int) IAtomContext {
 	return t.(IAtomContext)
 }
 
-func (s *ItemsContext) AllLISTSEP() []antlr.TerminalNode {
+func (s *AggregateContext) WITHIN() antlr.TerminalNode {
+	return s.GetToken(SfplParserWITHIN, 0)
+}
+
+func (s *AggregateContext) BY() antlr.TerminalNode {
+	return s.GetToken(SfplParserBY, 0)
+}
+
+func (s *AggregateContext) AllLISTSEP() []antlr.TerminalNode {
 	return s.GetTokens(SfplParserLISTSEP)
 }
 
-func (s *ItemsContext) LISTSEP(i int) antlr.TerminalNode {
+func (s *AggregateContext) LISTSEP(i int) antlr.TerminalNode {
 	return s.GetToken(SfplParserLISTSEP, i)
 }
 
-func (s *ItemsContext) GetRuleContext() antlr.RuleContext {
+func (s *AggregateContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
 
-func (s *ItemsContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+func (s *AggregateContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
 	return antlr.TreesStringTree(s, ruleNames, recog)
 }
 
-func (s *ItemsContext) EnterRule(listener antlr.ParseTreeListener) {
+func (s *AggregateContext) EnterRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(SfplListener); ok {
-		listenerT.EnterItems(s)
+		listenerT.EnterAggregate(s)
 	}
 }
 
-func (s *ItemsContext) ExitRule(listener antlr.ParseTreeListener) {
+func (s *AggregateContext) ExitRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(SfplListener); ok {
-		listenerT.ExitItems(s)
+		listenerT.ExitAggregate(s)
 	}
 }
 
-func (s *ItemsContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
+func (s *AggregateContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
 	switch t := visitor.(type) {
 	case SfplVisitor:
-		return t.VisitItems(s)
+		return t.VisitAggregate(s)
 
 	default:
 		return t.VisitChildren(s)
 	}
 }
 
-func (p *SfplParser) Items() (localctx IItemsContext) {
-	localctx = NewItemsContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 26, SfplParserRULE_items)
+func (p *SfplParser) Aggregate() (localctx IAggregateContext) {
+	localctx = NewAggregateContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 26, SfplParserRULE_aggregate)
 	var _la int
 
 	defer func() {
@@ -3585,173 +4579,193 @@ func (p *SfplParser) Items() (localctx IItemsContext) {
 		}
 	}()
 
-	var _alt int
-
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(249)
-		p.Match(SfplParserLBRACK)
+		p.SetState(357)
+		p.Match(SfplParserID)
+	}
+	{
+		p.SetState(358)
+		p.Match(SfplParserLPAREN)
 	}
-	p.SetState(258)
+	p.SetState(360)
 	p.GetErrorHandler().Sync(p)
 	_la = p.GetTokenStream().LA(1)
 
-	if ((_la-22)&-(0x1f+1)) == 0 && ((1<<uint((_la-22)))&((1<<(SfplParserLT-22))|(1<<(SfplParserGT-22))|(1<<(SfplParserID-22))|(1<<(SfplParserNUMBER-22))|(1<<(SfplParserPATH-22))|(1<<(SfplParserSTRING-22))|(1<<(SfplParserTAG-22)))) != 0 {
+	if (((_la-19)&-(0x1f+1)) == 0 && ((1<<uint((_la-19)))&((1<<(SfplParserEXCEPTIONS-19))|(1<<(SfplParserFIELDS-19))|(1<<(SfplParserCOMPS-19))|(1<<(SfplParserVALUES-19))|(1<<(SfplParserSEQUENCE-19))|(1<<(SfplParserKEY-19))|(1<<(SfplParserWINDOW-19))|(1<<(SfplParserSTEPS-19))|(1<<(SfplParserWITHIN-19))|(1<<(SfplParserBY-19))|(1<<(SfplParserLT-19))|(1<<(SfplParserGT-19))|(1<<(SfplParserIEQ-19))|(1<<(SfplParserIIN-19))|(1<<(SfplParserISTARTSWITH-19))|(1<<(SfplParserIENDSWITH-19))|(1<<(SfplParserMATCHES-19))|(1<<(SfplParserIMATCHES-19))|(1<<(SfplParserINCIDR-19)))) != 0) || (((_la-54)&-(0x1f+1)) == 0 && ((1<<uint((_la-54)))&((1<<(SfplParserDIV-54))|(1<<(SfplParserID-54))|(1<<(SfplParserNUMBER-54))|(1<<(SfplParserPATH-54))|(1<<(SfplParserSTRING-54))|(1<<(SfplParserTAG-54)))) != 0) {
 		{
-			p.SetState(250)
+			p.SetState(359)
 			p.Atom()
 		}
-		p.SetState(255)
-		p.GetErrorHandler().Sync(p)
-		_alt = p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 17, p.GetParserRuleContext())
-
-		for _alt != 2 && _alt != antlr.ATNInvalidAltNumber {
-			if _alt == 1 {
-				{
-					p.SetState(251)
-					p.Match(SfplParserLISTSEP)
-				}
-				{
-					p.SetState(252)
-					p.Atom()
-				}
-
-			}
-			p.SetState(257)
-			p.GetErrorHandler().Sync(p)
-			_alt = p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 17, p.GetParserRuleContext())
-		}
 
 	}
-	p.SetState(261)
+	{
+		p.SetState(362)
+		p.Match(SfplParserRPAREN)
+	}
+	{
+		p.SetState(363)
+		p.Binary_operator()
+	}
+	{
+		p.SetState(364)
+		p.Atom()
+	}
+	{
+		p.SetState(365)
+		p.Match(SfplParserWITHIN)
+	}
+	{
+		p.SetState(366)
+		p.Atom()
+	}
+	p.SetState(376)
 	p.GetErrorHandler().Sync(p)
 	_la = p.GetTokenStream().LA(1)
 
-	if _la == SfplParserLISTSEP {
+	if _la == SfplParserBY {
 		{
-			p.SetState(260)
-			p.Match(SfplParserLISTSEP)
+			p.SetState(367)
+			p.Match(SfplParserBY)
+		}
+		{
+			p.SetState(368)
+			p.Atom()
+		}
+		p.SetState(373)
+		p.GetErrorHandler().Sync(p)
+		_la = p.GetTokenStream().LA(1)
+
+		for _la == SfplParserLISTSEP {
+			{
+				p.SetState(369)
+				p.Match(SfplParserLISTSEP)
+			}
+			{
+				p.SetState(370)
+				p.Atom()
+			}
+
+			p.SetState(375)
+			p.GetErrorHandler().Sync(p)
+			_la = p.GetTokenStream().LA(1)
 		}
 
 	}
-	{
-		p.SetState(263)
-		p.Match(SfplParserRBRACK)
-	}
 
 	return localctx
 }
 
-// ITagsContext is an interface to support dynamic dispatch.
-type ITagsContext interface {
+// IArith_expressionContext is an interface to support dynamic dispatch.
+type IArith_expressionContext interface {
 	antlr.ParserRuleContext
 
 	// GetParser returns the parser.
 	GetParser() antlr.Parser
 
-	// IsTagsContext differentiates from other interfaces.
-	IsTagsContext()
+	// IsArith_expressionContext differentiates from other interfaces.
+	IsArith_expressionContext()
 }
 
-type TagsContext struct {
+type Arith_expressionContext struct {
 	*antlr.BaseParserRuleContext
 	parser antlr.Parser
 }
 
-func NewEmptyTagsContext() *TagsContext {
-	var p = new(TagsContext)
+func NewEmptyArith_expressionContext() *Arith_expressionContext {
+	var p = new(Arith_expressionContext)
 	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
-	p.RuleIndex = SfplParserRULE_tags
+	p.RuleIndex = SfplParserRULE_arith_expression
 	return p
 }
 
-func (*TagsContext) IsTagsContext() {}
+func (*Arith_expressionContext) IsArith_expressionContext() {}
 
-func NewTagsContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *TagsContext {
-	var p = new(TagsContext)
+func NewArith_expressionContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *Arith_expressionContext {
+	var p = new(Arith_expressionContext)
 
 	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
 
 	p.parser = parser
-	p.RuleIndex = SfplParserRULE_tags
+	p.RuleIndex = SfplParserRULE_arith_expression
 
 	return p
 }
 
-func (s *TagsContext) GetParser() antlr.Parser { return s.parser }
-
-func (s *TagsContext) LBRACK() antlr.TerminalNode {
-	return s.GetToken(SfplParserLBRACK, 0)
-}
-
-func (s *TagsContext) RBRACK() antlr.TerminalNode {
-	return s.GetToken(SfplParserRBRACK, 0)
-}
+func (s *Arith_expressionContext) GetParser() antlr.Parser { return s.parser }
 
-func (s *TagsContext) AllAtom() []IAtomContext {
-	var ts = s.GetTypedRuleContexts(reflect.TypeOf((*IAtomContext)(nil)).Elem())
-	var tst = make([]IAtomContext, len(ts))
+func (s *Arith_expressionContext) AllArith_term() []IArith_termContext {
+	var ts = s.GetTypedRuleContexts(reflect.TypeOf((*IArith_termContext)(nil)).Elem())
+	var tst = make([]IArith_termContext, len(ts))
 
 	for i, t := range ts {
 		if t != nil {
-			tst[i] = t.(IAtomContext)
+			tst[i] = t.(IArith_termContext)
 		}
 	}
 
 	return tst
 }
 
-func (s *TagsContext) Atom(i int) IAtomContext {
-	var t = s.GetTypedRuleContext(reflect.TypeOf((*IAtomContext)(nil)).Elem(), i)
+func (s *Arith_expressionContext) Arith_term(i int) IArith_termContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IArith_termContext)(nil)).Elem(), i)
 
 	if t == nil {
 		return nil
 	}
 
-	return t.(IAtomContext)
+	return t.(IArith_termContext)
 }
 
-func (s *TagsContext) AllLISTSEP() []antlr.TerminalNode {
-	return s.GetTokens(SfplParserLISTSEP)
+func (s *Arith_expressionContext) AllPLUS() []antlr.TerminalNode {
+	return s.GetTokens(SfplParserPLUS)
 }
 
-func (s *TagsContext) LISTSEP(i int) antlr.TerminalNode {
-	return s.GetToken(SfplParserLISTSEP, i)
+func (s *Arith_expressionContext) PLUS(i int) antlr.TerminalNode {
+	return s.GetToken(SfplParserPLUS, i)
 }
 
-func (s *TagsContext) GetRuleContext() antlr.RuleContext {
+func (s *Arith_expressionContext) AllDECL() []antlr.TerminalNode {
+	return s.GetTokens(SfplParserDECL)
+}
+
+func (s *Arith_expressionContext) DECL(i int) antlr.TerminalNode {
+	return s.GetToken(SfplParserDECL, i)
+}
+
+func (s *Arith_expressionContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
 
-func (s *TagsContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+func (s *Arith_expressionContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
 	return antlr.TreesStringTree(s, ruleNames, recog)
 }
 
-func (s *TagsContext) EnterRule(listener antlr.ParseTreeListener) {
+func (s *Arith_expressionContext) EnterRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(SfplListener); ok {
-		listenerT.EnterTags(s)
+		listenerT.EnterArith_expression(s)
 	}
 }
 
-func (s *TagsContext) ExitRule(listener antlr.ParseTreeListener) {
+func (s *Arith_expressionContext) ExitRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(SfplListener); ok {
-		listenerT.ExitTags(s)
+		listenerT.ExitArith_expression(s)
 	}
 }
 
-func (s *TagsContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
+func (s *Arith_expressionContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
 	switch t := visitor.(type) {
 	case SfplVisitor:
-		return t.VisitTags(s)
+		return t.VisitArith_expression(s)
 
 	default:
 		return t.VisitChildren(s)
 	}
 }
 
-func (p *SfplParser) Tags() (localctx ITagsContext) {
-	localctx = NewTagsContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 28, SfplParserRULE_tags)
+func (p *SfplParser) Arith_expression() (localctx IArith_expressionContext) {
+	localctx = NewArith_expressionContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 28, SfplParserRULE_arith_expression)
 	var _la int
 
 	defer func() {
@@ -3774,140 +4788,159 @@ func (p *SfplParser) Tags() (localctx ITagsContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(265)
-		p.Match(SfplParserLBRACK)
+		p.SetState(378)
+		p.Arith_term()
 	}
-	p.SetState(274)
+	p.SetState(383)
 	p.GetErrorHandler().Sync(p)
-	_la = p.GetTokenStream().LA(1)
+	_alt = p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 32, p.GetParserRuleContext())
 
-	if ((_la-22)&-(0x1f+1)) == 0 && ((1<<uint((_la-22)))&((1<<(SfplParserLT-22))|(1<<(SfplParserGT-22))|(1<<(SfplParserID-22))|(1<<(SfplParserNUMBER-22))|(1<<(SfplParserPATH-22))|(1<<(SfplParserSTRING-22))|(1<<(SfplParserTAG-22)))) != 0 {
-		{
-			p.SetState(266)
-			p.Atom()
-		}
-		p.SetState(271)
-		p.GetErrorHandler().Sync(p)
-		_alt = p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 20, p.GetParserRuleContext())
+	for _alt != 2 && _alt != antlr.ATNInvalidAltNumber {
+		if _alt == 1 {
+			{
+				p.SetState(379)
+				_la = p.GetTokenStream().LA(1)
 
-		for _alt != 2 && _alt != antlr.ATNInvalidAltNumber {
-			if _alt == 1 {
-				{
-					p.SetState(267)
-					p.Match(SfplParserLISTSEP)
-				}
-				{
-					p.SetState(268)
-					p.Atom()
+				if !(_la == SfplParserPLUS || _la == SfplParserDECL) {
+					p.GetErrorHandler().RecoverInline(p)
+				} else {
+					p.GetErrorHandler().ReportMatch(p)
+					p.Consume()
 				}
-
 			}
-			p.SetState(273)
-			p.GetErrorHandler().Sync(p)
-			_alt = p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 20, p.GetParserRuleContext())
-		}
-
-	}
-	p.SetState(277)
-	p.GetErrorHandler().Sync(p)
-	_la = p.GetTokenStream().LA(1)
+			{
+				p.SetState(380)
+				p.Arith_term()
+			}
 
-	if _la == SfplParserLISTSEP {
-		{
-			p.SetState(276)
-			p.Match(SfplParserLISTSEP)
 		}
-
-	}
-	{
-		p.SetState(279)
-		p.Match(SfplParserRBRACK)
+		p.SetState(385)
+		p.GetErrorHandler().Sync(p)
+		_alt = p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 32, p.GetParserRuleContext())
 	}
 
 	return localctx
 }
 
-// IPrefilterContext is an interface to support dynamic dispatch.
-type IPrefilterContext interface {
+// IArith_termContext is an interface to support dynamic dispatch.
+type IArith_termContext interface {
 	antlr.ParserRuleContext
 
 	// GetParser returns the parser.
 	GetParser() antlr.Parser
 
-	// IsPrefilterContext differentiates from other interfaces.
-	IsPrefilterContext()
+	// IsArith_termContext differentiates from other interfaces.
+	IsArith_termContext()
 }
 
-type PrefilterContext struct {
+type Arith_termContext struct {
 	*antlr.BaseParserRuleContext
 	parser antlr.Parser
 }
 
-func NewEmptyPrefilterContext() *PrefilterContext {
-	var p = new(PrefilterContext)
+func NewEmptyArith_termContext() *Arith_termContext {
+	var p = new(Arith_termContext)
 	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
-	p.RuleIndex = SfplParserRULE_prefilter
+	p.RuleIndex = SfplParserRULE_arith_term
 	return p
 }
 
-func (*PrefilterContext) IsPrefilterContext() {}
+func (*Arith_termContext) IsArith_termContext() {}
 
-func NewPrefilterContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *PrefilterContext {
-	var p = new(PrefilterContext)
+func NewArith_termContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *Arith_termContext {
+	var p = new(Arith_termContext)
 
 	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
 
 	p.parser = parser
-	p.RuleIndex = SfplParserRULE_prefilter
+	p.RuleIndex = SfplParserRULE_arith_term
 
 	return p
 }
 
-func (s *PrefilterContext) GetParser() antlr.Parser { return s.parser }
+func (s *Arith_termContext) GetParser() antlr.Parser { return s.parser }
 
-func (s *PrefilterContext) Items() IItemsContext {
-	var t = s.GetTypedRuleContext(reflect.TypeOf((*IItemsContext)(nil)).Elem(), 0)
+func (s *Arith_termContext) AllArith_factor() []IArith_factorContext {
+	var ts = s.GetTypedRuleContexts(reflect.TypeOf((*IArith_factorContext)(nil)).Elem())
+	var tst = make([]IArith_factorContext, len(ts))
+
+	for i, t := range ts {
+		if t != nil {
+			tst[i] = t.(IArith_factorContext)
+		}
+	}
+
+	return tst
+}
+
+func (s *Arith_termContext) Arith_factor(i int) IArith_factorContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IArith_factorContext)(nil)).Elem(), i)
 
 	if t == nil {
 		return nil
 	}
 
-	return t.(IItemsContext)
+	return t.(IArith_factorContext)
 }
 
-func (s *PrefilterContext) GetRuleContext() antlr.RuleContext {
+func (s *Arith_termContext) AllSTAR() []antlr.TerminalNode {
+	return s.GetTokens(SfplParserSTAR)
+}
+
+func (s *Arith_termContext) STAR(i int) antlr.TerminalNode {
+	return s.GetToken(SfplParserSTAR, i)
+}
+
+func (s *Arith_termContext) AllDIV() []antlr.TerminalNode {
+	return s.GetTokens(SfplParserDIV)
+}
+
+func (s *Arith_termContext) DIV(i int) antlr.TerminalNode {
+	return s.GetToken(SfplParserDIV, i)
+}
+
+func (s *Arith_termContext) AllMOD() []antlr.TerminalNode {
+	return s.GetTokens(SfplParserMOD)
+}
+
+func (s *Arith_termContext) MOD(i int) antlr.TerminalNode {
+	return s.GetToken(SfplParserMOD, i)
+}
+
+func (s *Arith_termContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
 
-func (s *PrefilterContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+func (s *Arith_termContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
 	return antlr.TreesStringTree(s, ruleNames, recog)
 }
 
-func (s *PrefilterContext) EnterRule(listener antlr.ParseTreeListener) {
+func (s *Arith_termContext) EnterRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(SfplListener); ok {
-		listenerT.EnterPrefilter(s)
+		listenerT.EnterArith_term(s)
 	}
 }
 
-func (s *PrefilterContext) ExitRule(listener antlr.ParseTreeListener) {
+func (s *Arith_termContext) ExitRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(SfplListener); ok {
-		listenerT.ExitPrefilter(s)
+		listenerT.ExitArith_term(s)
 	}
 }
 
-func (s *PrefilterContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
+func (s *Arith_termContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
 	switch t := visitor.(type) {
 	case SfplVisitor:
-		return t.VisitPrefilter(s)
+		return t.VisitArith_term(s)
 
 	default:
 		return t.VisitChildren(s)
 	}
 }
 
-func (p *SfplParser) Prefilter() (localctx IPrefilterContext) {
-	localctx = NewPrefilterContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 30, SfplParserRULE_prefilter)
+func (p *SfplParser) Arith_term() (localctx IArith_termContext) {
+	localctx = NewArith_termContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 30, SfplParserRULE_arith_term)
+	var _la int
 
 	defer func() {
 		p.ExitRule()
@@ -3927,78 +4960,921 @@ func (p *SfplParser) Prefilter() (localctx IPrefilterContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(281)
-		p.Items()
+		p.SetState(386)
+		p.Arith_factor()
+	}
+	p.SetState(391)
+	p.GetErrorHandler().Sync(p)
+	_la = p.GetTokenStream().LA(1)
+
+	for ((_la-53)&-(0x1f+1)) == 0 && ((1<<uint((_la-53)))&((1<<(SfplParserSTAR-53))|(1<<(SfplParserDIV-53))|(1<<(SfplParserMOD-53)))) != 0 {
+		{
+			p.SetState(387)
+			_la = p.GetTokenStream().LA(1)
+
+			if !(((_la-53)&-(0x1f+1)) == 0 && ((1<<uint((_la-53)))&((1<<(SfplParserSTAR-53))|(1<<(SfplParserDIV-53))|(1<<(SfplParserMOD-53)))) != 0) {
+				p.GetErrorHandler().RecoverInline(p)
+			} else {
+				p.GetErrorHandler().ReportMatch(p)
+				p.Consume()
+			}
+		}
+		{
+			p.SetState(388)
+			p.Arith_factor()
+		}
+
+		p.SetState(393)
+		p.GetErrorHandler().Sync(p)
+		_la = p.GetTokenStream().LA(1)
 	}
 
 	return localctx
 }
 
-// ISeverityContext is an interface to support dynamic dispatch.
-type ISeverityContext interface {
+// IArith_factorContext is an interface to support dynamic dispatch.
+type IArith_factorContext interface {
 	antlr.ParserRuleContext
 
 	// GetParser returns the parser.
 	GetParser() antlr.Parser
 
-	// IsSeverityContext differentiates from other interfaces.
-	IsSeverityContext()
+	// IsArith_factorContext differentiates from other interfaces.
+	IsArith_factorContext()
 }
 
-type SeverityContext struct {
+type Arith_factorContext struct {
 	*antlr.BaseParserRuleContext
 	parser antlr.Parser
 }
 
-func NewEmptySeverityContext() *SeverityContext {
-	var p = new(SeverityContext)
+func NewEmptyArith_factorContext() *Arith_factorContext {
+	var p = new(Arith_factorContext)
 	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
-	p.RuleIndex = SfplParserRULE_severity
+	p.RuleIndex = SfplParserRULE_arith_factor
 	return p
 }
 
-func (*SeverityContext) IsSeverityContext() {}
+func (*Arith_factorContext) IsArith_factorContext() {}
 
-func NewSeverityContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *SeverityContext {
-	var p = new(SeverityContext)
+func NewArith_factorContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *Arith_factorContext {
+	var p = new(Arith_factorContext)
 
 	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
 
 	p.parser = parser
-	p.RuleIndex = SfplParserRULE_severity
+	p.RuleIndex = SfplParserRULE_arith_factor
 
 	return p
 }
 
-func (s *SeverityContext) GetParser() antlr.Parser { return s.parser }
+func (s *Arith_factorContext) GetParser() antlr.Parser { return s.parser }
 
-func (s *SeverityContext) SEVERITY() antlr.TerminalNode {
-	return s.GetToken(SfplParserSEVERITY, 0)
-}
+func (s *Arith_factorContext) Function() IFunctionContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IFunctionContext)(nil)).Elem(), 0)
 
-func (s *SeverityContext) GetRuleContext() antlr.RuleContext {
-	return s
+	if t == nil {
+		return nil
+	}
+
+	return t.(IFunctionContext)
 }
 
-func (s *SeverityContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
-	return antlr.TreesStringTree(s, ruleNames, recog)
+func (s *Arith_factorContext) LPAREN() antlr.TerminalNode {
+	return s.GetToken(SfplParserLPAREN, 0)
 }
 
-func (s *SeverityContext) EnterRule(listener antlr.ParseTreeListener) {
-	if listenerT, ok := listener.(SfplListener); ok {
-		listenerT.EnterSeverity(s)
+func (s *Arith_factorContext) Arith_expression() IArith_expressionContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IArith_expressionContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
 	}
+
+	return t.(IArith_expressionContext)
 }
 
-func (s *SeverityContext) ExitRule(listener antlr.ParseTreeListener) {
-	if listenerT, ok := listener.(SfplListener); ok {
-		listenerT.ExitSeverity(s)
-	}
+func (s *Arith_factorContext) RPAREN() antlr.TerminalNode {
+	return s.GetToken(SfplParserRPAREN, 0)
 }
 
-func (s *SeverityContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
-	switch t := visitor.(type) {
-	case SfplVisitor:
+func (s *Arith_factorContext) Atom() IAtomContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IAtomContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IAtomContext)
+}
+
+func (s *Arith_factorContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *Arith_factorContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *Arith_factorContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SfplListener); ok {
+		listenerT.EnterArith_factor(s)
+	}
+}
+
+func (s *Arith_factorContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SfplListener); ok {
+		listenerT.ExitArith_factor(s)
+	}
+}
+
+func (s *Arith_factorContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
+	switch t := visitor.(type) {
+	case SfplVisitor:
+		return t.VisitArith_factor(s)
+
+	default:
+		return t.VisitChildren(s)
+	}
+}
+
+func (p *SfplParser) Arith_factor() (localctx IArith_factorContext) {
+	localctx = NewArith_factorContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 32, SfplParserRULE_arith_factor)
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
+
+	p.SetState(400)
+	p.GetErrorHandler().Sync(p)
+	switch p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 34, p.GetParserRuleContext()) {
+	case 1:
+		p.EnterOuterAlt(localctx, 1)
+		{
+			p.SetState(394)
+			p.Function()
+		}
+
+	case 2:
+		p.EnterOuterAlt(localctx, 2)
+		{
+			p.SetState(395)
+			p.Match(SfplParserLPAREN)
+		}
+		{
+			p.SetState(396)
+			p.Arith_expression()
+		}
+		{
+			p.SetState(397)
+			p.Match(SfplParserRPAREN)
+		}
+
+	case 3:
+		p.EnterOuterAlt(localctx, 3)
+		{
+			p.SetState(399)
+			p.Atom()
+		}
+
+	}
+
+	return localctx
+}
+
+// IFunctionContext is an interface to support dynamic dispatch.
+type IFunctionContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// IsFunctionContext differentiates from other interfaces.
+	IsFunctionContext()
+}
+
+type FunctionContext struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyFunctionContext() *FunctionContext {
+	var p = new(FunctionContext)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = SfplParserRULE_function
+	return p
+}
+
+func (*FunctionContext) IsFunctionContext() {}
+
+func NewFunctionContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *FunctionContext {
+	var p = new(FunctionContext)
+
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = SfplParserRULE_function
+
+	return p
+}
+
+func (s *FunctionContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *FunctionContext) ID() antlr.TerminalNode {
+	return s.GetToken(SfplParserID, 0)
+}
+
+func (s *FunctionContext) LPAREN() antlr.TerminalNode {
+	return s.GetToken(SfplParserLPAREN, 0)
+}
+
+func (s *FunctionContext) RPAREN() antlr.TerminalNode {
+	return s.GetToken(SfplParserRPAREN, 0)
+}
+
+func (s *FunctionContext) AllArith_expression() []IArith_expressionContext {
+	var ts = s.GetTypedRuleContexts(reflect.TypeOf((*IArith_expressionContext)(nil)).Elem())
+	var tst = make([]IArith_expressionContext, len(ts))
+
+	for i, t := range ts {
+		if t != nil {
+			tst[i] = t.(IArith_expressionContext)
+		}
+	}
+
+	return tst
+}
+
+func (s *FunctionContext) Arith_expression(i int) IArith_expressionContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IArith_expressionContext)(nil)).Elem(), i)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IArith_expressionContext)
+}
+
+func (s *FunctionContext) AllLISTSEP() []antlr.TerminalNode {
+	return s.GetTokens(SfplParserLISTSEP)
+}
+
+func (s *FunctionContext) LISTSEP(i int) antlr.TerminalNode {
+	return s.GetToken(SfplParserLISTSEP, i)
+}
+
+func (s *FunctionContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *FunctionContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *FunctionContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SfplListener); ok {
+		listenerT.EnterFunction(s)
+	}
+}
+
+func (s *FunctionContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SfplListener); ok {
+		listenerT.ExitFunction(s)
+	}
+}
+
+func (s *FunctionContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
+	switch t := visitor.(type) {
+	case SfplVisitor:
+		return t.VisitFunction(s)
+
+	default:
+		return t.VisitChildren(s)
+	}
+}
+
+func (p *SfplParser) Function() (localctx IFunctionContext) {
+	localctx = NewFunctionContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 34, SfplParserRULE_function)
+	var _la int
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
+
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(402)
+		p.Match(SfplParserID)
+	}
+	{
+		p.SetState(403)
+		p.Match(SfplParserLPAREN)
+	}
+	p.SetState(412)
+	p.GetErrorHandler().Sync(p)
+	_la = p.GetTokenStream().LA(1)
+
+	if (((_la-19)&-(0x1f+1)) == 0 && ((1<<uint((_la-19)))&((1<<(SfplParserEXCEPTIONS-19))|(1<<(SfplParserFIELDS-19))|(1<<(SfplParserCOMPS-19))|(1<<(SfplParserVALUES-19))|(1<<(SfplParserSEQUENCE-19))|(1<<(SfplParserKEY-19))|(1<<(SfplParserWINDOW-19))|(1<<(SfplParserSTEPS-19))|(1<<(SfplParserWITHIN-19))|(1<<(SfplParserBY-19))|(1<<(SfplParserLT-19))|(1<<(SfplParserGT-19))|(1<<(SfplParserIEQ-19))|(1<<(SfplParserIIN-19))|(1<<(SfplParserISTARTSWITH-19))|(1<<(SfplParserIENDSWITH-19))|(1<<(SfplParserMATCHES-19))|(1<<(SfplParserIMATCHES-19))|(1<<(SfplParserINCIDR-19)))) != 0) || (((_la-54)&-(0x1f+1)) == 0 && ((1<<uint((_la-54)))&((1<<(SfplParserDIV-54))|(1<<(SfplParserLPAREN-54))|(1<<(SfplParserID-54))|(1<<(SfplParserNUMBER-54))|(1<<(SfplParserPATH-54))|(1<<(SfplParserSTRING-54))|(1<<(SfplParserTAG-54)))) != 0) {
+		{
+			p.SetState(404)
+			p.Arith_expression()
+		}
+		p.SetState(409)
+		p.GetErrorHandler().Sync(p)
+		_la = p.GetTokenStream().LA(1)
+
+		for _la == SfplParserLISTSEP {
+			{
+				p.SetState(405)
+				p.Match(SfplParserLISTSEP)
+			}
+			{
+				p.SetState(406)
+				p.Arith_expression()
+			}
+
+			p.SetState(411)
+			p.GetErrorHandler().Sync(p)
+			_la = p.GetTokenStream().LA(1)
+		}
+
+	}
+	{
+		p.SetState(414)
+		p.Match(SfplParserRPAREN)
+	}
+
+	return localctx
+}
+
+// IItemsContext is an interface to support dynamic dispatch.
+type IItemsContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// IsItemsContext differentiates from other interfaces.
+	IsItemsContext()
+}
+
+type ItemsContext struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyItemsContext() *ItemsContext {
+	var p = new(ItemsContext)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = SfplParserRULE_items
+	return p
+}
+
+func (*ItemsContext) IsItemsContext() {}
+
+func NewItemsContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *ItemsContext {
+	var p = new(ItemsContext)
+
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = SfplParserRULE_items
+
+	return p
+}
+
+func (s *ItemsContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *ItemsContext) LBRACK() antlr.TerminalNode {
+	return s.GetToken(SfplParserLBRACK, 0)
+}
+
+func (s *ItemsContext) RBRACK() antlr.TerminalNode {
+	return s.GetToken(SfplParserRBRACK, 0)
+}
+
+func (s *ItemsContext) AllAtom() []IAtomContext {
+	var ts = s.GetTypedRuleContexts(reflect.TypeOf((*IAtomContext)(nil)).Elem())
+	var tst = make([]IAtomContext, len(ts))
+
+	for i, t := range ts {
+		if t != nil {
+			tst[i] = t.(IAtomContext)
+		}
+	}
+
+	return tst
+}
+
+func (s *ItemsContext) Atom(i int) IAtomContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IAtomContext)(nil)).Elem(), i)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IAtomContext)
+}
+
+func (s *ItemsContext) AllLISTSEP() []antlr.TerminalNode {
+	return s.GetTokens(SfplParserLISTSEP)
+}
+
+func (s *ItemsContext) LISTSEP(i int) antlr.TerminalNode {
+	return s.GetToken(SfplParserLISTSEP, i)
+}
+
+func (s *ItemsContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *ItemsContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *ItemsContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SfplListener); ok {
+		listenerT.EnterItems(s)
+	}
+}
+
+func (s *ItemsContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SfplListener); ok {
+		listenerT.ExitItems(s)
+	}
+}
+
+func (s *ItemsContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
+	switch t := visitor.(type) {
+	case SfplVisitor:
+		return t.VisitItems(s)
+
+	default:
+		return t.VisitChildren(s)
+	}
+}
+
+func (p *SfplParser) Items() (localctx IItemsContext) {
+	localctx = NewItemsContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 36, SfplParserRULE_items)
+	var _la int
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
+
+	var _alt int
+
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(416)
+		p.Match(SfplParserLBRACK)
+	}
+	p.SetState(425)
+	p.GetErrorHandler().Sync(p)
+	_la = p.GetTokenStream().LA(1)
+
+	if (((_la-19)&-(0x1f+1)) == 0 && ((1<<uint((_la-19)))&((1<<(SfplParserEXCEPTIONS-19))|(1<<(SfplParserFIELDS-19))|(1<<(SfplParserCOMPS-19))|(1<<(SfplParserVALUES-19))|(1<<(SfplParserSEQUENCE-19))|(1<<(SfplParserKEY-19))|(1<<(SfplParserWINDOW-19))|(1<<(SfplParserSTEPS-19))|(1<<(SfplParserWITHIN-19))|(1<<(SfplParserBY-19))|(1<<(SfplParserLT-19))|(1<<(SfplParserGT-19))|(1<<(SfplParserIEQ-19))|(1<<(SfplParserIIN-19))|(1<<(SfplParserISTARTSWITH-19))|(1<<(SfplParserIENDSWITH-19))|(1<<(SfplParserMATCHES-19))|(1<<(SfplParserIMATCHES-19))|(1<<(SfplParserINCIDR-19)))) != 0) || (((_la-54)&-(0x1f+1)) == 0 && ((1<<uint((_la-54)))&((1<<(SfplParserDIV-54))|(1<<(SfplParserID-54))|(1<<(SfplParserNUMBER-54))|(1<<(SfplParserPATH-54))|(1<<(SfplParserSTRING-54))|(1<<(SfplParserTAG-54)))) != 0) {
+		{
+			p.SetState(417)
+			p.Atom()
+		}
+		p.SetState(422)
+		p.GetErrorHandler().Sync(p)
+		_alt = p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 37, p.GetParserRuleContext())
+
+		for _alt != 2 && _alt != antlr.ATNInvalidAltNumber {
+			if _alt == 1 {
+				{
+					p.SetState(418)
+					p.Match(SfplParserLISTSEP)
+				}
+				{
+					p.SetState(419)
+					p.Atom()
+				}
+
+			}
+			p.SetState(424)
+			p.GetErrorHandler().Sync(p)
+			_alt = p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 37, p.GetParserRuleContext())
+		}
+
+	}
+	p.SetState(428)
+	p.GetErrorHandler().Sync(p)
+	_la = p.GetTokenStream().LA(1)
+
+	if _la == SfplParserLISTSEP {
+		{
+			p.SetState(427)
+			p.Match(SfplParserLISTSEP)
+		}
+
+	}
+	{
+		p.SetState(430)
+		p.Match(SfplParserRBRACK)
+	}
+
+	return localctx
+}
+
+// ITagsContext is an interface to support dynamic dispatch.
+type ITagsContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// IsTagsContext differentiates from other interfaces.
+	IsTagsContext()
+}
+
+type TagsContext struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyTagsContext() *TagsContext {
+	var p = new(TagsContext)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = SfplParserRULE_tags
+	return p
+}
+
+func (*TagsContext) IsTagsContext() {}
+
+func NewTagsContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *TagsContext {
+	var p = new(TagsContext)
+
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = SfplParserRULE_tags
+
+	return p
+}
+
+func (s *TagsContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *TagsContext) LBRACK() antlr.TerminalNode {
+	return s.GetToken(SfplParserLBRACK, 0)
+}
+
+func (s *TagsContext) RBRACK() antlr.TerminalNode {
+	return s.GetToken(SfplParserRBRACK, 0)
+}
+
+func (s *TagsContext) AllAtom() []IAtomContext {
+	var ts = s.GetTypedRuleContexts(reflect.TypeOf((*IAtomContext)(nil)).Elem())
+	var tst = make([]IAtomContext, len(ts))
+
+	for i, t := range ts {
+		if t != nil {
+			tst[i] = t.(IAtomContext)
+		}
+	}
+
+	return tst
+}
+
+func (s *TagsContext) Atom(i int) IAtomContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IAtomContext)(nil)).Elem(), i)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IAtomContext)
+}
+
+func (s *TagsContext) AllLISTSEP() []antlr.TerminalNode {
+	return s.GetTokens(SfplParserLISTSEP)
+}
+
+func (s *TagsContext) LISTSEP(i int) antlr.TerminalNode {
+	return s.GetToken(SfplParserLISTSEP, i)
+}
+
+func (s *TagsContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *TagsContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *TagsContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SfplListener); ok {
+		listenerT.EnterTags(s)
+	}
+}
+
+func (s *TagsContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SfplListener); ok {
+		listenerT.ExitTags(s)
+	}
+}
+
+func (s *TagsContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
+	switch t := visitor.(type) {
+	case SfplVisitor:
+		return t.VisitTags(s)
+
+	default:
+		return t.VisitChildren(s)
+	}
+}
+
+func (p *SfplParser) Tags() (localctx ITagsContext) {
+	localctx = NewTagsContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 38, SfplParserRULE_tags)
+	var _la int
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
+
+	var _alt int
+
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(432)
+		p.Match(SfplParserLBRACK)
+	}
+	p.SetState(441)
+	p.GetErrorHandler().Sync(p)
+	_la = p.GetTokenStream().LA(1)
+
+	if (((_la-19)&-(0x1f+1)) == 0 && ((1<<uint((_la-19)))&((1<<(SfplParserEXCEPTIONS-19))|(1<<(SfplParserFIELDS-19))|(1<<(SfplParserCOMPS-19))|(1<<(SfplParserVALUES-19))|(1<<(SfplParserSEQUENCE-19))|(1<<(SfplParserKEY-19))|(1<<(SfplParserWINDOW-19))|(1<<(SfplParserSTEPS-19))|(1<<(SfplParserWITHIN-19))|(1<<(SfplParserBY-19))|(1<<(SfplParserLT-19))|(1<<(SfplParserGT-19))|(1<<(SfplParserIEQ-19))|(1<<(SfplParserIIN-19))|(1<<(SfplParserISTARTSWITH-19))|(1<<(SfplParserIENDSWITH-19))|(1<<(SfplParserMATCHES-19))|(1<<(SfplParserIMATCHES-19))|(1<<(SfplParserINCIDR-19)))) != 0) || (((_la-54)&-(0x1f+1)) == 0 && ((1<<uint((_la-54)))&((1<<(SfplParserDIV-54))|(1<<(SfplParserID-54))|(1<<(SfplParserNUMBER-54))|(1<<(SfplParserPATH-54))|(1<<(SfplParserSTRING-54))|(1<<(SfplParserTAG-54)))) != 0) {
+		{
+			p.SetState(433)
+			p.Atom()
+		}
+		p.SetState(438)
+		p.GetErrorHandler().Sync(p)
+		_alt = p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 40, p.GetParserRuleContext())
+
+		for _alt != 2 && _alt != antlr.ATNInvalidAltNumber {
+			if _alt == 1 {
+				{
+					p.SetState(434)
+					p.Match(SfplParserLISTSEP)
+				}
+				{
+					p.SetState(435)
+					p.Atom()
+				}
+
+			}
+			p.SetState(440)
+			p.GetErrorHandler().Sync(p)
+			_alt = p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 40, p.GetParserRuleContext())
+		}
+
+	}
+	p.SetState(444)
+	p.GetErrorHandler().Sync(p)
+	_la = p.GetTokenStream().LA(1)
+
+	if _la == SfplParserLISTSEP {
+		{
+			p.SetState(443)
+			p.Match(SfplParserLISTSEP)
+		}
+
+	}
+	{
+		p.SetState(446)
+		p.Match(SfplParserRBRACK)
+	}
+
+	return localctx
+}
+
+// IPrefilterContext is an interface to support dynamic dispatch.
+type IPrefilterContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// IsPrefilterContext differentiates from other interfaces.
+	IsPrefilterContext()
+}
+
+type PrefilterContext struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyPrefilterContext() *PrefilterContext {
+	var p = new(PrefilterContext)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = SfplParserRULE_prefilter
+	return p
+}
+
+func (*PrefilterContext) IsPrefilterContext() {}
+
+func NewPrefilterContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *PrefilterContext {
+	var p = new(PrefilterContext)
+
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = SfplParserRULE_prefilter
+
+	return p
+}
+
+func (s *PrefilterContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *PrefilterContext) Items() IItemsContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IItemsContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IItemsContext)
+}
+
+func (s *PrefilterContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *PrefilterContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *PrefilterContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SfplListener); ok {
+		listenerT.EnterPrefilter(s)
+	}
+}
+
+func (s *PrefilterContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SfplListener); ok {
+		listenerT.ExitPrefilter(s)
+	}
+}
+
+func (s *PrefilterContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
+	switch t := visitor.(type) {
+	case SfplVisitor:
+		return t.VisitPrefilter(s)
+
+	default:
+		return t.VisitChildren(s)
+	}
+}
+
+func (p *SfplParser) Prefilter() (localctx IPrefilterContext) {
+	localctx = NewPrefilterContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 40, SfplParserRULE_prefilter)
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
+
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(448)
+		p.Items()
+	}
+
+	return localctx
+}
+
+// ISeverityContext is an interface to support dynamic dispatch.
+type ISeverityContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// IsSeverityContext differentiates from other interfaces.
+	IsSeverityContext()
+}
+
+type SeverityContext struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptySeverityContext() *SeverityContext {
+	var p = new(SeverityContext)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = SfplParserRULE_severity
+	return p
+}
+
+func (*SeverityContext) IsSeverityContext() {}
+
+func NewSeverityContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *SeverityContext {
+	var p = new(SeverityContext)
+
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = SfplParserRULE_severity
+
+	return p
+}
+
+func (s *SeverityContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *SeverityContext) SEVERITY() antlr.TerminalNode {
+	return s.GetToken(SfplParserSEVERITY, 0)
+}
+
+func (s *SeverityContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *SeverityContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *SeverityContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SfplListener); ok {
+		listenerT.EnterSeverity(s)
+	}
+}
+
+func (s *SeverityContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SfplListener); ok {
+		listenerT.ExitSeverity(s)
+	}
+}
+
+func (s *SeverityContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
+	switch t := visitor.(type) {
+	case SfplVisitor:
 		return t.VisitSeverity(s)
 
 	default:
@@ -4006,9 +5882,1473 @@ func (s *SeverityContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
 	}
 }
 
-func (p *SfplParser) Severity() (localctx ISeverityContext) {
-	localctx = NewSeverityContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 32, SfplParserRULE_severity)
+func (p *SfplParser) Severity() (localctx ISeverityContext) {
+	localctx = NewSeverityContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 42, SfplParserRULE_severity)
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
+
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(450)
+		p.Match(SfplParserSEVERITY)
+	}
+
+	return localctx
+}
+
+// IEnabledContext is an interface to support dynamic dispatch.
+type IEnabledContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// IsEnabledContext differentiates from other interfaces.
+	IsEnabledContext()
+}
+
+type EnabledContext struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyEnabledContext() *EnabledContext {
+	var p = new(EnabledContext)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = SfplParserRULE_enabled
+	return p
+}
+
+func (*EnabledContext) IsEnabledContext() {}
+
+func NewEnabledContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *EnabledContext {
+	var p = new(EnabledContext)
+
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = SfplParserRULE_enabled
+
+	return p
+}
+
+func (s *EnabledContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *EnabledContext) Atom() IAtomContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IAtomContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IAtomContext)
+}
+
+func (s *EnabledContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *EnabledContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *EnabledContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SfplListener); ok {
+		listenerT.EnterEnabled(s)
+	}
+}
+
+func (s *EnabledContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SfplListener); ok {
+		listenerT.ExitEnabled(s)
+	}
+}
+
+func (s *EnabledContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
+	switch t := visitor.(type) {
+	case SfplVisitor:
+		return t.VisitEnabled(s)
+
+	default:
+		return t.VisitChildren(s)
+	}
+}
+
+func (p *SfplParser) Enabled() (localctx IEnabledContext) {
+	localctx = NewEnabledContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 44, SfplParserRULE_enabled)
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
+
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(452)
+		p.Atom()
+	}
+
+	return localctx
+}
+
+// IWarnevttypeContext is an interface to support dynamic dispatch.
+type IWarnevttypeContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// IsWarnevttypeContext differentiates from other interfaces.
+	IsWarnevttypeContext()
+}
+
+type WarnevttypeContext struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyWarnevttypeContext() *WarnevttypeContext {
+	var p = new(WarnevttypeContext)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = SfplParserRULE_warnevttype
+	return p
+}
+
+func (*WarnevttypeContext) IsWarnevttypeContext() {}
+
+func NewWarnevttypeContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *WarnevttypeContext {
+	var p = new(WarnevttypeContext)
+
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = SfplParserRULE_warnevttype
+
+	return p
+}
+
+func (s *WarnevttypeContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *WarnevttypeContext) Atom() IAtomContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IAtomContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IAtomContext)
+}
+
+func (s *WarnevttypeContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *WarnevttypeContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *WarnevttypeContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SfplListener); ok {
+		listenerT.EnterWarnevttype(s)
+	}
+}
+
+func (s *WarnevttypeContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SfplListener); ok {
+		listenerT.ExitWarnevttype(s)
+	}
+}
+
+func (s *WarnevttypeContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
+	switch t := visitor.(type) {
+	case SfplVisitor:
+		return t.VisitWarnevttype(s)
+
+	default:
+		return t.VisitChildren(s)
+	}
+}
+
+func (p *SfplParser) Warnevttype() (localctx IWarnevttypeContext) {
+	localctx = NewWarnevttypeContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 46, SfplParserRULE_warnevttype)
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
+
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(454)
+		p.Atom()
+	}
+
+	return localctx
+}
+
+// ISkipunknownContext is an interface to support dynamic dispatch.
+type ISkipunknownContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// IsSkipunknownContext differentiates from other interfaces.
+	IsSkipunknownContext()
+}
+
+type SkipunknownContext struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptySkipunknownContext() *SkipunknownContext {
+	var p = new(SkipunknownContext)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = SfplParserRULE_skipunknown
+	return p
+}
+
+func (*SkipunknownContext) IsSkipunknownContext() {}
+
+func NewSkipunknownContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *SkipunknownContext {
+	var p = new(SkipunknownContext)
+
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = SfplParserRULE_skipunknown
+
+	return p
+}
+
+func (s *SkipunknownContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *SkipunknownContext) Atom() IAtomContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IAtomContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IAtomContext)
+}
+
+func (s *SkipunknownContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *SkipunknownContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *SkipunknownContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SfplListener); ok {
+		listenerT.EnterSkipunknown(s)
+	}
+}
+
+func (s *SkipunknownContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SfplListener); ok {
+		listenerT.ExitSkipunknown(s)
+	}
+}
+
+func (s *SkipunknownContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
+	switch t := visitor.(type) {
+	case SfplVisitor:
+		return t.VisitSkipunknown(s)
+
+	default:
+		return t.VisitChildren(s)
+	}
+}
+
+func (p *SfplParser) Skipunknown() (localctx ISkipunknownContext) {
+	localctx = NewSkipunknownContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 48, SfplParserRULE_skipunknown)
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
+
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(456)
+		p.Atom()
+	}
+
+	return localctx
+}
+
+// IFappendContext is an interface to support dynamic dispatch.
+type IFappendContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// IsFappendContext differentiates from other interfaces.
+	IsFappendContext()
+}
+
+type FappendContext struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyFappendContext() *FappendContext {
+	var p = new(FappendContext)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = SfplParserRULE_fappend
+	return p
+}
+
+func (*FappendContext) IsFappendContext() {}
+
+func NewFappendContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *FappendContext {
+	var p = new(FappendContext)
+
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = SfplParserRULE_fappend
+
+	return p
+}
+
+func (s *FappendContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *FappendContext) Atom() IAtomContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IAtomContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IAtomContext)
+}
+
+func (s *FappendContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *FappendContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *FappendContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SfplListener); ok {
+		listenerT.EnterFappend(s)
+	}
+}
+
+func (s *FappendContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SfplListener); ok {
+		listenerT.ExitFappend(s)
+	}
+}
+
+func (s *FappendContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
+	switch t := visitor.(type) {
+	case SfplVisitor:
+		return t.VisitFappend(s)
+
+	default:
+		return t.VisitChildren(s)
+	}
+}
+
+func (p *SfplParser) Fappend() (localctx IFappendContext) {
+	localctx = NewFappendContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 50, SfplParserRULE_fappend)
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
+
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(458)
+		p.Atom()
+	}
+
+	return localctx
+}
+
+// IExceptionsContext is an interface to support dynamic dispatch.
+type IExceptionsContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// IsExceptionsContext differentiates from other interfaces.
+	IsExceptionsContext()
+}
+
+type ExceptionsContext struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyExceptionsContext() *ExceptionsContext {
+	var p = new(ExceptionsContext)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = SfplParserRULE_exceptions
+	return p
+}
+
+func (*ExceptionsContext) IsExceptionsContext() {}
+
+func NewExceptionsContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *ExceptionsContext {
+	var p = new(ExceptionsContext)
+
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = SfplParserRULE_exceptions
+
+	return p
+}
+
+func (s *ExceptionsContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *ExceptionsContext) AllException() []IExceptionContext {
+	var ts = s.GetTypedRuleContexts(reflect.TypeOf((*IExceptionContext)(nil)).Elem())
+	var tst = make([]IExceptionContext, len(ts))
+
+	for i, t := range ts {
+		if t != nil {
+			tst[i] = t.(IExceptionContext)
+		}
+	}
+
+	return tst
+}
+
+func (s *ExceptionsContext) Exception(i int) IExceptionContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IExceptionContext)(nil)).Elem(), i)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IExceptionContext)
+}
+
+func (s *ExceptionsContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *ExceptionsContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *ExceptionsContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SfplListener); ok {
+		listenerT.EnterExceptions(s)
+	}
+}
+
+func (s *ExceptionsContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SfplListener); ok {
+		listenerT.ExitExceptions(s)
+	}
+}
+
+func (s *ExceptionsContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
+	switch t := visitor.(type) {
+	case SfplVisitor:
+		return t.VisitExceptions(s)
+
+	default:
+		return t.VisitChildren(s)
+	}
+}
+
+func (p *SfplParser) Exceptions() (localctx IExceptionsContext) {
+	localctx = NewExceptionsContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 52, SfplParserRULE_exceptions)
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
+
+	var _alt int
+
+	p.EnterOuterAlt(localctx, 1)
+	p.SetState(461)
+	p.GetErrorHandler().Sync(p)
+	_alt = 1
+	for ok := true; ok; ok = _alt != 2 && _alt != antlr.ATNInvalidAltNumber {
+		switch _alt {
+		case 1:
+			{
+				p.SetState(460)
+				p.Exception()
+			}
+
+		default:
+			panic(antlr.NewNoViableAltException(p, nil, nil, nil, nil, nil))
+		}
+
+		p.SetState(463)
+		p.GetErrorHandler().Sync(p)
+		_alt = p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 43, p.GetParserRuleContext())
+	}
+
+	return localctx
+}
+
+// IExceptionContext is an interface to support dynamic dispatch.
+type IExceptionContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// IsExceptionContext differentiates from other interfaces.
+	IsExceptionContext()
+}
+
+type ExceptionContext struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyExceptionContext() *ExceptionContext {
+	var p = new(ExceptionContext)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = SfplParserRULE_exception
+	return p
+}
+
+func (*ExceptionContext) IsExceptionContext() {}
+
+func NewExceptionContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *ExceptionContext {
+	var p = new(ExceptionContext)
+
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = SfplParserRULE_exception
+
+	return p
+}
+
+func (s *ExceptionContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *ExceptionContext) DECL() antlr.TerminalNode {
+	return s.GetToken(SfplParserDECL, 0)
+}
+
+func (s *ExceptionContext) NAME() antlr.TerminalNode {
+	return s.GetToken(SfplParserNAME, 0)
+}
+
+func (s *ExceptionContext) AllDEF() []antlr.TerminalNode {
+	return s.GetTokens(SfplParserDEF)
+}
+
+func (s *ExceptionContext) DEF(i int) antlr.TerminalNode {
+	return s.GetToken(SfplParserDEF, i)
+}
+
+func (s *ExceptionContext) ID() antlr.TerminalNode {
+	return s.GetToken(SfplParserID, 0)
+}
+
+func (s *ExceptionContext) AllFIELDS() []antlr.TerminalNode {
+	return s.GetTokens(SfplParserFIELDS)
+}
+
+func (s *ExceptionContext) FIELDS(i int) antlr.TerminalNode {
+	return s.GetToken(SfplParserFIELDS, i)
+}
+
+func (s *ExceptionContext) AllFields() []IFieldsContext {
+	var ts = s.GetTypedRuleContexts(reflect.TypeOf((*IFieldsContext)(nil)).Elem())
+	var tst = make([]IFieldsContext, len(ts))
+
+	for i, t := range ts {
+		if t != nil {
+			tst[i] = t.(IFieldsContext)
+		}
+	}
+
+	return tst
+}
+
+func (s *ExceptionContext) Fields(i int) IFieldsContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IFieldsContext)(nil)).Elem(), i)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IFieldsContext)
+}
+
+func (s *ExceptionContext) AllCOMPS() []antlr.TerminalNode {
+	return s.GetTokens(SfplParserCOMPS)
+}
+
+func (s *ExceptionContext) COMPS(i int) antlr.TerminalNode {
+	return s.GetToken(SfplParserCOMPS, i)
+}
+
+func (s *ExceptionContext) AllComps() []ICompsContext {
+	var ts = s.GetTypedRuleContexts(reflect.TypeOf((*ICompsContext)(nil)).Elem())
+	var tst = make([]ICompsContext, len(ts))
+
+	for i, t := range ts {
+		if t != nil {
+			tst[i] = t.(ICompsContext)
+		}
+	}
+
+	return tst
+}
+
+func (s *ExceptionContext) Comps(i int) ICompsContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*ICompsContext)(nil)).Elem(), i)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(ICompsContext)
+}
+
+func (s *ExceptionContext) AllVALUES() []antlr.TerminalNode {
+	return s.GetTokens(SfplParserVALUES)
+}
+
+func (s *ExceptionContext) VALUES(i int) antlr.TerminalNode {
+	return s.GetToken(SfplParserVALUES, i)
+}
+
+func (s *ExceptionContext) AllValues() []IValuesContext {
+	var ts = s.GetTypedRuleContexts(reflect.TypeOf((*IValuesContext)(nil)).Elem())
+	var tst = make([]IValuesContext, len(ts))
+
+	for i, t := range ts {
+		if t != nil {
+			tst[i] = t.(IValuesContext)
+		}
+	}
+
+	return tst
+}
+
+func (s *ExceptionContext) Values(i int) IValuesContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IValuesContext)(nil)).Elem(), i)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IValuesContext)
+}
+
+func (s *ExceptionContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *ExceptionContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *ExceptionContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SfplListener); ok {
+		listenerT.EnterException(s)
+	}
+}
+
+func (s *ExceptionContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SfplListener); ok {
+		listenerT.ExitException(s)
+	}
+}
+
+func (s *ExceptionContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
+	switch t := visitor.(type) {
+	case SfplVisitor:
+		return t.VisitException(s)
+
+	default:
+		return t.VisitChildren(s)
+	}
+}
+
+func (p *SfplParser) Exception() (localctx IExceptionContext) {
+	localctx = NewExceptionContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 54, SfplParserRULE_exception)
+	var _la int
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
+
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(465)
+		p.Match(SfplParserDECL)
+	}
+	{
+		p.SetState(466)
+		p.Match(SfplParserNAME)
+	}
+	{
+		p.SetState(467)
+		p.Match(SfplParserDEF)
+	}
+	{
+		p.SetState(468)
+		p.Match(SfplParserID)
+	}
+	p.SetState(480)
+	p.GetErrorHandler().Sync(p)
+	_la = p.GetTokenStream().LA(1)
+
+	for ((_la)&-(0x1f+1)) == 0 && ((1<<uint(_la))&((1<<SfplParserFIELDS)|(1<<SfplParserCOMPS)|(1<<SfplParserVALUES))) != 0 {
+		p.SetState(478)
+		p.GetErrorHandler().Sync(p)
+
+		switch p.GetTokenStream().LA(1) {
+		case SfplParserFIELDS:
+			{
+				p.SetState(469)
+				p.Match(SfplParserFIELDS)
+			}
+			{
+				p.SetState(470)
+				p.Match(SfplParserDEF)
+			}
+			{
+				p.SetState(471)
+				p.Fields()
+			}
+
+		case SfplParserCOMPS:
+			{
+				p.SetState(472)
+				p.Match(SfplParserCOMPS)
+			}
+			{
+				p.SetState(473)
+				p.Match(SfplParserDEF)
+			}
+			{
+				p.SetState(474)
+				p.Comps()
+			}
+
+		case SfplParserVALUES:
+			{
+				p.SetState(475)
+				p.Match(SfplParserVALUES)
+			}
+			{
+				p.SetState(476)
+				p.Match(SfplParserDEF)
+			}
+			{
+				p.SetState(477)
+				p.Values()
+			}
+
+		default:
+			panic(antlr.NewNoViableAltException(p, nil, nil, nil, nil, nil))
+		}
+
+		p.SetState(482)
+		p.GetErrorHandler().Sync(p)
+		_la = p.GetTokenStream().LA(1)
+	}
+
+	return localctx
+}
+
+// IFieldsContext is an interface to support dynamic dispatch.
+type IFieldsContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// IsFieldsContext differentiates from other interfaces.
+	IsFieldsContext()
+}
+
+type FieldsContext struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyFieldsContext() *FieldsContext {
+	var p = new(FieldsContext)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = SfplParserRULE_fields
+	return p
+}
+
+func (*FieldsContext) IsFieldsContext() {}
+
+func NewFieldsContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *FieldsContext {
+	var p = new(FieldsContext)
+
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = SfplParserRULE_fields
+
+	return p
+}
+
+func (s *FieldsContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *FieldsContext) Items() IItemsContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IItemsContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IItemsContext)
+}
+
+func (s *FieldsContext) Atom() IAtomContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IAtomContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IAtomContext)
+}
+
+func (s *FieldsContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *FieldsContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *FieldsContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SfplListener); ok {
+		listenerT.EnterFields(s)
+	}
+}
+
+func (s *FieldsContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SfplListener); ok {
+		listenerT.ExitFields(s)
+	}
+}
+
+func (s *FieldsContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
+	switch t := visitor.(type) {
+	case SfplVisitor:
+		return t.VisitFields(s)
+
+	default:
+		return t.VisitChildren(s)
+	}
+}
+
+func (p *SfplParser) Fields() (localctx IFieldsContext) {
+	localctx = NewFieldsContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 56, SfplParserRULE_fields)
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
+
+	p.SetState(485)
+	p.GetErrorHandler().Sync(p)
+
+	switch p.GetTokenStream().LA(1) {
+	case SfplParserLBRACK:
+		p.EnterOuterAlt(localctx, 1)
+		{
+			p.SetState(483)
+			p.Items()
+		}
+
+	case SfplParserEXCEPTIONS, SfplParserFIELDS, SfplParserCOMPS, SfplParserVALUES, SfplParserSEQUENCE, SfplParserKEY, SfplParserWINDOW, SfplParserSTEPS, SfplParserWITHIN, SfplParserBY, SfplParserLT, SfplParserGT, SfplParserIEQ, SfplParserIIN, SfplParserISTARTSWITH, SfplParserIENDSWITH, SfplParserMATCHES, SfplParserIMATCHES, SfplParserINCIDR, SfplParserDIV, SfplParserID, SfplParserNUMBER, SfplParserPATH, SfplParserSTRING, SfplParserTAG:
+		p.EnterOuterAlt(localctx, 2)
+		{
+			p.SetState(484)
+			p.Atom()
+		}
+
+	default:
+		panic(antlr.NewNoViableAltException(p, nil, nil, nil, nil, nil))
+	}
+
+	return localctx
+}
+
+// ICompsContext is an interface to support dynamic dispatch.
+type ICompsContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// IsCompsContext differentiates from other interfaces.
+	IsCompsContext()
+}
+
+type CompsContext struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyCompsContext() *CompsContext {
+	var p = new(CompsContext)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = SfplParserRULE_comps
+	return p
+}
+
+func (*CompsContext) IsCompsContext() {}
+
+func NewCompsContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *CompsContext {
+	var p = new(CompsContext)
+
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = SfplParserRULE_comps
+
+	return p
+}
+
+func (s *CompsContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *CompsContext) LBRACK() antlr.TerminalNode {
+	return s.GetToken(SfplParserLBRACK, 0)
+}
+
+func (s *CompsContext) AllComp() []ICompContext {
+	var ts = s.GetTypedRuleContexts(reflect.TypeOf((*ICompContext)(nil)).Elem())
+	var tst = make([]ICompContext, len(ts))
+
+	for i, t := range ts {
+		if t != nil {
+			tst[i] = t.(ICompContext)
+		}
+	}
+
+	return tst
+}
+
+func (s *CompsContext) Comp(i int) ICompContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*ICompContext)(nil)).Elem(), i)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(ICompContext)
+}
+
+func (s *CompsContext) RBRACK() antlr.TerminalNode {
+	return s.GetToken(SfplParserRBRACK, 0)
+}
+
+func (s *CompsContext) AllLISTSEP() []antlr.TerminalNode {
+	return s.GetTokens(SfplParserLISTSEP)
+}
+
+func (s *CompsContext) LISTSEP(i int) antlr.TerminalNode {
+	return s.GetToken(SfplParserLISTSEP, i)
+}
+
+func (s *CompsContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *CompsContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *CompsContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SfplListener); ok {
+		listenerT.EnterComps(s)
+	}
+}
+
+func (s *CompsContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SfplListener); ok {
+		listenerT.ExitComps(s)
+	}
+}
+
+func (s *CompsContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
+	switch t := visitor.(type) {
+	case SfplVisitor:
+		return t.VisitComps(s)
+
+	default:
+		return t.VisitChildren(s)
+	}
+}
+
+func (p *SfplParser) Comps() (localctx ICompsContext) {
+	localctx = NewCompsContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 58, SfplParserRULE_comps)
+	var _la int
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
+
+	p.SetState(499)
+	p.GetErrorHandler().Sync(p)
+
+	switch p.GetTokenStream().LA(1) {
+	case SfplParserLBRACK:
+		p.EnterOuterAlt(localctx, 1)
+		{
+			p.SetState(487)
+			p.Match(SfplParserLBRACK)
+		}
+		{
+			p.SetState(488)
+			p.Comp()
+		}
+		p.SetState(493)
+		p.GetErrorHandler().Sync(p)
+		_la = p.GetTokenStream().LA(1)
+
+		for _la == SfplParserLISTSEP {
+			{
+				p.SetState(489)
+				p.Match(SfplParserLISTSEP)
+			}
+			{
+				p.SetState(490)
+				p.Comp()
+			}
+
+			p.SetState(495)
+			p.GetErrorHandler().Sync(p)
+			_la = p.GetTokenStream().LA(1)
+		}
+		{
+			p.SetState(496)
+			p.Match(SfplParserRBRACK)
+		}
+
+	case SfplParserLT, SfplParserLE, SfplParserGT, SfplParserGE, SfplParserEQ, SfplParserIEQ, SfplParserNEQ, SfplParserIN, SfplParserIIN, SfplParserCONTAINS, SfplParserICONTAINS, SfplParserSTARTSWITH, SfplParserISTARTSWITH, SfplParserENDSWITH, SfplParserIENDSWITH, SfplParserMATCHES, SfplParserIMATCHES, SfplParserPMATCH, SfplParserINCIDR:
+		p.EnterOuterAlt(localctx, 2)
+		{
+			p.SetState(498)
+			p.Comp()
+		}
+
+	default:
+		panic(antlr.NewNoViableAltException(p, nil, nil, nil, nil, nil))
+	}
+
+	return localctx
+}
+
+// ICompContext is an interface to support dynamic dispatch.
+type ICompContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// IsCompContext differentiates from other interfaces.
+	IsCompContext()
+}
+
+type CompContext struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyCompContext() *CompContext {
+	var p = new(CompContext)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = SfplParserRULE_comp
+	return p
+}
+
+func (*CompContext) IsCompContext() {}
+
+func NewCompContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *CompContext {
+	var p = new(CompContext)
+
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = SfplParserRULE_comp
+
+	return p
+}
+
+func (s *CompContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *CompContext) Binary_operator() IBinary_operatorContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IBinary_operatorContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IBinary_operatorContext)
+}
+
+func (s *CompContext) IN() antlr.TerminalNode {
+	return s.GetToken(SfplParserIN, 0)
+}
+
+func (s *CompContext) IIN() antlr.TerminalNode {
+	return s.GetToken(SfplParserIIN, 0)
+}
+
+func (s *CompContext) PMATCH() antlr.TerminalNode {
+	return s.GetToken(SfplParserPMATCH, 0)
+}
+
+func (s *CompContext) INCIDR() antlr.TerminalNode {
+	return s.GetToken(SfplParserINCIDR, 0)
+}
+
+func (s *CompContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *CompContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *CompContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SfplListener); ok {
+		listenerT.EnterComp(s)
+	}
+}
+
+func (s *CompContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SfplListener); ok {
+		listenerT.ExitComp(s)
+	}
+}
+
+func (s *CompContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
+	switch t := visitor.(type) {
+	case SfplVisitor:
+		return t.VisitComp(s)
+
+	default:
+		return t.VisitChildren(s)
+	}
+}
+
+func (p *SfplParser) Comp() (localctx ICompContext) {
+	localctx = NewCompContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 60, SfplParserRULE_comp)
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
+
+	p.SetState(506)
+	p.GetErrorHandler().Sync(p)
+
+	switch p.GetTokenStream().LA(1) {
+	case SfplParserLT, SfplParserLE, SfplParserGT, SfplParserGE, SfplParserEQ, SfplParserIEQ, SfplParserNEQ, SfplParserCONTAINS, SfplParserICONTAINS, SfplParserSTARTSWITH, SfplParserISTARTSWITH, SfplParserENDSWITH, SfplParserIENDSWITH, SfplParserMATCHES, SfplParserIMATCHES:
+		p.EnterOuterAlt(localctx, 1)
+		{
+			p.SetState(501)
+			p.Binary_operator()
+		}
+
+	case SfplParserIN:
+		p.EnterOuterAlt(localctx, 2)
+		{
+			p.SetState(502)
+			p.Match(SfplParserIN)
+		}
+
+	case SfplParserIIN:
+		p.EnterOuterAlt(localctx, 3)
+		{
+			p.SetState(503)
+			p.Match(SfplParserIIN)
+		}
+
+	case SfplParserPMATCH:
+		p.EnterOuterAlt(localctx, 4)
+		{
+			p.SetState(504)
+			p.Match(SfplParserPMATCH)
+		}
+
+	case SfplParserINCIDR:
+		p.EnterOuterAlt(localctx, 5)
+		{
+			p.SetState(505)
+			p.Match(SfplParserINCIDR)
+		}
+
+	default:
+		panic(antlr.NewNoViableAltException(p, nil, nil, nil, nil, nil))
+	}
+
+	return localctx
+}
+
+// IValuesContext is an interface to support dynamic dispatch.
+type IValuesContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// IsValuesContext differentiates from other interfaces.
+	IsValuesContext()
+}
+
+type ValuesContext struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyValuesContext() *ValuesContext {
+	var p = new(ValuesContext)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = SfplParserRULE_values
+	return p
+}
+
+func (*ValuesContext) IsValuesContext() {}
+
+func NewValuesContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *ValuesContext {
+	var p = new(ValuesContext)
+
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = SfplParserRULE_values
+
+	return p
+}
+
+func (s *ValuesContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *ValuesContext) LBRACK() antlr.TerminalNode {
+	return s.GetToken(SfplParserLBRACK, 0)
+}
+
+func (s *ValuesContext) RBRACK() antlr.TerminalNode {
+	return s.GetToken(SfplParserRBRACK, 0)
+}
+
+func (s *ValuesContext) AllValue() []IValueContext {
+	var ts = s.GetTypedRuleContexts(reflect.TypeOf((*IValueContext)(nil)).Elem())
+	var tst = make([]IValueContext, len(ts))
+
+	for i, t := range ts {
+		if t != nil {
+			tst[i] = t.(IValueContext)
+		}
+	}
+
+	return tst
+}
+
+func (s *ValuesContext) Value(i int) IValueContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IValueContext)(nil)).Elem(), i)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IValueContext)
+}
+
+func (s *ValuesContext) AllLISTSEP() []antlr.TerminalNode {
+	return s.GetTokens(SfplParserLISTSEP)
+}
+
+func (s *ValuesContext) LISTSEP(i int) antlr.TerminalNode {
+	return s.GetToken(SfplParserLISTSEP, i)
+}
+
+func (s *ValuesContext) AllDECL() []antlr.TerminalNode {
+	return s.GetTokens(SfplParserDECL)
+}
+
+func (s *ValuesContext) DECL(i int) antlr.TerminalNode {
+	return s.GetToken(SfplParserDECL, i)
+}
+
+func (s *ValuesContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *ValuesContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *ValuesContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SfplListener); ok {
+		listenerT.EnterValues(s)
+	}
+}
+
+func (s *ValuesContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SfplListener); ok {
+		listenerT.ExitValues(s)
+	}
+}
+
+func (s *ValuesContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
+	switch t := visitor.(type) {
+	case SfplVisitor:
+		return t.VisitValues(s)
+
+	default:
+		return t.VisitChildren(s)
+	}
+}
+
+func (p *SfplParser) Values() (localctx IValuesContext) {
+	localctx = NewValuesContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 62, SfplParserRULE_values)
+	var _la int
 
 	defer func() {
 		p.ExitRule()
@@ -4026,54 +7366,134 @@ func (p *SfplParser) Severity() (localctx ISeverityContext) {
 		}
 	}()
 
-	p.EnterOuterAlt(localctx, 1)
-	{
-		p.SetState(283)
-		p.Match(SfplParserSEVERITY)
+	var _alt int
+
+	p.SetState(526)
+	p.GetErrorHandler().Sync(p)
+
+	switch p.GetTokenStream().LA(1) {
+	case SfplParserLBRACK:
+		p.EnterOuterAlt(localctx, 1)
+		{
+			p.SetState(508)
+			p.Match(SfplParserLBRACK)
+		}
+		p.SetState(517)
+		p.GetErrorHandler().Sync(p)
+		_la = p.GetTokenStream().LA(1)
+
+		if (((_la-19)&-(0x1f+1)) == 0 && ((1<<uint((_la-19)))&((1<<(SfplParserEXCEPTIONS-19))|(1<<(SfplParserFIELDS-19))|(1<<(SfplParserCOMPS-19))|(1<<(SfplParserVALUES-19))|(1<<(SfplParserSEQUENCE-19))|(1<<(SfplParserKEY-19))|(1<<(SfplParserWINDOW-19))|(1<<(SfplParserSTEPS-19))|(1<<(SfplParserWITHIN-19))|(1<<(SfplParserBY-19))|(1<<(SfplParserLT-19))|(1<<(SfplParserGT-19))|(1<<(SfplParserIEQ-19))|(1<<(SfplParserIIN-19))|(1<<(SfplParserISTARTSWITH-19))|(1<<(SfplParserIENDSWITH-19))|(1<<(SfplParserMATCHES-19))|(1<<(SfplParserIMATCHES-19))|(1<<(SfplParserINCIDR-19)))) != 0) || (((_la-54)&-(0x1f+1)) == 0 && ((1<<uint((_la-54)))&((1<<(SfplParserDIV-54))|(1<<(SfplParserLBRACK-54))|(1<<(SfplParserID-54))|(1<<(SfplParserNUMBER-54))|(1<<(SfplParserPATH-54))|(1<<(SfplParserSTRING-54))|(1<<(SfplParserTAG-54)))) != 0) {
+			{
+				p.SetState(509)
+				p.Value()
+			}
+			p.SetState(514)
+			p.GetErrorHandler().Sync(p)
+			_la = p.GetTokenStream().LA(1)
+
+			for _la == SfplParserLISTSEP {
+				{
+					p.SetState(510)
+					p.Match(SfplParserLISTSEP)
+				}
+				{
+					p.SetState(511)
+					p.Value()
+				}
+
+				p.SetState(516)
+				p.GetErrorHandler().Sync(p)
+				_la = p.GetTokenStream().LA(1)
+			}
+
+		}
+		{
+			p.SetState(519)
+			p.Match(SfplParserRBRACK)
+		}
+
+	case SfplParserDECL:
+		p.EnterOuterAlt(localctx, 2)
+		p.SetState(522)
+		p.GetErrorHandler().Sync(p)
+		_alt = 1
+		for ok := true; ok; ok = _alt != 2 && _alt != antlr.ATNInvalidAltNumber {
+			switch _alt {
+			case 1:
+				{
+					p.SetState(520)
+					p.Match(SfplParserDECL)
+				}
+				{
+					p.SetState(521)
+					p.Value()
+				}
+
+			default:
+				panic(antlr.NewNoViableAltException(p, nil, nil, nil, nil, nil))
+			}
+
+			p.SetState(524)
+			p.GetErrorHandler().Sync(p)
+			_alt = p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 52, p.GetParserRuleContext())
+		}
+
+	default:
+		panic(antlr.NewNoViableAltException(p, nil, nil, nil, nil, nil))
 	}
 
 	return localctx
 }
 
-// IEnabledContext is an interface to support dynamic dispatch.
-type IEnabledContext interface {
+// IValueContext is an interface to support dynamic dispatch.
+type IValueContext interface {
 	antlr.ParserRuleContext
 
 	// GetParser returns the parser.
 	GetParser() antlr.Parser
 
-	// IsEnabledContext differentiates from other interfaces.
-	IsEnabledContext()
+	// IsValueContext differentiates from other interfaces.
+	IsValueContext()
 }
 
-type EnabledContext struct {
+type ValueContext struct {
 	*antlr.BaseParserRuleContext
 	parser antlr.Parser
 }
 
-func NewEmptyEnabledContext() *EnabledContext {
-	var p = new(EnabledContext)
+func NewEmptyValueContext() *ValueContext {
+	var p = new(ValueContext)
 	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
-	p.RuleIndex = SfplParserRULE_enabled
+	p.RuleIndex = SfplParserRULE_value
 	return p
 }
 
-func (*EnabledContext) IsEnabledContext() {}
+func (*ValueContext) IsValueContext() {}
 
-func NewEnabledContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *EnabledContext {
-	var p = new(EnabledContext)
+func NewValueContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *ValueContext {
+	var p = new(ValueContext)
 
 	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
 
 	p.parser = parser
-	p.RuleIndex = SfplParserRULE_enabled
+	p.RuleIndex = SfplParserRULE_value
 
 	return p
 }
 
-func (s *EnabledContext) GetParser() antlr.Parser { return s.parser }
+func (s *ValueContext) GetParser() antlr.Parser { return s.parser }
 
-func (s *EnabledContext) Atom() IAtomContext {
+func (s *ValueContext) Tuple() ITupleContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*ITupleContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(ITupleContext)
+}
+
+func (s *ValueContext) Atom() IAtomContext {
 	var t = s.GetTypedRuleContext(reflect.TypeOf((*IAtomContext)(nil)).Elem(), 0)
 
 	if t == nil {
@@ -4083,39 +7503,39 @@ func (s *EnabledContext) Atom() IAtomContext {
 	return t.(IAtomContext)
 }
 
-func (s *EnabledContext) GetRuleContext() antlr.RuleContext {
+func (s *ValueContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
 
-func (s *EnabledContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+func (s *ValueContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
 	return antlr.TreesStringTree(s, ruleNames, recog)
 }
 
-func (s *EnabledContext) EnterRule(listener antlr.ParseTreeListener) {
+func (s *ValueContext) EnterRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(SfplListener); ok {
-		listenerT.EnterEnabled(s)
+		listenerT.EnterValue(s)
 	}
 }
 
-func (s *EnabledContext) ExitRule(listener antlr.ParseTreeListener) {
+func (s *ValueContext) ExitRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(SfplListener); ok {
-		listenerT.ExitEnabled(s)
+		listenerT.ExitValue(s)
 	}
 }
 
-func (s *EnabledContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
+func (s *ValueContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
 	switch t := visitor.(type) {
 	case SfplVisitor:
-		return t.VisitEnabled(s)
+		return t.VisitValue(s)
 
 	default:
 		return t.VisitChildren(s)
 	}
 }
 
-func (p *SfplParser) Enabled() (localctx IEnabledContext) {
-	localctx = NewEnabledContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 34, SfplParserRULE_enabled)
+func (p *SfplParser) Value() (localctx IValueContext) {
+	localctx = NewValueContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 64, SfplParserRULE_value)
 
 	defer func() {
 		p.ExitRule()
@@ -4133,55 +7553,92 @@ func (p *SfplParser) Enabled() (localctx IEnabledContext) {
 		}
 	}()
 
-	p.EnterOuterAlt(localctx, 1)
-	{
-		p.SetState(285)
-		p.Atom()
+	p.SetState(530)
+	p.GetErrorHandler().Sync(p)
+
+	switch p.GetTokenStream().LA(1) {
+	case SfplParserLBRACK:
+		p.EnterOuterAlt(localctx, 1)
+		{
+			p.SetState(528)
+			p.Tuple()
+		}
+
+	case SfplParserEXCEPTIONS, SfplParserFIELDS, SfplParserCOMPS, SfplParserVALUES, SfplParserSEQUENCE, SfplParserKEY, SfplParserWINDOW, SfplParserSTEPS, SfplParserWITHIN, SfplParserBY, SfplParserLT, SfplParserGT, SfplParserIEQ, SfplParserIIN, SfplParserISTARTSWITH, SfplParserIENDSWITH, SfplParserMATCHES, SfplParserIMATCHES, SfplParserINCIDR, SfplParserDIV, SfplParserID, SfplParserNUMBER, SfplParserPATH, SfplParserSTRING, SfplParserTAG:
+		p.EnterOuterAlt(localctx, 2)
+		{
+			p.SetState(529)
+			p.Atom()
+		}
+
+	default:
+		panic(antlr.NewNoViableAltException(p, nil, nil, nil, nil, nil))
 	}
 
 	return localctx
 }
 
-// IWarnevttypeContext is an interface to support dynamic dispatch.
-type IWarnevttypeContext interface {
+// ITupleContext is an interface to support dynamic dispatch.
+type ITupleContext interface {
 	antlr.ParserRuleContext
 
 	// GetParser returns the parser.
 	GetParser() antlr.Parser
 
-	// IsWarnevttypeContext differentiates from other interfaces.
-	IsWarnevttypeContext()
+	// IsTupleContext differentiates from other interfaces.
+	IsTupleContext()
 }
 
-type WarnevttypeContext struct {
+type TupleContext struct {
 	*antlr.BaseParserRuleContext
 	parser antlr.Parser
 }
 
-func NewEmptyWarnevttypeContext() *WarnevttypeContext {
-	var p = new(WarnevttypeContext)
+func NewEmptyTupleContext() *TupleContext {
+	var p = new(TupleContext)
 	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
-	p.RuleIndex = SfplParserRULE_warnevttype
+	p.RuleIndex = SfplParserRULE_tuple
 	return p
 }
 
-func (*WarnevttypeContext) IsWarnevttypeContext() {}
+func (*TupleContext) IsTupleContext() {}
 
-func NewWarnevttypeContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *WarnevttypeContext {
-	var p = new(WarnevttypeContext)
+func NewTupleContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *TupleContext {
+	var p = new(TupleContext)
 
 	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
 
 	p.parser = parser
-	p.RuleIndex = SfplParserRULE_warnevttype
+	p.RuleIndex = SfplParserRULE_tuple
 
 	return p
 }
 
-func (s *WarnevttypeContext) GetParser() antlr.Parser { return s.parser }
+func (s *TupleContext) GetParser() antlr.Parser { return s.parser }
 
-func (s *WarnevttypeContext) Atom() IAtomContext {
-	var t = s.GetTypedRuleContext(reflect.TypeOf((*IAtomContext)(nil)).Elem(), 0)
+func (s *TupleContext) LBRACK() antlr.TerminalNode {
+	return s.GetToken(SfplParserLBRACK, 0)
+}
+
+func (s *TupleContext) RBRACK() antlr.TerminalNode {
+	return s.GetToken(SfplParserRBRACK, 0)
+}
+
+func (s *TupleContext) AllAtom() []IAtomContext {
+	var ts = s.GetTypedRuleContexts(reflect.TypeOf((*IAtomContext)(nil)).Elem())
+	var tst = make([]IAtomContext, len(ts))
+
+	for i, t := range ts {
+		if t != nil {
+			tst[i] = t.(IAtomContext)
+		}
+	}
+
+	return tst
+}
+
+func (s *TupleContext) Atom(i int) IAtomContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IAtomContext)(nil)).Elem(), i)
 
 	if t == nil {
 		return nil
@@ -4190,39 +7647,71 @@ func (s *WarnevttypeContext) Atom() IAtomContext {
 	return t.(IAtomContext)
 }
 
-func (s *WarnevttypeContext) GetRuleContext() antlr.RuleContext {
+func (s *TupleContext) AllItems() []IItemsContext {
+	var ts = s.GetTypedRuleContexts(reflect.TypeOf((*IItemsContext)(nil)).Elem())
+	var tst = make([]IItemsContext, len(ts))
+
+	for i, t := range ts {
+		if t != nil {
+			tst[i] = t.(IItemsContext)
+		}
+	}
+
+	return tst
+}
+
+func (s *TupleContext) Items(i int) IItemsContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IItemsContext)(nil)).Elem(), i)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IItemsContext)
+}
+
+func (s *TupleContext) AllLISTSEP() []antlr.TerminalNode {
+	return s.GetTokens(SfplParserLISTSEP)
+}
+
+func (s *TupleContext) LISTSEP(i int) antlr.TerminalNode {
+	return s.GetToken(SfplParserLISTSEP, i)
+}
+
+func (s *TupleContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
 
-func (s *WarnevttypeContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+func (s *TupleContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
 	return antlr.TreesStringTree(s, ruleNames, recog)
 }
 
-func (s *WarnevttypeContext) EnterRule(listener antlr.ParseTreeListener) {
+func (s *TupleContext) EnterRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(SfplListener); ok {
-		listenerT.EnterWarnevttype(s)
+		listenerT.EnterTuple(s)
 	}
 }
 
-func (s *WarnevttypeContext) ExitRule(listener antlr.ParseTreeListener) {
+func (s *TupleContext) ExitRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(SfplListener); ok {
-		listenerT.ExitWarnevttype(s)
+		listenerT.ExitTuple(s)
 	}
 }
 
-func (s *WarnevttypeContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
+func (s *TupleContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
 	switch t := visitor.(type) {
 	case SfplVisitor:
-		return t.VisitWarnevttype(s)
+		return t.VisitTuple(s)
 
 	default:
 		return t.VisitChildren(s)
 	}
 }
 
-func (p *SfplParser) Warnevttype() (localctx IWarnevttypeContext) {
-	localctx = NewWarnevttypeContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 36, SfplParserRULE_warnevttype)
+func (p *SfplParser) Tuple() (localctx ITupleContext) {
+	localctx = NewTupleContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 66, SfplParserRULE_tuple)
+	var _la int
 
 	defer func() {
 		p.ExitRule()
@@ -4242,94 +7731,163 @@ func (p *SfplParser) Warnevttype() (localctx IWarnevttypeContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(287)
-		p.Atom()
+		p.SetState(532)
+		p.Match(SfplParserLBRACK)
+	}
+	p.SetState(535)
+	p.GetErrorHandler().Sync(p)
+
+	switch p.GetTokenStream().LA(1) {
+	case SfplParserEXCEPTIONS, SfplParserFIELDS, SfplParserCOMPS, SfplParserVALUES, SfplParserSEQUENCE, SfplParserKEY, SfplParserWINDOW, SfplParserSTEPS, SfplParserWITHIN, SfplParserBY, SfplParserLT, SfplParserGT, SfplParserIEQ, SfplParserIIN, SfplParserISTARTSWITH, SfplParserIENDSWITH, SfplParserMATCHES, SfplParserIMATCHES, SfplParserINCIDR, SfplParserDIV, SfplParserID, SfplParserNUMBER, SfplParserPATH, SfplParserSTRING, SfplParserTAG:
+		{
+			p.SetState(533)
+			p.Atom()
+		}
+
+	case SfplParserLBRACK:
+		{
+			p.SetState(534)
+			p.Items()
+		}
+
+	default:
+		panic(antlr.NewNoViableAltException(p, nil, nil, nil, nil, nil))
+	}
+	p.SetState(544)
+	p.GetErrorHandler().Sync(p)
+	_la = p.GetTokenStream().LA(1)
+
+	for _la == SfplParserLISTSEP {
+		{
+			p.SetState(537)
+			p.Match(SfplParserLISTSEP)
+		}
+		p.SetState(540)
+		p.GetErrorHandler().Sync(p)
+
+		switch p.GetTokenStream().LA(1) {
+		case SfplParserEXCEPTIONS, SfplParserFIELDS, SfplParserCOMPS, SfplParserVALUES, SfplParserSEQUENCE, SfplParserKEY, SfplParserWINDOW, SfplParserSTEPS, SfplParserWITHIN, SfplParserBY, SfplParserLT, SfplParserGT, SfplParserIEQ, SfplParserIIN, SfplParserISTARTSWITH, SfplParserIENDSWITH, SfplParserMATCHES, SfplParserIMATCHES, SfplParserINCIDR, SfplParserDIV, SfplParserID, SfplParserNUMBER, SfplParserPATH, SfplParserSTRING, SfplParserTAG:
+			{
+				p.SetState(538)
+				p.Atom()
+			}
+
+		case SfplParserLBRACK:
+			{
+				p.SetState(539)
+				p.Items()
+			}
+
+		default:
+			panic(antlr.NewNoViableAltException(p, nil, nil, nil, nil, nil))
+		}
+
+		p.SetState(546)
+		p.GetErrorHandler().Sync(p)
+		_la = p.GetTokenStream().LA(1)
+	}
+	{
+		p.SetState(547)
+		p.Match(SfplParserRBRACK)
 	}
 
 	return localctx
 }
 
-// ISkipunknownContext is an interface to support dynamic dispatch.
-type ISkipunknownContext interface {
+// IStepsContext is an interface to support dynamic dispatch.
+type IStepsContext interface {
 	antlr.ParserRuleContext
 
 	// GetParser returns the parser.
 	GetParser() antlr.Parser
 
-	// IsSkipunknownContext differentiates from other interfaces.
-	IsSkipunknownContext()
+	// IsStepsContext differentiates from other interfaces.
+	IsStepsContext()
 }
 
-type SkipunknownContext struct {
+type StepsContext struct {
 	*antlr.BaseParserRuleContext
 	parser antlr.Parser
 }
 
-func NewEmptySkipunknownContext() *SkipunknownContext {
-	var p = new(SkipunknownContext)
+func NewEmptyStepsContext() *StepsContext {
+	var p = new(StepsContext)
 	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
-	p.RuleIndex = SfplParserRULE_skipunknown
+	p.RuleIndex = SfplParserRULE_steps
 	return p
 }
 
-func (*SkipunknownContext) IsSkipunknownContext() {}
+func (*StepsContext) IsStepsContext() {}
 
-func NewSkipunknownContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *SkipunknownContext {
-	var p = new(SkipunknownContext)
+func NewStepsContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *StepsContext {
+	var p = new(StepsContext)
 
 	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
 
 	p.parser = parser
-	p.RuleIndex = SfplParserRULE_skipunknown
+	p.RuleIndex = SfplParserRULE_steps
 
 	return p
 }
 
-func (s *SkipunknownContext) GetParser() antlr.Parser { return s.parser }
+func (s *StepsContext) GetParser() antlr.Parser { return s.parser }
 
-func (s *SkipunknownContext) Atom() IAtomContext {
-	var t = s.GetTypedRuleContext(reflect.TypeOf((*IAtomContext)(nil)).Elem(), 0)
+func (s *StepsContext) AllStep() []IStepContext {
+	var ts = s.GetTypedRuleContexts(reflect.TypeOf((*IStepContext)(nil)).Elem())
+	var tst = make([]IStepContext, len(ts))
+
+	for i, t := range ts {
+		if t != nil {
+			tst[i] = t.(IStepContext)
+		}
+	}
+
+	return tst
+}
+
+func (s *StepsContext) Step(i int) IStepContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IStepContext)(nil)).Elem(), i)
 
 	if t == nil {
 		return nil
 	}
 
-	return t.(IAtomContext)
+	return t.(IStepContext)
 }
 
-func (s *SkipunknownContext) GetRuleContext() antlr.RuleContext {
+func (s *StepsContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
 
-func (s *SkipunknownContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+func (s *StepsContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
 	return antlr.TreesStringTree(s, ruleNames, recog)
 }
 
-func (s *SkipunknownContext) EnterRule(listener antlr.ParseTreeListener) {
+func (s *StepsContext) EnterRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(SfplListener); ok {
-		listenerT.EnterSkipunknown(s)
+		listenerT.EnterSteps(s)
 	}
 }
 
-func (s *SkipunknownContext) ExitRule(listener antlr.ParseTreeListener) {
+func (s *StepsContext) ExitRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(SfplListener); ok {
-		listenerT.ExitSkipunknown(s)
+		listenerT.ExitSteps(s)
 	}
 }
 
-func (s *SkipunknownContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
+func (s *StepsContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
 	switch t := visitor.(type) {
 	case SfplVisitor:
-		return t.VisitSkipunknown(s)
+		return t.VisitSteps(s)
 
 	default:
 		return t.VisitChildren(s)
 	}
 }
 
-func (p *SfplParser) Skipunknown() (localctx ISkipunknownContext) {
-	localctx = NewSkipunknownContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 38, SfplParserRULE_skipunknown)
+func (p *SfplParser) Steps() (localctx IStepsContext) {
+	localctx = NewStepsContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 68, SfplParserRULE_steps)
 
 	defer func() {
 		p.ExitRule()
@@ -4345,98 +7903,145 @@ func (p *SfplParser) Skipunknown() (localctx ISkipunknownContext) {
 				panic(err)
 			}
 		}
-	}()
+	}()
+
+	var _alt int
+
+	p.EnterOuterAlt(localctx, 1)
+	p.SetState(550)
+	p.GetErrorHandler().Sync(p)
+	_alt = 1
+	for ok := true; ok; ok = _alt != 2 && _alt != antlr.ATNInvalidAltNumber {
+		switch _alt {
+		case 1:
+			{
+				p.SetState(549)
+				p.Step()
+			}
+
+		default:
+			panic(antlr.NewNoViableAltException(p, nil, nil, nil, nil, nil))
+		}
 
-	p.EnterOuterAlt(localctx, 1)
-	{
-		p.SetState(289)
-		p.Atom()
+		p.SetState(552)
+		p.GetErrorHandler().Sync(p)
+		_alt = p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 58, p.GetParserRuleContext())
 	}
 
 	return localctx
 }
 
-// IFappendContext is an interface to support dynamic dispatch.
-type IFappendContext interface {
+// IStepContext is an interface to support dynamic dispatch.
+type IStepContext interface {
 	antlr.ParserRuleContext
 
 	// GetParser returns the parser.
 	GetParser() antlr.Parser
 
-	// IsFappendContext differentiates from other interfaces.
-	IsFappendContext()
+	// IsStepContext differentiates from other interfaces.
+	IsStepContext()
 }
 
-type FappendContext struct {
+type StepContext struct {
 	*antlr.BaseParserRuleContext
 	parser antlr.Parser
 }
 
-func NewEmptyFappendContext() *FappendContext {
-	var p = new(FappendContext)
+func NewEmptyStepContext() *StepContext {
+	var p = new(StepContext)
 	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
-	p.RuleIndex = SfplParserRULE_fappend
+	p.RuleIndex = SfplParserRULE_step
 	return p
 }
 
-func (*FappendContext) IsFappendContext() {}
+func (*StepContext) IsStepContext() {}
 
-func NewFappendContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *FappendContext {
-	var p = new(FappendContext)
+func NewStepContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *StepContext {
+	var p = new(StepContext)
 
 	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
 
 	p.parser = parser
-	p.RuleIndex = SfplParserRULE_fappend
+	p.RuleIndex = SfplParserRULE_step
 
 	return p
 }
 
-func (s *FappendContext) GetParser() antlr.Parser { return s.parser }
+func (s *StepContext) GetParser() antlr.Parser { return s.parser }
 
-func (s *FappendContext) Atom() IAtomContext {
-	var t = s.GetTypedRuleContext(reflect.TypeOf((*IAtomContext)(nil)).Elem(), 0)
+func (s *StepContext) DECL() antlr.TerminalNode {
+	return s.GetToken(SfplParserDECL, 0)
+}
+
+func (s *StepContext) COND() antlr.TerminalNode {
+	return s.GetToken(SfplParserCOND, 0)
+}
+
+func (s *StepContext) AllDEF() []antlr.TerminalNode {
+	return s.GetTokens(SfplParserDEF)
+}
+
+func (s *StepContext) DEF(i int) antlr.TerminalNode {
+	return s.GetToken(SfplParserDEF, i)
+}
+
+func (s *StepContext) Expression() IExpressionContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IExpressionContext)(nil)).Elem(), 0)
 
 	if t == nil {
 		return nil
 	}
 
-	return t.(IAtomContext)
+	return t.(IExpressionContext)
 }
 
-func (s *FappendContext) GetRuleContext() antlr.RuleContext {
+func (s *StepContext) KEY() antlr.TerminalNode {
+	return s.GetToken(SfplParserKEY, 0)
+}
+
+func (s *StepContext) Fields() IFieldsContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IFieldsContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IFieldsContext)
+}
+
+func (s *StepContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
 
-func (s *FappendContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+func (s *StepContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
 	return antlr.TreesStringTree(s, ruleNames, recog)
 }
 
-func (s *FappendContext) EnterRule(listener antlr.ParseTreeListener) {
+func (s *StepContext) EnterRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(SfplListener); ok {
-		listenerT.EnterFappend(s)
+		listenerT.EnterStep(s)
 	}
 }
 
-func (s *FappendContext) ExitRule(listener antlr.ParseTreeListener) {
+func (s *StepContext) ExitRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(SfplListener); ok {
-		listenerT.ExitFappend(s)
+		listenerT.ExitStep(s)
 	}
 }
 
-func (s *FappendContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
+func (s *StepContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
 	switch t := visitor.(type) {
 	case SfplVisitor:
-		return t.VisitFappend(s)
+		return t.VisitStep(s)
 
 	default:
 		return t.VisitChildren(s)
 	}
 }
 
-func (p *SfplParser) Fappend() (localctx IFappendContext) {
-	localctx = NewFappendContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 40, SfplParserRULE_fappend)
+func (p *SfplParser) Step() (localctx IStepContext) {
+	localctx = NewStepContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 70, SfplParserRULE_step)
 
 	defer func() {
 		p.ExitRule()
@@ -4456,8 +8061,38 @@ func (p *SfplParser) Fappend() (localctx IFappendContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(291)
-		p.Atom()
+		p.SetState(554)
+		p.Match(SfplParserDECL)
+	}
+	{
+		p.SetState(555)
+		p.Match(SfplParserCOND)
+	}
+	{
+		p.SetState(556)
+		p.Match(SfplParserDEF)
+	}
+	{
+		p.SetState(557)
+		p.Expression()
+	}
+	p.SetState(561)
+	p.GetErrorHandler().Sync(p)
+
+	if p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 59, p.GetParserRuleContext()) == 1 {
+		{
+			p.SetState(558)
+			p.Match(SfplParserKEY)
+		}
+		{
+			p.SetState(559)
+			p.Match(SfplParserDEF)
+		}
+		{
+			p.SetState(560)
+			p.Fields()
+		}
+
 	}
 
 	return localctx
@@ -4501,8 +8136,14 @@ func NewVariableContext(parser antlr.Parser, parent antlr.ParserRuleContext, inv
 
 func (s *VariableContext) GetParser() antlr.Parser { return s.parser }
 
-func (s *VariableContext) ID() antlr.TerminalNode {
-	return s.GetToken(SfplParserID, 0)
+func (s *VariableContext) Identifier() IIdentifierContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IIdentifierContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IIdentifierContext)
 }
 
 func (s *VariableContext) GetRuleContext() antlr.RuleContext {
@@ -4537,7 +8178,7 @@ func (s *VariableContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
 
 func (p *SfplParser) Variable() (localctx IVariableContext) {
 	localctx = NewVariableContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 42, SfplParserRULE_variable)
+	p.EnterRule(localctx, 72, SfplParserRULE_variable)
 
 	defer func() {
 		p.ExitRule()
@@ -4557,8 +8198,8 @@ func (p *SfplParser) Variable() (localctx IVariableContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(293)
-		p.Match(SfplParserID)
+		p.SetState(563)
+		p.Identifier()
 	}
 
 	return localctx
@@ -4630,6 +8271,78 @@ func (s *AtomContext) GT() antlr.TerminalNode {
 	return s.GetToken(SfplParserGT, 0)
 }
 
+func (s *AtomContext) DIV() antlr.TerminalNode {
+	return s.GetToken(SfplParserDIV, 0)
+}
+
+func (s *AtomContext) EXCEPTIONS() antlr.TerminalNode {
+	return s.GetToken(SfplParserEXCEPTIONS, 0)
+}
+
+func (s *AtomContext) FIELDS() antlr.TerminalNode {
+	return s.GetToken(SfplParserFIELDS, 0)
+}
+
+func (s *AtomContext) COMPS() antlr.TerminalNode {
+	return s.GetToken(SfplParserCOMPS, 0)
+}
+
+func (s *AtomContext) VALUES() antlr.TerminalNode {
+	return s.GetToken(SfplParserVALUES, 0)
+}
+
+func (s *AtomContext) SEQUENCE() antlr.TerminalNode {
+	return s.GetToken(SfplParserSEQUENCE, 0)
+}
+
+func (s *AtomContext) KEY() antlr.TerminalNode {
+	return s.GetToken(SfplParserKEY, 0)
+}
+
+func (s *AtomContext) WINDOW() antlr.TerminalNode {
+	return s.GetToken(SfplParserWINDOW, 0)
+}
+
+func (s *AtomContext) STEPS() antlr.TerminalNode {
+	return s.GetToken(SfplParserSTEPS, 0)
+}
+
+func (s *AtomContext) WITHIN() antlr.TerminalNode {
+	return s.GetToken(SfplParserWITHIN, 0)
+}
+
+func (s *AtomContext) BY() antlr.TerminalNode {
+	return s.GetToken(SfplParserBY, 0)
+}
+
+func (s *AtomContext) IEQ() antlr.TerminalNode {
+	return s.GetToken(SfplParserIEQ, 0)
+}
+
+func (s *AtomContext) IIN() antlr.TerminalNode {
+	return s.GetToken(SfplParserIIN, 0)
+}
+
+func (s *AtomContext) ISTARTSWITH() antlr.TerminalNode {
+	return s.GetToken(SfplParserISTARTSWITH, 0)
+}
+
+func (s *AtomContext) IENDSWITH() antlr.TerminalNode {
+	return s.GetToken(SfplParserIENDSWITH, 0)
+}
+
+func (s *AtomContext) MATCHES() antlr.TerminalNode {
+	return s.GetToken(SfplParserMATCHES, 0)
+}
+
+func (s *AtomContext) IMATCHES() antlr.TerminalNode {
+	return s.GetToken(SfplParserIMATCHES, 0)
+}
+
+func (s *AtomContext) INCIDR() antlr.TerminalNode {
+	return s.GetToken(SfplParserINCIDR, 0)
+}
+
 func (s *AtomContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
@@ -4662,7 +8375,7 @@ func (s *AtomContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
 
 func (p *SfplParser) Atom() (localctx IAtomContext) {
 	localctx = NewAtomContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 44, SfplParserRULE_atom)
+	p.EnterRule(localctx, 74, SfplParserRULE_atom)
 	var _la int
 
 	defer func() {
@@ -4683,10 +8396,10 @@ func (p *SfplParser) Atom() (localctx IAtomContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(295)
+		p.SetState(565)
 		_la = p.GetTokenStream().LA(1)
 
-		if !(((_la-22)&-(0x1f+1)) == 0 && ((1<<uint((_la-22)))&((1<<(SfplParserLT-22))|(1<<(SfplParserGT-22))|(1<<(SfplParserID-22))|(1<<(SfplParserNUMBER-22))|(1<<(SfplParserPATH-22))|(1<<(SfplParserSTRING-22))|(1<<(SfplParserTAG-22)))) != 0) {
+		if !((((_la-19)&-(0x1f+1)) == 0 && ((1<<uint((_la-19)))&((1<<(SfplParserEXCEPTIONS-19))|(1<<(SfplParserFIELDS-19))|(1<<(SfplParserCOMPS-19))|(1<<(SfplParserVALUES-19))|(1<<(SfplParserSEQUENCE-19))|(1<<(SfplParserKEY-19))|(1<<(SfplParserWINDOW-19))|(1<<(SfplParserSTEPS-19))|(1<<(SfplParserWITHIN-19))|(1<<(SfplParserBY-19))|(1<<(SfplParserLT-19))|(1<<(SfplParserGT-19))|(1<<(SfplParserIEQ-19))|(1<<(SfplParserIIN-19))|(1<<(SfplParserISTARTSWITH-19))|(1<<(SfplParserIENDSWITH-19))|(1<<(SfplParserMATCHES-19))|(1<<(SfplParserIMATCHES-19))|(1<<(SfplParserINCIDR-19)))) != 0) || (((_la-54)&-(0x1f+1)) == 0 && ((1<<uint((_la-54)))&((1<<(SfplParserDIV-54))|(1<<(SfplParserID-54))|(1<<(SfplParserNUMBER-54))|(1<<(SfplParserPATH-54))|(1<<(SfplParserSTRING-54))|(1<<(SfplParserTAG-54)))) != 0)) {
 			p.GetErrorHandler().RecoverInline(p)
 		} else {
 			p.GetErrorHandler().ReportMatch(p)
@@ -4766,7 +8479,7 @@ func (s *TextContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
 
 func (p *SfplParser) Text() (localctx ITextContext) {
 	localctx = NewTextContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 46, SfplParserRULE_text)
+	p.EnterRule(localctx, 76, SfplParserRULE_text)
 
 	defer func() {
 		p.ExitRule()
@@ -4787,13 +8500,13 @@ func (p *SfplParser) Text() (localctx ITextContext) {
 	var _alt int
 
 	p.EnterOuterAlt(localctx, 1)
-	p.SetState(299)
+	p.SetState(569)
 	p.GetErrorHandler().Sync(p)
 	_alt = 1
 	for ok := true; ok; ok = _alt != 2 && _alt != antlr.ATNInvalidAltNumber {
 		switch _alt {
 		case 1:
-			p.SetState(297)
+			p.SetState(567)
 
 			if !(!(p.GetCurrentToken().GetText() == "desc" ||
 				p.GetCurrentToken().GetText() == "condition" ||
@@ -4805,19 +8518,23 @@ func (p *SfplParser) Text() (localctx ITextContext) {
 				p.GetCurrentToken().GetText() == "enabled" ||
 				p.GetCurrentToken().GetText() == "warn_evttypes" ||
 				p.GetCurrentToken().GetText() == "skip-if-unknown-filter" ||
+				(p.GetCurrentToken().GetText() == "exceptions" && p.GetTokenStream().LA(2) == SfplParserDEF) ||
+				(p.GetCurrentToken().GetText() == "key" && p.GetTokenStream().LA(2) == SfplParserDEF) ||
+				(p.GetCurrentToken().GetText() == "window" && p.GetTokenStream().LA(2) == SfplParserDEF) ||
+				(p.GetCurrentToken().GetText() == "steps" && p.GetTokenStream().LA(2) == SfplParserDEF) ||
 				p.GetCurrentToken().GetText() == "append")) {
-				panic(antlr.NewFailedPredicateException(p, "!(p.GetCurrentToken().GetText() == \"desc\" ||\n\t      p.GetCurrentToken().GetText() == \"condition\" ||\n\t      p.GetCurrentToken().GetText() == \"action\" ||\n\t      p.GetCurrentToken().GetText() == \"output\" ||\n\t      p.GetCurrentToken().GetText() == \"priority\" ||\n\t      p.GetCurrentToken().GetText() == \"tags\" ||\n\t\t  p.GetCurrentToken().GetText() == \"prefilter\" ||\n\t\t  p.GetCurrentToken().GetText() == \"enabled\" ||\n\t\t  p.GetCurrentToken().GetText() == \"warn_evttypes\" ||\n\t\t  p.GetCurrentToken().GetText() == \"skip-if-unknown-filter\" ||\n\t\t  p.GetCurrentToken().GetText() == \"append\")", ""))
+				panic(antlr.NewFailedPredicateException(p, "!(p.GetCurrentToken().GetText() == \"desc\" ||\n\t      p.GetCurrentToken().GetText() == \"condition\" ||\n\t      p.GetCurrentToken().GetText() == \"action\" ||\n\t      p.GetCurrentToken().GetText() == \"output\" ||\n\t      p.GetCurrentToken().GetText() == \"priority\" ||\n\t      p.GetCurrentToken().GetText() == \"tags\" ||\n\t\t  p.GetCurrentToken().GetText() == \"prefilter\" ||\n\t\t  p.GetCurrentToken().GetText() == \"enabled\" ||\n\t\t  p.GetCurrentToken().GetText() == \"warn_evttypes\" ||\n\t\t  p.GetCurrentToken().GetText() == \"skip-if-unknown-filter\" ||\n\t\t  (p.GetCurrentToken().GetText() == \"exceptions\" && p.GetTokenStream().LA(2) == SfplParserDEF) ||\n\t\t  (p.GetCurrentToken().GetText() == \"key\" && p.GetTokenStream().LA(2) == SfplParserDEF) ||\n\t\t  (p.GetCurrentToken().GetText() == \"window\" && p.GetTokenStream().LA(2) == SfplParserDEF) ||\n\t\t  (p.GetCurrentToken().GetText() == \"steps\" && p.GetTokenStream().LA(2) == SfplParserDEF) ||\n\t\t  p.GetCurrentToken().GetText() == \"append\")", ""))
 			}
-			p.SetState(298)
+			p.SetState(568)
 			p.MatchWildcard()
 
 		default:
 			panic(antlr.NewNoViableAltException(p, nil, nil, nil, nil, nil))
 		}
 
-		p.SetState(301)
+		p.SetState(571)
 		p.GetErrorHandler().Sync(p)
-		_alt = p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 23, p.GetParserRuleContext())
+		_alt = p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 60, p.GetParserRuleContext())
 	}
 
 	return localctx
@@ -4881,6 +8598,10 @@ func (s *Binary_operatorContext) EQ() antlr.TerminalNode {
 	return s.GetToken(SfplParserEQ, 0)
 }
 
+func (s *Binary_operatorContext) IEQ() antlr.TerminalNode {
+	return s.GetToken(SfplParserIEQ, 0)
+}
+
 func (s *Binary_operatorContext) NEQ() antlr.TerminalNode {
 	return s.GetToken(SfplParserNEQ, 0)
 }
@@ -4897,10 +8618,26 @@ func (s *Binary_operatorContext) STARTSWITH() antlr.TerminalNode {
 	return s.GetToken(SfplParserSTARTSWITH, 0)
 }
 
+func (s *Binary_operatorContext) ISTARTSWITH() antlr.TerminalNode {
+	return s.GetToken(SfplParserISTARTSWITH, 0)
+}
+
 func (s *Binary_operatorContext) ENDSWITH() antlr.TerminalNode {
 	return s.GetToken(SfplParserENDSWITH, 0)
 }
 
+func (s *Binary_operatorContext) IENDSWITH() antlr.TerminalNode {
+	return s.GetToken(SfplParserIENDSWITH, 0)
+}
+
+func (s *Binary_operatorContext) MATCHES() antlr.TerminalNode {
+	return s.GetToken(SfplParserMATCHES, 0)
+}
+
+func (s *Binary_operatorContext) IMATCHES() antlr.TerminalNode {
+	return s.GetToken(SfplParserIMATCHES, 0)
+}
+
 func (s *Binary_operatorContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
@@ -4933,7 +8670,7 @@ func (s *Binary_operatorContext) Accept(visitor antlr.ParseTreeVisitor) interfac
 
 func (p *SfplParser) Binary_operator() (localctx IBinary_operatorContext) {
 	localctx = NewBinary_operatorContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 48, SfplParserRULE_binary_operator)
+	p.EnterRule(localctx, 78, SfplParserRULE_binary_operator)
 	var _la int
 
 	defer func() {
@@ -4954,10 +8691,10 @@ func (p *SfplParser) Binary_operator() (localctx IBinary_operatorContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(303)
+		p.SetState(573)
 		_la = p.GetTokenStream().LA(1)
 
-		if !(((_la-22)&-(0x1f+1)) == 0 && ((1<<uint((_la-22)))&((1<<(SfplParserLT-22))|(1<<(SfplParserLE-22))|(1<<(SfplParserGT-22))|(1<<(SfplParserGE-22))|(1<<(SfplParserEQ-22))|(1<<(SfplParserNEQ-22))|(1<<(SfplParserCONTAINS-22))|(1<<(SfplParserICONTAINS-22))|(1<<(SfplParserSTARTSWITH-22))|(1<<(SfplParserENDSWITH-22)))) != 0) {
+		if !(((_la-32)&-(0x1f+1)) == 0 && ((1<<uint((_la-32)))&((1<<(SfplParserLT-32))|(1<<(SfplParserLE-32))|(1<<(SfplParserGT-32))|(1<<(SfplParserGE-32))|(1<<(SfplParserEQ-32))|(1<<(SfplParserIEQ-32))|(1<<(SfplParserNEQ-32))|(1<<(SfplParserCONTAINS-32))|(1<<(SfplParserICONTAINS-32))|(1<<(SfplParserSTARTSWITH-32))|(1<<(SfplParserISTARTSWITH-32))|(1<<(SfplParserENDSWITH-32))|(1<<(SfplParserIENDSWITH-32))|(1<<(SfplParserMATCHES-32))|(1<<(SfplParserIMATCHES-32)))) != 0) {
 			p.GetErrorHandler().RecoverInline(p)
 		} else {
 			p.GetErrorHandler().ReportMatch(p)
@@ -5042,7 +8779,7 @@ func (s *Unary_operatorContext) Accept(visitor antlr.ParseTreeVisitor) interface
 
 func (p *SfplParser) Unary_operator() (localctx IUnary_operatorContext) {
 	localctx = NewUnary_operatorContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 50, SfplParserRULE_unary_operator)
+	p.EnterRule(localctx, 80, SfplParserRULE_unary_operator)
 
 	defer func() {
 		p.ExitRule()
@@ -5062,16 +8799,193 @@ func (p *SfplParser) Unary_operator() (localctx IUnary_operatorContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(305)
+		p.SetState(575)
 		p.Match(SfplParserEXISTS)
 	}
 
 	return localctx
 }
 
+// IIdentifierContext is an interface to support dynamic dispatch.
+type IIdentifierContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// IsIdentifierContext differentiates from other interfaces.
+	IsIdentifierContext()
+}
+
+type IdentifierContext struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyIdentifierContext() *IdentifierContext {
+	var p = new(IdentifierContext)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = SfplParserRULE_identifier
+	return p
+}
+
+func (*IdentifierContext) IsIdentifierContext() {}
+
+func NewIdentifierContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *IdentifierContext {
+	var p = new(IdentifierContext)
+
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = SfplParserRULE_identifier
+
+	return p
+}
+
+func (s *IdentifierContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *IdentifierContext) ID() antlr.TerminalNode {
+	return s.GetToken(SfplParserID, 0)
+}
+
+func (s *IdentifierContext) EXCEPTIONS() antlr.TerminalNode {
+	return s.GetToken(SfplParserEXCEPTIONS, 0)
+}
+
+func (s *IdentifierContext) FIELDS() antlr.TerminalNode {
+	return s.GetToken(SfplParserFIELDS, 0)
+}
+
+func (s *IdentifierContext) COMPS() antlr.TerminalNode {
+	return s.GetToken(SfplParserCOMPS, 0)
+}
+
+func (s *IdentifierContext) VALUES() antlr.TerminalNode {
+	return s.GetToken(SfplParserVALUES, 0)
+}
+
+func (s *IdentifierContext) SEQUENCE() antlr.TerminalNode {
+	return s.GetToken(SfplParserSEQUENCE, 0)
+}
+
+func (s *IdentifierContext) KEY() antlr.TerminalNode {
+	return s.GetToken(SfplParserKEY, 0)
+}
+
+func (s *IdentifierContext) WINDOW() antlr.TerminalNode {
+	return s.GetToken(SfplParserWINDOW, 0)
+}
+
+func (s *IdentifierContext) STEPS() antlr.TerminalNode {
+	return s.GetToken(SfplParserSTEPS, 0)
+}
+
+func (s *IdentifierContext) WITHIN() antlr.TerminalNode {
+	return s.GetToken(SfplParserWITHIN, 0)
+}
+
+func (s *IdentifierContext) BY() antlr.TerminalNode {
+	return s.GetToken(SfplParserBY, 0)
+}
+
+func (s *IdentifierContext) IEQ() antlr.TerminalNode {
+	return s.GetToken(SfplParserIEQ, 0)
+}
+
+func (s *IdentifierContext) IIN() antlr.TerminalNode {
+	return s.GetToken(SfplParserIIN, 0)
+}
+
+func (s *IdentifierContext) ISTARTSWITH() antlr.TerminalNode {
+	return s.GetToken(SfplParserISTARTSWITH, 0)
+}
+
+func (s *IdentifierContext) IENDSWITH() antlr.TerminalNode {
+	return s.GetToken(SfplParserIENDSWITH, 0)
+}
+
+func (s *IdentifierContext) MATCHES() antlr.TerminalNode {
+	return s.GetToken(SfplParserMATCHES, 0)
+}
+
+func (s *IdentifierContext) IMATCHES() antlr.TerminalNode {
+	return s.GetToken(SfplParserIMATCHES, 0)
+}
+
+func (s *IdentifierContext) INCIDR() antlr.TerminalNode {
+	return s.GetToken(SfplParserINCIDR, 0)
+}
+
+func (s *IdentifierContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *IdentifierContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *IdentifierContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SfplListener); ok {
+		listenerT.EnterIdentifier(s)
+	}
+}
+
+func (s *IdentifierContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SfplListener); ok {
+		listenerT.ExitIdentifier(s)
+	}
+}
+
+func (s *IdentifierContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
+	switch t := visitor.(type) {
+	case SfplVisitor:
+		return t.VisitIdentifier(s)
+
+	default:
+		return t.VisitChildren(s)
+	}
+}
+
+func (p *SfplParser) Identifier() (localctx IIdentifierContext) {
+	localctx = NewIdentifierContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 82, SfplParserRULE_identifier)
+	var _la int
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
+
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(577)
+		_la = p.GetTokenStream().LA(1)
+
+		if !((((_la)&-(0x1f+1)) == 0 && ((1<<uint(_la))&((1<<SfplParserEXCEPTIONS)|(1<<SfplParserFIELDS)|(1<<SfplParserCOMPS)|(1<<SfplParserVALUES)|(1<<SfplParserSEQUENCE)|(1<<SfplParserKEY)|(1<<SfplParserWINDOW)|(1<<SfplParserSTEPS)|(1<<SfplParserWITHIN)|(1<<SfplParserBY))) != 0) || (((_la-37)&-(0x1f+1)) == 0 && ((1<<uint((_la-37)))&((1<<(SfplParserIEQ-37))|(1<<(SfplParserIIN-37))|(1<<(SfplParserISTARTSWITH-37))|(1<<(SfplParserIENDSWITH-37))|(1<<(SfplParserMATCHES-37))|(1<<(SfplParserIMATCHES-37))|(1<<(SfplParserINCIDR-37))|(1<<(SfplParserID-37)))) != 0)) {
+			p.GetErrorHandler().RecoverInline(p)
+		} else {
+			p.GetErrorHandler().ReportMatch(p)
+			p.Consume()
+		}
+	}
+
+	return localctx
+}
+
 func (p *SfplParser) Sempred(localctx antlr.RuleContext, ruleIndex, predIndex int) bool {
 	switch ruleIndex {
-	case 23:
+	case 38:
 		var t *TextContext = nil
 		if localctx != nil {
 			t = localctx.(*TextContext)
@@ -5096,6 +9010,10 @@ func (p *SfplParser) Text_Sempred(localctx antlr.RuleContext, predIndex int) boo
 			p.GetCurrentToken().GetText() == "enabled" ||
 			p.GetCurrentToken().GetText() == "warn_evttypes" ||
 			p.GetCurrentToken().GetText() == "skip-if-unknown-filter" ||
+			(p.GetCurrentToken().GetText() == "exceptions" && p.GetTokenStream().LA(2) == SfplParserDEF) ||
+			(p.GetCurrentToken().GetText() == "key" && p.GetTokenStream().LA(2) == SfplParserDEF) ||
+			(p.GetCurrentToken().GetText() == "window" && p.GetTokenStream().LA(2) == SfplParserDEF) ||
+			(p.GetCurrentToken().GetText() == "steps" && p.GetTokenStream().LA(2) == SfplParserDEF) ||
 			p.GetCurrentToken().GetText() == "append")
 
 	default:
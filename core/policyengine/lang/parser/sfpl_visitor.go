@@ -46,6 +46,21 @@ type SfplVisitor interface {
 	// Visit a parse tree produced by SfplParser#term.
 	VisitTerm(ctx *TermContext) interface{}
 
+	// Visit a parse tree produced by SfplParser#aggregate.
+	VisitAggregate(ctx *AggregateContext) interface{}
+
+	// Visit a parse tree produced by SfplParser#arith_expression.
+	VisitArith_expression(ctx *Arith_expressionContext) interface{}
+
+	// Visit a parse tree produced by SfplParser#arith_term.
+	VisitArith_term(ctx *Arith_termContext) interface{}
+
+	// Visit a parse tree produced by SfplParser#arith_factor.
+	VisitArith_factor(ctx *Arith_factorContext) interface{}
+
+	// Visit a parse tree produced by SfplParser#function.
+	VisitFunction(ctx *FunctionContext) interface{}
+
 	// Visit a parse tree produced by SfplParser#items.
 	VisitItems(ctx *ItemsContext) interface{}
 
@@ -70,6 +85,36 @@ type SfplVisitor interface {
 	// Visit a parse tree produced by SfplParser#fappend.
 	VisitFappend(ctx *FappendContext) interface{}
 
+	// Visit a parse tree produced by SfplParser#exceptions.
+	VisitExceptions(ctx *ExceptionsContext) interface{}
+
+	// Visit a parse tree produced by SfplParser#exception.
+	VisitException(ctx *ExceptionContext) interface{}
+
+	// Visit a parse tree produced by SfplParser#fields.
+	VisitFields(ctx *FieldsContext) interface{}
+
+	// Visit a parse tree produced by SfplParser#comps.
+	VisitComps(ctx *CompsContext) interface{}
+
+	// Visit a parse tree produced by SfplParser#comp.
+	VisitComp(ctx *CompContext) interface{}
+
+	// Visit a parse tree produced by SfplParser#values.
+	VisitValues(ctx *ValuesContext) interface{}
+
+	// Visit a parse tree produced by SfplParser#value.
+	VisitValue(ctx *ValueContext) interface{}
+
+	// Visit a parse tree produced by SfplParser#tuple.
+	VisitTuple(ctx *TupleContext) interface{}
+
+	// Visit a parse tree produced by SfplParser#steps.
+	VisitSteps(ctx *StepsContext) interface{}
+
+	// Visit a parse tree produced by SfplParser#step.
+	VisitStep(ctx *StepContext) interface{}
+
 	// Visit a parse tree produced by SfplParser#variable.
 	VisitVariable(ctx *VariableContext) interface{}
 
@@ -84,4 +129,7 @@ type SfplVisitor interface {
 
 	// Visit a parse tree produced by SfplParser#unary_operator.
 	VisitUnary_operator(ctx *Unary_operatorContext) interface{}
+
+	// Visit a parse tree produced by SfplParser#identifier.
+	VisitIdentifier(ctx *IdentifierContext) interface{}
 }
@@ -0,0 +1,230 @@
+package monitor
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/sysflow-telemetry/sf-processor/core/policyengine/engine"
+	"gopkg.in/yaml.v3"
+)
+
+// Name of the manifest file in policy bundles.
+const manifestName = "manifest.yaml"
+
+// Maximum uncompressed size of policy bundles.
+const maxBundleSize = 64 << 20
+
+// Regular expression for sha256 hex digests in bundle manifests.
+var sha256re = regexp.MustCompile(`^[0-9a-f]{64}$`)
+
+// bundleManifest lists the policy files of a bundle, in compile order, with their sha256 digests.
+type bundleManifest struct {
+	Version   string        `yaml:"version"`
+	Policies  []bundleEntry `yaml:"policies"`
+	Signature string        `yaml:"signature"`
+}
+
+// bundleEntry is a policy file listed in a bundle manifest.
+type bundleEntry struct {
+	Path   string `yaml:"path"`
+	SHA256 string `yaml:"sha256"`
+}
+
+// signedContent returns the manifest content covered by the bundle signature:
+// a version line followed by a "<sha256>  <path>" line for each policy file, in manifest order.
+// Versions and paths must not contain line breaks, so that the content can't be split into other lines.
+func (m *bundleManifest) signedContent() []byte {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "version: %s\n", m.Version)
+	for _, e := range m.Policies {
+		fmt.Fprintf(&b, "%s  %s\n", e.SHA256, e.Path)
+	}
+	return b.Bytes()
+}
+
+// policyBundle is a verified policy bundle.
+type policyBundle struct {
+	engine.PolicyBundle
+	manifest *bundleManifest
+	files    map[string][]byte
+}
+
+// loadPublicKeys reads the PEM-encoded ed25519 public keys in paths.
+func loadPublicKeys(paths []string) ([]ed25519.PublicKey, error) {
+	keys := make([]ed25519.PublicKey, 0, len(paths))
+	for _, p := range paths {
+		data, err := ioutil.ReadFile(p)
+		if err != nil {
+			return nil, err
+		}
+		block, _ := pem.Decode(data)
+		if block == nil {
+			return nil, errors.New("No PEM data found in public key file " + p)
+		}
+		pub, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse public key file %s, %v", p, err)
+		}
+		key, ok := pub.(ed25519.PublicKey)
+		if !ok {
+			return nil, errors.New("Public key file " + p + " does not contain an ed25519 key")
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// readBundle reads the regular files of the gzipped tar archive at bundlePath.
+func readBundle(bundlePath string) (map[string][]byte, error) {
+	f, err := os.Open(bundlePath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, err
+	}
+	defer gz.Close()
+	files := make(map[string][]byte)
+	size := int64(0)
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if hdr.Typeflag == tar.TypeDir {
+			continue
+		}
+		name := path.Clean(hdr.Name)
+		if hdr.Typeflag != tar.TypeReg || !isLocalPath(name) {
+			return nil, errors.New("Unsupported bundle entry " + hdr.Name)
+		}
+		if _, ok := files[name]; ok {
+			return nil, errors.New("Duplicate bundle entry " + hdr.Name)
+		}
+		if size += hdr.Size; size > maxBundleSize {
+			return nil, errors.New("Bundle exceeds maximum size")
+		}
+		data, err := ioutil.ReadAll(io.LimitReader(tr, hdr.Size))
+		if err != nil {
+			return nil, err
+		}
+		files[name] = data
+	}
+	return files, nil
+}
+
+// isLocalPath checks whether the cleaned slash-separated path name stays within the bundle root.
+func isLocalPath(name string) bool {
+	return name != "." && name != ".." && !path.IsAbs(name) && !strings.HasPrefix(name, "../")
+}
+
+// loadBundle reads the policy bundle at bundlePath and verifies it against keys.
+// A bundle is verified if its manifest signature is valid under one of keys, and the
+// bundle contains exactly the manifest and the policy files it lists, with matching digests.
+func loadBundle(bundlePath string, keys []ed25519.PublicKey) (*policyBundle, error) {
+	files, err := readBundle(bundlePath)
+	if err != nil {
+		return nil, err
+	}
+	data, ok := files[manifestName]
+	if !ok {
+		return nil, errors.New("No " + manifestName + " found in bundle")
+	}
+	delete(files, manifestName)
+	m := &bundleManifest{}
+	if err := yaml.Unmarshal(data, m); err != nil {
+		return nil, fmt.Errorf("unable to parse bundle manifest, %v", err)
+	}
+	if m.Version == "" || strings.ContainsAny(m.Version, "\r\n") || len(m.Policies) == 0 {
+		return nil, errors.New("Bundle manifest must have a single-line version and at least one policy")
+	}
+	for _, e := range m.Policies {
+		if strings.ContainsAny(e.Path, "\r\n") || !sha256re.MatchString(e.SHA256) {
+			return nil, errors.New("Bundle manifest entries must have a single-line path and a sha256 hex digest")
+		}
+	}
+	sig, err := base64.StdEncoding.DecodeString(m.Signature)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode bundle signature, %v", err)
+	}
+	content := m.signedContent()
+	if !verifySignature(keys, content, sig) {
+		return nil, errors.New("Bundle signature is not valid under any configured public key")
+	}
+	listed := make(map[string]bool)
+	for _, e := range m.Policies {
+		if listed[e.Path] {
+			return nil, errors.New("Policy file " + e.Path + " listed more than once in bundle manifest")
+		}
+		listed[e.Path] = true
+		if !strings.HasSuffix(e.Path, ".yaml") && !strings.HasSuffix(e.Path, ".yml") {
+			return nil, errors.New("Policy file " + e.Path + " must have extension .yaml or .yml")
+		}
+		f, ok := files[e.Path]
+		if !ok {
+			return nil, errors.New("Policy file " + e.Path + " listed in bundle manifest is missing from bundle")
+		}
+		cs := sha256.Sum256(f)
+		if hex.EncodeToString(cs[:]) != e.SHA256 {
+			return nil, errors.New("Digest mismatch for policy file " + e.Path)
+		}
+	}
+	for name := range files {
+		if !listed[name] {
+			return nil, errors.New("File " + name + " is not listed in bundle manifest")
+		}
+	}
+	digest := sha256.Sum256(content)
+	return &policyBundle{
+		PolicyBundle: engine.PolicyBundle{Version: m.Version, Digest: hex.EncodeToString(digest[:])},
+		manifest:     m,
+		files:        files}, nil
+}
+
+// verifySignature checks whether sig is a valid signature of msg under one of keys.
+func verifySignature(keys []ed25519.PublicKey, msg []byte, sig []byte) bool {
+	for _, k := range keys {
+		if ed25519.Verify(k, msg, sig) {
+			return true
+		}
+	}
+	return false
+}
+
+// extract writes the policy files of the bundle into directory dir, returning their paths in compile order.
+func (b *policyBundle) extract(dir string) ([]string, error) {
+	paths := make([]string, 0, len(b.manifest.Policies))
+	for _, e := range b.manifest.Policies {
+		p := filepath.Join(dir, filepath.FromSlash(e.Path))
+		if err := os.MkdirAll(filepath.Dir(p), 0700); err != nil {
+			return nil, err
+		}
+		if err := ioutil.WriteFile(p, b.files[e.Path], 0600); err != nil {
+			return nil, err
+		}
+		paths = append(paths, p)
+	}
+	return paths, nil
+}
@@ -0,0 +1,164 @@
+package monitor_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/sysflow-telemetry/sf-apis/go/logger"
+	"github.com/sysflow-telemetry/sf-apis/go/sfgo"
+	"github.com/sysflow-telemetry/sf-processor/core/policyengine/engine"
+	"github.com/sysflow-telemetry/sf-processor/core/policyengine/monitor"
+)
+
+const bundlePolicy = "- rule: A\n  desc: rule a\n  condition: a = a\n  action: [alert]\n  priority: low\n"
+
+func TestMain(m *testing.M) {
+	logger.InitLoggers(logger.TRACE)
+	os.Exit(m.Run())
+}
+
+func writeKey(t *testing.T, dir string, name string, key ed25519.PublicKey) string {
+	der, err := x509.MarshalPKIXPublicKey(key)
+	assert.NoError(t, err)
+	path := filepath.Join(dir, name)
+	assert.NoError(t, ioutil.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0644))
+	return path
+}
+
+// writeBundle writes a bundle listing policies, signed with key, and containing files.
+func writeBundle(t *testing.T, path string, key ed25519.PrivateKey, version string, policies map[string]string, files map[string]string) {
+	content := "version: " + version + "\n"
+	manifest := "version: " + version + "\npolicies:\n"
+	names := make([]string, 0, len(policies))
+	for name := range policies {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		cs := sha256.Sum256([]byte(policies[name]))
+		content += hex.EncodeToString(cs[:]) + "  " + name + "\n"
+		manifest += fmt.Sprintf("  - path: %s\n    sha256: %s\n", name, hex.EncodeToString(cs[:]))
+	}
+	manifest += "signature: " + base64.StdEncoding.EncodeToString(ed25519.Sign(key, []byte(content))) + "\n"
+	files["manifest.yaml"] = manifest
+	writeArchive(t, path, files)
+}
+
+// writeArchive writes a gzipped tar archive containing files.
+func writeArchive(t *testing.T, path string, files map[string]string) {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for name, data := range files {
+		assert.NoError(t, tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(data)), Typeflag: tar.TypeReg}))
+		_, err := tw.Write([]byte(data))
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, tw.Close())
+	assert.NoError(t, gz.Close())
+	assert.NoError(t, ioutil.WriteFile(path, buf.Bytes(), 0644))
+}
+
+func TestBundlePolicyMonitor(t *testing.T) {
+	dir, err := ioutil.TempDir("", "bundle")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	otherPub, otherPriv, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	bundle := filepath.Join(dir, "policies.tar.gz")
+	conf := engine.Config{PoliciesPath: bundle, Monitor: engine.BundleType,
+		BundleConfig: engine.BundleConfig{BundleKeys: []string{writeKey(t, dir, "other.pem", otherPub), writeKey(t, dir, "key.pem", pub)}}}
+	pm, err := monitor.NewPolicyMonitor(conf)
+	assert.NoError(t, err)
+	policies := map[string]string{"a.yaml": bundlePolicy}
+
+	// verified bundle
+	writeBundle(t, bundle, priv, "1.0.0", policies, map[string]string{"a.yaml": bundlePolicy})
+	assert.NoError(t, pm.CheckForPolicyUpdate())
+	pi := <-pm.GetInterpreterChan()
+	b := pi.Bundle()
+	if assert.NotNil(t, b) {
+		assert.Equal(t, "1.0.0", b.Version)
+		assert.Len(t, b.Digest, 64)
+	}
+	r := engine.NewRecord(sfgo.FlatRecord{}, nil)
+	match, _ := pi.Process(true, false, r)
+	assert.True(t, match)
+	assert.Equal(t, b, r.Ctx.GetBundle())
+
+	// unchanged bundle is not recompiled
+	assert.NoError(t, pm.CheckForPolicyUpdate())
+	assert.Len(t, pm.GetInterpreterChan(), 0)
+
+	// bundle signed with any of the configured keys is verified
+	writeBundle(t, bundle, otherPriv, "1.0.1", policies, map[string]string{"a.yaml": bundlePolicy})
+	assert.NoError(t, pm.CheckForPolicyUpdate())
+	assert.Same(t, pi, <-pm.GetInterpreterChan())
+	assert.Equal(t, "1.0.1", pi.Bundle().Version)
+
+	// rejected bundles
+	_, wrongPriv, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	writeBundle(t, bundle, wrongPriv, "2.0.0", policies, map[string]string{"a.yaml": bundlePolicy})
+	assert.Error(t, pm.CheckForPolicyUpdate())
+	writeBundle(t, bundle, priv, "2.0.0", policies, map[string]string{"a.yaml": bundlePolicy + "  enabled: false\n"})
+	assert.Error(t, pm.CheckForPolicyUpdate())
+	writeBundle(t, bundle, priv, "2.0.0", policies, map[string]string{"a.yaml": bundlePolicy, "b.yaml": bundlePolicy})
+	assert.Error(t, pm.CheckForPolicyUpdate())
+	writeBundle(t, bundle, priv, "2.0.0", map[string]string{"a.yaml": bundlePolicy, "b.yaml": bundlePolicy}, map[string]string{"a.yaml": bundlePolicy})
+	assert.Error(t, pm.CheckForPolicyUpdate())
+	writeBundle(t, bundle, priv, "2.0.0", map[string]string{"../a.yaml": bundlePolicy}, map[string]string{"../a.yaml": bundlePolicy})
+	assert.Error(t, pm.CheckForPolicyUpdate())
+	assert.Len(t, pm.GetInterpreterChan(), 0)
+	assert.Equal(t, "1.0.1", pi.Bundle().Version)
+}
+
+func TestBundleManifestLineInjection(t *testing.T) {
+	dir, err := ioutil.TempDir("", "bundle")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	bundle := filepath.Join(dir, "policies.tar.gz")
+	pm, err := monitor.NewPolicyMonitor(engine.Config{PoliciesPath: bundle, Monitor: engine.BundleType,
+		BundleConfig: engine.BundleConfig{BundleKeys: []string{writeKey(t, dir, "key.pem", pub)}}})
+	assert.NoError(t, err)
+
+	// the signature of a bundle with policies a.yaml and b.yaml is reused for a
+	// single entry whose path spans the line of b.yaml, dropping b.yaml's policies
+	a := sha256.Sum256([]byte(bundlePolicy))
+	b := sha256.Sum256([]byte(bundlePolicy + "  enabled: false\n"))
+	ha, hb := hex.EncodeToString(a[:]), hex.EncodeToString(b[:])
+	sig := ed25519.Sign(priv, []byte("version: 1.0.0\n"+ha+"  a.yaml\n"+hb+"  b.yaml\n"))
+	name := "a.yaml\n" + hb + "  b.yaml"
+	writeArchive(t, bundle, map[string]string{
+		name: bundlePolicy,
+		"manifest.yaml": fmt.Sprintf("version: 1.0.0\npolicies:\n  - path: %q\n    sha256: %s\nsignature: %s\n",
+			name, ha, base64.StdEncoding.EncodeToString(sig)),
+	})
+	assert.Error(t, pm.CheckForPolicyUpdate())
+	assert.Len(t, pm.GetInterpreterChan(), 0)
+}
+
+func TestBundlePolicyMonitorKeys(t *testing.T) {
+	_, err := monitor.NewPolicyMonitor(engine.Config{Monitor: engine.BundleType,
+		BundleConfig: engine.BundleConfig{BundleKeys: []string{"/nonexistent/key.pem"}}})
+	assert.Error(t, err)
+}
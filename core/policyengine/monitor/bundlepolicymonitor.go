@@ -0,0 +1,105 @@
+package monitor
+
+import (
+	"crypto/ed25519"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/sysflow-telemetry/sf-apis/go/logger"
+	"github.com/sysflow-telemetry/sf-processor/core/policyengine/engine"
+)
+
+// BundlePolicyMonitor is an object that monitors a local signed policy bundle
+// for changes and recompiles its policy interpreter if a new verified bundle is found.
+type BundlePolicyMonitor struct {
+	config    engine.Config
+	pi        *engine.PolicyInterpreter
+	interChan chan *engine.PolicyInterpreter
+	watcher   *policyWatcher
+	keys      []ed25519.PublicKey
+	digest    string
+}
+
+// NewBundlePolicyMonitor returns a new bundle policy monitor object given an engine configuration.
+func NewBundlePolicyMonitor(config engine.Config) (PolicyMonitor, error) {
+	keys, err := loadPublicKeys(config.BundleKeys)
+	if err != nil {
+		logger.Error.Printf("unable to load policy bundle public keys %v", err)
+		return nil, err
+	}
+	if len(keys) == 0 {
+		return nil, errors.New("No public keys configured for policy bundle verification")
+	}
+	watcher, err := newPolicyWatcher()
+	if err != nil {
+		return nil, err
+	}
+	return &BundlePolicyMonitor{config: config, pi: engine.NewPolicyInterpreter(config),
+		interChan: make(chan *engine.PolicyInterpreter, 10), watcher: watcher, keys: keys}, nil
+}
+
+// GetInterpreterChan returns a channel of the policy interpreter after its policies have been compiled.
+// The monitor recompiles the same interpreter on updates, so the channel only needs to be read once.
+func (p *BundlePolicyMonitor) GetInterpreterChan() chan *engine.PolicyInterpreter {
+	return p.interChan
+}
+
+func (p *BundlePolicyMonitor) isBundleEvent(event fsnotify.Event) bool {
+	return (event.Op == fsnotify.Create || event.Op == fsnotify.Write || event.Op == fsnotify.Rename) &&
+		filepath.Clean(event.Name) == filepath.Clean(p.config.PoliciesPath)
+}
+
+// StartMonitor starts a thread to monitor the directory of the policy bundle.
+// The bundle directory is watched, rather than the bundle itself, so that bundles can be replaced by renaming.
+func (p *BundlePolicyMonitor) StartMonitor() error {
+	return p.watcher.start(filepath.Dir(p.config.PoliciesPath), p.isBundleEvent, func() {
+		logger.Info.Println("Attempting to compile new policy bundle")
+		p.CheckForPolicyUpdate() //nolint:errcheck
+	})
+}
+
+// StopMonitor sends a signal to exit the monitor thread.
+func (p *BundlePolicyMonitor) StopMonitor() error {
+	p.watcher.stop()
+	return nil
+}
+
+// CheckForPolicyUpdate recompiles the policy interpreter from the policy bundle, if the bundle
+// is verified and differs from the active one. Unverified bundles are never compiled.
+func (p *BundlePolicyMonitor) CheckForPolicyUpdate() error {
+	b, err := loadBundle(p.config.PoliciesPath, p.keys)
+	if err != nil {
+		logger.Error.Printf("unable to verify policy bundle %s. Not using new policy bundle. %v", p.config.PoliciesPath, err)
+		return err
+	}
+	if b.Digest == p.digest {
+		logger.Info.Printf("Policy bundle version %s with digest %s is already active", b.Version, b.Digest)
+		return nil
+	}
+	dir, err := ioutil.TempDir("", "sfbundle")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+	paths, err := b.extract(dir)
+	if err != nil {
+		logger.Error.Printf("unable to extract policy bundle %s. Not using new policy bundle. %v", p.config.PoliciesPath, err)
+		return err
+	}
+	bundle := b.PolicyBundle
+	if err = p.pi.CompileBundle(&bundle, paths...); err != nil {
+		logger.Error.Printf("unable to compile policy bundle %s. Not using new policy bundle. %v", p.config.PoliciesPath, err)
+		return err
+	}
+	p.digest = b.Digest
+	select {
+	case p.interChan <- p.pi:
+		logger.Info.Printf("pushed policy interpreter on channel for policy bundle version %s with digest %s", b.Version, b.Digest)
+	default:
+		logger.Info.Printf("reloaded policy interpreter with policy bundle version %s with digest %s", b.Version, b.Digest)
+	}
+	return nil
+}
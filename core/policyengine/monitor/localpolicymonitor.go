@@ -7,7 +7,6 @@ import (
 	"io"
 	"os"
 	"strings"
-	"time"
 
 	"github.com/fsnotify/fsnotify"
 	"github.com/sysflow-telemetry/sf-apis/go/ioutils"
@@ -16,53 +15,31 @@ import (
 )
 
 // LocalPolicyMonitor is an object that monitors the local policy file
-// directory for changes and compiles a new policy engine if changes occur.
+// directory for changes and recompiles its policy interpreter if changes occur.
 type LocalPolicyMonitor struct {
 	config    engine.Config
+	pi        *engine.PolicyInterpreter
 	interChan chan *engine.PolicyInterpreter
-	watcher   *fsnotify.Watcher
-	started   bool
-	done      chan bool
+	watcher   *policyWatcher
 	policies  map[string][]byte
 }
 
 // NewLocalPolicyMonitor returns a new policy monitor object given an engine configuration.
 func NewLocalPolicyMonitor(config engine.Config) (PolicyMonitor, error) {
-	lpm := &LocalPolicyMonitor{config: config, interChan: make(chan *engine.PolicyInterpreter, 10), started: false,
-		done: make(chan bool), policies: make(map[string][]byte)}
-	watcher, err := fsnotify.NewWatcher()
+	watcher, err := newPolicyWatcher()
 	if err != nil {
-		logger.Error.Printf("unable to create policy watcher object %v", err)
 		return nil, err
 	}
-	lpm.watcher = watcher
-	return lpm, err
+	return &LocalPolicyMonitor{config: config, pi: engine.NewPolicyInterpreter(config),
+		interChan: make(chan *engine.PolicyInterpreter, 10), watcher: watcher, policies: make(map[string][]byte)}, nil
 }
 
-// GetInterpreterChan returns a channel of the policy engine after they have been built.
-// This channel can be checked for policy engines that are ready to be used.
+// GetInterpreterChan returns a channel of the policy interpreter after its policies have been compiled.
+// The monitor recompiles the same interpreter on updates, so the channel only needs to be read once.
 func (p *LocalPolicyMonitor) GetInterpreterChan() chan *engine.PolicyInterpreter {
 	return p.interChan
 }
 
-func (p *LocalPolicyMonitor) dequeueFileEvents() int {
-	count := 0
-	i := 0
-	for i < 1000 {
-		select {
-		case ev := <-p.watcher.Events:
-			logger.Trace.Printf("Queued Event %#v, Operation: %s\n", ev, ev.Op.String())
-			if hasModifiedYaml(ev) {
-				count++
-			}
-		default:
-			time.Sleep(10 * time.Millisecond)
-			i++
-		}
-	}
-	return count
-}
-
 func hasModifiedYaml(event fsnotify.Event) bool {
 	result := false
 	if (event.Op == fsnotify.Create || event.Op == fsnotify.Remove ||
@@ -125,67 +102,33 @@ func (p *LocalPolicyMonitor) calculateChecksum() (bool, []string, error) {
 
 // StartMonitor starts a thread to monitor the local policy directory.
 func (p *LocalPolicyMonitor) StartMonitor() error {
-	if p.started {
-		return nil
-	}
-	go func() {
-		for {
-			yamlCount := 0
-			select {
-			case <-p.done:
-				logger.Trace.Printf("Policy monitor received done event.. exiting..")
-				return
-			// watch for events
-			case event := <-p.watcher.Events:
-				logger.Trace.Printf("EVENT! %#v, Operation: %s\n", event, event.Op.String())
-				if hasModifiedYaml(event) {
-					yamlCount++
-				}
-
-				//time.Sleep(10 * time.Second)
-				yamlCount += p.dequeueFileEvents()
-				//breakout := false
+	return p.watcher.start(p.config.PoliciesPath, hasModifiedYaml, p.update)
+}
 
-				logger.Trace.Printf("Received %d more file events.\n", yamlCount)
-				if yamlCount > 0 {
-					changes, policyFiles, err := p.calculateChecksum()
-					if err != nil {
-						if policyFiles != nil && len(policyFiles) == 0 {
-							logger.Error.Printf("There are no policy files in the policy path %s. Waiting for policies to be added.", p.config.PoliciesPath)
-							continue
-						} else {
-							logger.Error.Printf("Unable to calculate checksums on policies.. attempting to compile policies")
-						}
-					}
-					if changes || err != nil {
-						logger.Info.Println("Attempting to compile new policy")
-						p.CheckForPolicyUpdate() //nolint:errcheck
-					}
-				}
-			// watch for errors
-			case err := <-p.watcher.Errors:
-				logger.Error.Printf("Error while watching policy directory %s, %v", p.config.PoliciesPath, err)
-			}
+// update recompiles the policy interpreter if the checksums of the policy files changed.
+func (p *LocalPolicyMonitor) update() {
+	changes, policyFiles, err := p.calculateChecksum()
+	if err != nil {
+		if policyFiles != nil && len(policyFiles) == 0 {
+			logger.Error.Printf("There are no policy files in the policy path %s. Waiting for policies to be added.", p.config.PoliciesPath)
+			return
 		}
-	}()
-	p.started = true
-	if err := p.watcher.Add(p.config.PoliciesPath); err != nil {
-		logger.Error.Printf("Unable to add watch to directory %s, %v", p.config.PoliciesPath, err)
-		return err
+		logger.Error.Printf("Unable to calculate checksums on policies.. attempting to compile policies")
+	}
+	if changes || err != nil {
+		logger.Info.Println("Attempting to compile new policy")
+		p.CheckForPolicyUpdate() //nolint:errcheck
 	}
-	return nil
 }
 
 // StopMonitor sends a signal to exit the monitor thread.
 func (p *LocalPolicyMonitor) StopMonitor() error {
-	p.started = false
-	p.done <- true
+	p.watcher.stop()
 	return nil
 }
 
-// CheckForPolicyUpdate creates a new policy engine based on updated policies.
+// CheckForPolicyUpdate recompiles the policy interpreter with the updated policies.
 func (p *LocalPolicyMonitor) CheckForPolicyUpdate() error {
-	pi := engine.NewPolicyInterpreter(p.config)
 	paths, err := ioutils.ListFilePaths(p.config.PoliciesPath, ".yaml")
 	if err != nil {
 		return err
@@ -193,16 +136,16 @@ func (p *LocalPolicyMonitor) CheckForPolicyUpdate() error {
 	if len(paths) == 0 {
 		return errors.New("No policy files with extension .yaml found in policy directory: " + p.config.PoliciesPath)
 	}
-	err = pi.Compile(paths...)
+	err = p.pi.Compile(paths...)
 	if err != nil {
 		logger.Error.Printf("unable to compile policy files in directory %s. Not using new policy files. %v", p.config.PoliciesPath, err)
 		return err
 	}
 	select {
-	case p.interChan <- pi:
-		logger.Info.Printf("pushed new policy interpreter on channel")
+	case p.interChan <- p.pi:
+		logger.Info.Printf("pushed policy interpreter on channel")
 	default:
-		logger.Error.Printf("unable to push new policy interpreter to policy thread.")
+		logger.Info.Printf("reloaded policy interpreter with policies in directory %s", p.config.PoliciesPath)
 	}
 
 	return nil
@@ -7,7 +7,7 @@ import (
 )
 
 // PolicyMonitor is an interface representing policy monitor objects.
-// Currently the interface supports a local directory policy monitor.
+// Currently the interface supports a local directory policy monitor and a local signed policy bundle monitor.
 type PolicyMonitor interface {
 	GetInterpreterChan() chan *engine.PolicyInterpreter
 	StartMonitor() error
@@ -20,5 +20,8 @@ func NewPolicyMonitor(config engine.Config) (PolicyMonitor, error) {
 	if config.Monitor == engine.LocalType {
 		return NewLocalPolicyMonitor(config)
 	}
+	if config.Monitor == engine.BundleType {
+		return NewBundlePolicyMonitor(config)
+	}
 	return nil, errors.New("Policy monitor of type: " + config.Monitor.String() + " is not supported.")
 }
@@ -0,0 +1,92 @@
+package monitor
+
+import (
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/sysflow-telemetry/sf-apis/go/logger"
+)
+
+// Number of polls, 10ms apart, for which file events following a first event are collected.
+const dequeuePolls = 1000
+
+// policyWatcher watches a policy directory, and calls an update function after batches of file events.
+type policyWatcher struct {
+	watcher *fsnotify.Watcher
+	started bool
+	done    chan bool
+}
+
+// newPolicyWatcher creates a new policy watcher.
+func newPolicyWatcher() (*policyWatcher, error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		logger.Error.Printf("unable to create policy watcher object %v", err)
+		return nil, err
+	}
+	return &policyWatcher{watcher: watcher, done: make(chan bool)}, nil
+}
+
+// dequeueFileEvents collects the file events queued shortly after a first event,
+// and returns the number of events matched by match.
+func (w *policyWatcher) dequeueFileEvents(match func(fsnotify.Event) bool) int {
+	count := 0
+	i := 0
+	for i < dequeuePolls {
+		select {
+		case ev := <-w.watcher.Events:
+			logger.Trace.Printf("Queued Event %#v, Operation: %s\n", ev, ev.Op.String())
+			if match(ev) {
+				count++
+			}
+		default:
+			time.Sleep(10 * time.Millisecond)
+			i++
+		}
+	}
+	return count
+}
+
+// start starts a thread watching directory dir. Events are batched, and update is called
+// after each batch containing events matched by match.
+func (w *policyWatcher) start(dir string, match func(fsnotify.Event) bool, update func()) error {
+	if w.started {
+		return nil
+	}
+	go func() {
+		for {
+			select {
+			case <-w.done:
+				logger.Trace.Printf("Policy monitor received done event.. exiting..")
+				return
+			// watch for events
+			case event := <-w.watcher.Events:
+				logger.Trace.Printf("EVENT! %#v, Operation: %s\n", event, event.Op.String())
+				count := 0
+				if match(event) {
+					count++
+				}
+				count += w.dequeueFileEvents(match)
+				logger.Trace.Printf("Received %d more file events.\n", count)
+				if count > 0 {
+					update()
+				}
+			// watch for errors
+			case err := <-w.watcher.Errors:
+				logger.Error.Printf("Error while watching policy directory %s, %v", dir, err)
+			}
+		}
+	}()
+	w.started = true
+	if err := w.watcher.Add(dir); err != nil {
+		logger.Error.Printf("Unable to add watch to directory %s, %v", dir, err)
+		return err
+	}
+	return nil
+}
+
+// stop sends a signal to exit the watcher thread.
+func (w *policyWatcher) stop() {
+	w.started = false
+	w.done <- true
+}
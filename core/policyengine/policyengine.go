@@ -27,6 +27,7 @@ import (
 	"github.com/sysflow-telemetry/sf-apis/go/ioutils"
 	"github.com/sysflow-telemetry/sf-apis/go/logger"
 	"github.com/sysflow-telemetry/sf-apis/go/plugins"
+	"github.com/sysflow-telemetry/sf-apis/go/sfgo"
 	"github.com/sysflow-telemetry/sf-processor/core/cache"
 	"github.com/sysflow-telemetry/sf-processor/core/flattener"
 	"github.com/sysflow-telemetry/sf-processor/core/policyengine/engine"
@@ -124,11 +125,19 @@ func (s *PolicyEngine) Init(conf map[string]interface{}) error {
 	return nil
 }
 
-// Process implements the main loop of the plugin.
+// Process implements the main loop of the plugin. Records are read from a flattener channel, or from a record
+// channel when the policy engine runs after another record processor, such as the enricher.
 func (s *PolicyEngine) Process(ch interface{}, wg *sync.WaitGroup) {
-	in := ch.(*flattener.FlatChannel).In
+	var in chan *sfgo.FlatRecord
+	var recIn chan *engine.Record
+	if rc, ok := ch.(*engine.RecordChannel); ok {
+		recIn = rc.In
+	} else {
+		in = ch.(*flattener.FlatChannel).In
+	}
+	capacity := cap(in) + cap(recIn)
 	defer wg.Done()
-	logger.Trace.Println("Starting policy engine with capacity: ", cap(in))
+	logger.Trace.Println("Starting policy engine with capacity: ", capacity)
 	out := func(r *engine.Record) {
 		for _, c := range s.outCh {
 			c <- r
@@ -139,28 +148,68 @@ func (s *PolicyEngine) Process(ch interface{}, wg *sync.WaitGroup) {
 	if s.policyMonitor != nil {
 		s.policyMonitor.StartMonitor()
 	}
+	var statsTick <-chan time.Time
+	if !s.bypass && s.config.Stats {
+		// logs final statistics once workers have evaluated all records
+		defer func() { s.pi.LogStats() }()
+		if s.config.StatsInterval > 0 {
+			ticker := time.NewTicker(s.config.StatsInterval)
+			defer ticker.Stop()
+			statsTick = ticker.C
+		}
+	}
+	var process func(r *engine.Record)
+	if !s.bypass && s.config.Workers > 1 {
+		pool := newWorkerPool(s.config.Workers, capacity/s.config.Workers+1, s.config.ShardKey, s.filterOnly, out)
+		defer pool.stop()
+		process = func(r *engine.Record) {
+			pool.submit(s.pi, r)
+		}
+	} else if !s.bypass {
+		// outputs records in order, waiting for records with pending asynchronous actions before output channels are closed
+		ordered := engine.NewOrderedOutput(capacity+1, out)
+		defer ordered.Close()
+		process = func(r *engine.Record) {
+			s.pi.ProcessOrdered(true, s.filterOnly, r, ordered)
+		}
+	}
 
-	for {
-		if fc, ok := <-in; ok {
-			if s.bypass {
-				out(engine.NewRecord(*fc, s.tables))
-			} else if s.policyMonitor != nil {
-				now := time.Now()
-				if now.After(expiration) {
-					select {
-					case s.pi = <-s.policyMonitor.GetInterpreterChan():
-						logger.Info.Println("Updated policy engine in main policy engine thread.")
-					default:
-					}
-					expiration = now.Add(20 * time.Second)
+	handle := func(r *engine.Record) {
+		if s.bypass {
+			out(r)
+			return
+		}
+		if s.policyMonitor != nil {
+			now := time.Now()
+			if now.After(expiration) {
+				select {
+				case s.pi = <-s.policyMonitor.GetInterpreterChan():
+					logger.Info.Println("Updated policy engine in main policy engine thread.")
+				default:
 				}
-				s.pi.ProcessAsync(true, s.filterOnly, engine.NewRecord(*fc, s.tables), out)
-			} else {
-				s.pi.ProcessAsync(true, s.filterOnly, engine.NewRecord(*fc, s.tables), out)
+				expiration = now.Add(20 * time.Second)
+			}
+		}
+		process(r)
+	}
+
+RecLoop:
+	for {
+		select {
+		case fc, ok := <-in:
+			if !ok {
+				logger.Trace.Println("Input channel closed. Shutting down.")
+				break RecLoop
+			}
+			handle(engine.NewRecord(*fc, s.tables))
+		case r, ok := <-recIn:
+			if !ok {
+				logger.Trace.Println("Input channel closed. Shutting down.")
+				break RecLoop
 			}
-		} else {
-			logger.Trace.Println("Input channel closed. Shutting down.")
-			break
+			handle(r)
+		case <-statsTick:
+			s.pi.LogStats()
 		}
 	}
 }
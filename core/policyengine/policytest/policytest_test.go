@@ -0,0 +1,93 @@
+//
+// Copyright (C) 2020 IBM Corporation.
+//
+// Authors:
+// Frederico Araujo <frederico.araujo@ibm.com>
+// Teryl Taylor <terylt@ibm.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+package policytest_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/sysflow-telemetry/sf-apis/go/logger"
+	"github.com/sysflow-telemetry/sf-processor/core/policyengine/engine"
+	. "github.com/sysflow-telemetry/sf-processor/core/policyengine/policytest"
+)
+
+func TestMain(m *testing.M) {
+	logger.InitLoggers(logger.TRACE)
+	os.Exit(m.Run())
+}
+
+func TestPolicyTests(t *testing.T) {
+	tcs, err := LoadTestCases("../../../resources/policytests")
+	assert.NoError(t, err)
+	assert.NotEmpty(t, tcs)
+	for _, r := range RunAll(tcs, engine.Config{}) {
+		assert.NoError(t, r.Err, r.TestCase.Name)
+		assert.Empty(t, r.Failures, r.TestCase.Name)
+		assert.True(t, r.Records > 0, r.TestCase.Name)
+	}
+}
+
+func TestPolicyTestFailures(t *testing.T) {
+	dir, err := ioutil.TempDir("", "policytest")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	traces, _ := filepath.Abs("../../../resources/traces")
+	policies, _ := filepath.Abs("../../../resources/policies/runtimeintegrity")
+	tc := "- name: wrong expectations\n  trace: " + traces + "/tcp.sf\n  policies: [" + policies + "]\n" +
+		"  expect:\n    counts:\n      Interactive login detected: 3\n    records:\n      - index: 1\n        rules: [Interactive login detected]\n      - index: 100\n" +
+		"- name: missing trace\n  trace: missing.sf\n  policies: [" + policies + "]\n"
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "cases.yaml"), []byte(tc), 0644))
+	tcs, err := LoadTestCases(dir)
+	assert.NoError(t, err)
+	results := RunAll(tcs, engine.Config{})
+	assert.Equal(t, 2, len(results))
+	assert.False(t, results[0].Passed())
+	assert.Equal(t, []string{
+		`rule "Interactive login detected" hit 4 records, expected 3`,
+		"record 1 hit rules [Interactive shell], expected [Interactive login detected]",
+		"record 100 not found, trace has 10 records",
+	}, results[0].Failures)
+	assert.Error(t, results[1].Err)
+
+	var tap bytes.Buffer
+	assert.NoError(t, WriteReport(&tap, TAPReport, results))
+	assert.Contains(t, tap.String(), "1..2\nnot ok 1 - wrong expectations\n")
+	assert.Contains(t, tap.String(), "not ok 2 - missing trace\n# error: ")
+	var junit bytes.Buffer
+	assert.NoError(t, WriteReport(&junit, JUnitReport, results))
+	assert.Contains(t, junit.String(), `tests="2" failures="1" errors="1"`)
+}
+
+func TestLoadTestCasesErrors(t *testing.T) {
+	dir, err := ioutil.TempDir("", "policytest")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	_, err = LoadTestCases(dir)
+	assert.Error(t, err)
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "cases.yaml"), []byte("- name: no trace\n  policies: [a.yaml]\n"), 0644))
+	_, err = LoadTestCases(dir)
+	assert.Error(t, err)
+	_, err = ParseReportFormat("xml")
+	assert.Error(t, err)
+}
@@ -0,0 +1,162 @@
+//
+// Copyright (C) 2020 IBM Corporation.
+//
+// Authors:
+// Frederico Araujo <frederico.araujo@ibm.com>
+// Teryl Taylor <terylt@ibm.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+package policytest
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// ReportFormat denotes the format of test reports.
+type ReportFormat int
+
+// ReportFormat config options.
+const (
+	TAPReport ReportFormat = iota
+	JUnitReport
+)
+
+func (s ReportFormat) String() string {
+	return [...]string{"tap", "junit"}[s]
+}
+
+// ParseReportFormat parses a report format from string s.
+func ParseReportFormat(s string) (ReportFormat, error) {
+	switch s {
+	case TAPReport.String():
+		return TAPReport, nil
+	case JUnitReport.String():
+		return JUnitReport, nil
+	}
+	return TAPReport, fmt.Errorf("report format must be set to '%s' or '%s'", TAPReport.String(), JUnitReport.String())
+}
+
+// WriteReport writes a report of test results to w in format.
+func WriteReport(w io.Writer, format ReportFormat, results []Result) error {
+	if format == JUnitReport {
+		return writeJUnit(w, results)
+	}
+	return writeTAP(w, results)
+}
+
+// writeTAP writes a TAP report of test results to w.
+func writeTAP(w io.Writer, results []Result) error {
+	var sb strings.Builder
+	sb.WriteString("TAP version 13\n")
+	sb.WriteString(fmt.Sprintf("1..%d\n", len(results)))
+	for i, r := range results {
+		status := "ok"
+		if !r.Passed() {
+			status = "not ok"
+		}
+		sb.WriteString(fmt.Sprintf("%s %d - %s\n", status, i+1, r.TestCase.Name))
+		if r.Err != nil {
+			sb.WriteString(fmt.Sprintf("# error: %v\n", r.Err))
+		}
+		for _, f := range r.Failures {
+			sb.WriteString(fmt.Sprintf("# %s\n", f))
+		}
+	}
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// junitTestSuites is the root element of JUnit reports.
+type junitTestSuites struct {
+	XMLName xml.Name         `xml:"testsuites"`
+	Suites  []junitTestSuite `xml:"testsuite"`
+}
+
+// junitTestSuite holds the results of the test cases of a test case file.
+type junitTestSuite struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Errors   int             `xml:"errors,attr"`
+	Time     string          `xml:"time,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+// junitTestCase holds the result of a test case.
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitMessage `xml:"failure,omitempty"`
+	Error     *junitMessage `xml:"error,omitempty"`
+}
+
+// junitMessage holds the failure or error of a test case.
+type junitMessage struct {
+	Message string `xml:"message,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// writeJUnit writes a JUnit XML report of test results to w, with a test suite per test case file.
+func writeJUnit(w io.Writer, results []Result) error {
+	var report junitTestSuites
+	var durations []time.Duration
+	suites := make(map[string]int)
+	for _, r := range results {
+		idx, ok := suites[r.TestCase.File]
+		if !ok {
+			idx = len(report.Suites)
+			suites[r.TestCase.File] = idx
+			report.Suites = append(report.Suites, junitTestSuite{Name: r.TestCase.File})
+			durations = append(durations, 0)
+		}
+		suite := &report.Suites[idx]
+		durations[idx] += r.Duration
+		tc := junitTestCase{Name: r.TestCase.Name, ClassName: r.TestCase.File, Time: seconds(r.Duration)}
+		if r.Err != nil {
+			tc.Error = &junitMessage{Message: r.Err.Error()}
+			suite.Errors++
+		} else if len(r.Failures) > 0 {
+			tc.Failure = &junitMessage{
+				Message: fmt.Sprintf("%d expectations not met", len(r.Failures)),
+				Text:    strings.Join(r.Failures, "\n"),
+			}
+			suite.Failures++
+		}
+		suite.Tests++
+		suite.Cases = append(suite.Cases, tc)
+	}
+	for i := range report.Suites {
+		report.Suites[i].Time = seconds(durations[i])
+	}
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(report); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// seconds formats duration d in seconds.
+func seconds(d time.Duration) string {
+	return fmt.Sprintf("%.3f", d.Seconds())
+}
@@ -0,0 +1,262 @@
+//
+// Copyright (C) 2020 IBM Corporation.
+//
+// Authors:
+// Frederico Araujo <frederico.araujo@ibm.com>
+// Teryl Taylor <terylt@ibm.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+package policytest
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/linkedin/goavro"
+	"github.com/sysflow-telemetry/sf-apis/go/converter"
+	"github.com/sysflow-telemetry/sf-apis/go/ioutils"
+	"github.com/sysflow-telemetry/sf-apis/go/logger"
+	"github.com/sysflow-telemetry/sf-apis/go/plugins"
+	"github.com/sysflow-telemetry/sf-apis/go/sfgo"
+	"github.com/sysflow-telemetry/sf-processor/core/cache"
+	"github.com/sysflow-telemetry/sf-processor/core/flattener"
+	"github.com/sysflow-telemetry/sf-processor/core/policyengine/engine"
+	"github.com/sysflow-telemetry/sf-processor/core/processor"
+)
+
+const (
+	chanSize    = 1000
+	handlerName = "flattener"
+)
+
+// Result holds the outcome of a test case.
+type Result struct {
+	TestCase TestCase
+	Records  int
+	Failures []string
+	Err      error
+	Duration time.Duration
+}
+
+// Passed indicates whether the test case ran and met all its expectations.
+func (r Result) Passed() bool {
+	return r.Err == nil && len(r.Failures) == 0
+}
+
+// pluginCache is a plugin cache for the plugins of a test pipeline, which are created directly rather than by name.
+type pluginCache struct{}
+
+// AddDriver adds a driver factory to the plugin cache.
+func (pluginCache) AddDriver(name string, factory interface{}) {}
+
+// AddProcessor adds a processor factory to the plugin cache.
+func (pluginCache) AddProcessor(name string, factory interface{}) {}
+
+// AddChannel adds a channel factory to the plugin cache.
+func (pluginCache) AddChannel(name string, factory interface{}) {}
+
+// Run runs test case tc with policy engine configuration conf.
+func Run(tc TestCase, conf engine.Config) Result {
+	start := time.Now()
+	res := Result{TestCase: tc}
+	hits, err := evaluate(tc, conf)
+	res.Duration = time.Since(start)
+	if err != nil {
+		res.Err = err
+		return res
+	}
+	res.Records = len(hits)
+	res.Failures = check(tc.Expect, hits)
+	return res
+}
+
+// RunAll runs test cases tcs with policy engine configuration conf.
+func RunAll(tcs []TestCase, conf engine.Config) []Result {
+	results := make([]Result, 0, len(tcs))
+	for _, tc := range tcs {
+		logger.Trace.Printf("Running test case %s from %s\n", tc.Name, tc.File)
+		results = append(results, Run(tc, conf))
+	}
+	return results
+}
+
+// evaluate runs the trace of test case tc through the SysFlow processor and the policy interpreter,
+// and returns the names of the rules hit by each evaluated record.
+func evaluate(tc TestCase, conf engine.Config) ([][]string, error) {
+	pi := engine.NewPolicyInterpreter(conf)
+	var paths []string
+	for _, p := range tc.Policies {
+		ps, err := ioutils.ListFilePaths(p, ".yaml")
+		if err != nil {
+			return nil, err
+		}
+		paths = append(paths, ps...)
+	}
+	if len(paths) == 0 {
+		return nil, errors.New("No policy files with extension .yaml found in test case policies")
+	}
+	if err := pi.Compile(paths...); err != nil {
+		return nil, err
+	}
+	files, err := traceFiles(tc.Trace)
+	if err != nil {
+		return nil, err
+	}
+
+	// setup the sysflow reader with the flattener handler
+	proc := processor.NewSysFlowProcessor()
+	proc.Register(pluginCache{})
+	if err := proc.Init(map[string]interface{}{"handler": handlerName}); err != nil {
+		return nil, err
+	}
+	sfCh := &plugins.SFChannel{In: make(chan *sfgo.SysFlow, chanSize)}
+	flatCh := &flattener.FlatChannel{In: make(chan *sfgo.FlatRecord, chanSize)}
+	proc.SetOutChan([]interface{}{flatCh})
+	wg := new(sync.WaitGroup)
+	wg.Add(1)
+	go func() {
+		proc.Process(sfCh, wg)
+		proc.Cleanup()
+	}()
+
+	// read the trace while records are evaluated
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- readTrace(files, sfCh.In)
+		close(sfCh.In)
+	}()
+	tables := cache.GetInstance()
+	var hits [][]string
+	for fr := range flatCh.In {
+		var rules []string
+		if match, r := pi.Process(true, false, engine.NewRecord(*fr, tables)); match {
+			for _, rule := range r.Ctx.GetRules() {
+				rules = append(rules, rule.Name)
+			}
+		}
+		hits = append(hits, rules)
+	}
+	wg.Wait()
+	if err := <-errCh; err != nil {
+		return nil, err
+	}
+	return hits, nil
+}
+
+// traceFiles returns the trace files in path, which is a directory or a file.
+func traceFiles(path string) ([]string, error) {
+	fi, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if !fi.IsDir() {
+		return []string{path}, nil
+	}
+	files, err := ioutil.ReadDir(path)
+	if err != nil {
+		return nil, err
+	}
+	var paths []string
+	for _, f := range files {
+		if !f.IsDir() {
+			paths = append(paths, filepath.Join(path, f.Name()))
+		}
+	}
+	if len(paths) == 0 {
+		return nil, errors.New("No trace files present in directory: " + path)
+	}
+	return paths, nil
+}
+
+// readTrace reads the SysFlow records of trace files into channel records.
+func readTrace(files []string, records chan *sfgo.SysFlow) error {
+	sfobjcvter := converter.NewSFObjectConverter()
+	for _, fn := range files {
+		logger.Trace.Println("Loading file: " + fn)
+		f, err := os.Open(fn)
+		if err != nil {
+			return err
+		}
+		sreader, err := goavro.NewOCFReader(bufio.NewReader(f))
+		if err != nil {
+			f.Close()
+			return fmt.Errorf("unable to read trace %s: %v", fn, err)
+		}
+		for sreader.Scan() {
+			datum, err := sreader.Read()
+			if err != nil {
+				f.Close()
+				return fmt.Errorf("unable to read record from trace %s: %v", fn, err)
+			}
+			records <- sfobjcvter.ConvertToSysFlow(datum)
+		}
+		f.Close()
+	}
+	return nil
+}
+
+// check returns the expectations exp that are not met by the rules hit by records.
+func check(exp Expectations, hits [][]string) []string {
+	var failures []string
+	counts := make(map[string]int)
+	for _, rules := range hits {
+		for _, rule := range rules {
+			counts[rule]++
+		}
+	}
+	names := make([]string, 0, len(exp.Counts))
+	for name := range exp.Counts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if counts[name] != exp.Counts[name] {
+			failures = append(failures, fmt.Sprintf("rule %q hit %d records, expected %d", name, counts[name], exp.Counts[name]))
+		}
+	}
+	for _, re := range exp.Records {
+		if re.Index < 0 || re.Index >= len(hits) {
+			failures = append(failures, fmt.Sprintf("record %d not found, trace has %d records", re.Index, len(hits)))
+			continue
+		}
+		got := ruleSet(hits[re.Index])
+		want := ruleSet(re.Rules)
+		if got != want {
+			failures = append(failures, fmt.Sprintf("record %d hit rules [%s], expected [%s]", re.Index, got, want))
+		}
+	}
+	return failures
+}
+
+// ruleSet returns the sorted, comma-separated names of rules, without duplicates.
+func ruleSet(rules []string) string {
+	seen := make(map[string]bool)
+	var names []string
+	for _, r := range rules {
+		if !seen[r] {
+			seen[r] = true
+			names = append(names, r)
+		}
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
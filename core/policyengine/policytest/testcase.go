@@ -0,0 +1,106 @@
+//
+// Copyright (C) 2020 IBM Corporation.
+//
+// Authors:
+// Frederico Araujo <frederico.araujo@ibm.com>
+// Teryl Taylor <terylt@ibm.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+package policytest
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/sysflow-telemetry/sf-apis/go/ioutils"
+	"gopkg.in/yaml.v3"
+)
+
+// TestCase defines a policy test case, which runs a trace through a set of policies.
+// Trace and policy paths are relative to the test case file.
+type TestCase struct {
+	Name     string       `yaml:"name"`
+	Trace    string       `yaml:"trace"`
+	Policies []string     `yaml:"policies"`
+	Expect   Expectations `yaml:"expect"`
+	File     string       `yaml:"-"`
+}
+
+// Expectations defines the rule hits expected in a test case.
+type Expectations struct {
+	Counts  map[string]int      `yaml:"counts"`
+	Records []RecordExpectation `yaml:"records"`
+}
+
+// RecordExpectation defines the rules expected to hit a record, identified by its index
+// among the records evaluated by the policy engine, starting at 0.
+type RecordExpectation struct {
+	Index int      `yaml:"index"`
+	Rules []string `yaml:"rules"`
+}
+
+// LoadTestCases loads the test cases defined in the .yaml files of path, which is a directory or a file.
+func LoadTestCases(path string) ([]TestCase, error) {
+	paths, err := ioutils.ListFilePaths(path, ".yaml")
+	if err != nil {
+		return nil, err
+	}
+	if len(paths) == 0 {
+		return nil, errors.New("No test case files with extension .yaml found in path: " + path)
+	}
+	var tcs []TestCase
+	for _, p := range paths {
+		fileTcs, err := loadTestCaseFile(p)
+		if err != nil {
+			return nil, err
+		}
+		tcs = append(tcs, fileTcs...)
+	}
+	return tcs, nil
+}
+
+// loadTestCaseFile loads the test cases defined in the file at path, resolving their paths.
+func loadTestCaseFile(path string) ([]TestCase, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var tcs []TestCase
+	if err := yaml.Unmarshal(data, &tcs); err != nil {
+		return nil, fmt.Errorf("unable to parse test cases in %s: %v", path, err)
+	}
+	dir := filepath.Dir(path)
+	for i := range tcs {
+		tc := &tcs[i]
+		if tc.Name == "" || tc.Trace == "" || len(tc.Policies) == 0 {
+			return nil, fmt.Errorf("test case %d in %s must define a name, a trace, and policies", i+1, path)
+		}
+		tc.File = path
+		tc.Trace = resolvePath(dir, tc.Trace)
+		for j, p := range tc.Policies {
+			tc.Policies[j] = resolvePath(dir, p)
+		}
+	}
+	return tcs, nil
+}
+
+// resolvePath resolves path relative to directory dir.
+func resolvePath(dir string, path string) string {
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(dir, path)
+}
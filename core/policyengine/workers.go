@@ -0,0 +1,90 @@
+//
+// Copyright (C) 2020 IBM Corporation.
+//
+// Authors:
+// Frederico Araujo <frederico.araujo@ibm.com>
+// Teryl Taylor <terylt@ibm.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+package policyengine
+
+import (
+	"sync"
+
+	"github.com/cespare/xxhash"
+	"github.com/sysflow-telemetry/sf-apis/go/logger"
+	"github.com/sysflow-telemetry/sf-apis/go/sfgo"
+	"github.com/sysflow-telemetry/sf-processor/core/policyengine/engine"
+)
+
+// job is a record to be evaluated by a worker, with the policy interpreter that was active when it was received.
+type job struct {
+	pi *engine.PolicyInterpreter
+	r  *engine.Record
+}
+
+// workerPool evaluates records on a set of workers, each of which owns a shard of the records.
+// Records of the same shard are evaluated, and their output sent, in the order in which they were received,
+// including records output once their asynchronous actions complete.
+type workerPool struct {
+	shards   []chan job
+	shardKey engine.ShardKey
+	wg       sync.WaitGroup
+}
+
+// newWorkerPool creates a pool of n workers, with shard queues of the given capacity, that evaluate
+// records with filterOnly mode and send their output to out.
+func newWorkerPool(n int, capacity int, shardKey engine.ShardKey, filterOnly bool, out func(r *engine.Record)) *workerPool {
+	logger.Trace.Printf("Starting %d policy engine workers sharded by %s\n", n, shardKey.String())
+	p := &workerPool{shards: make([]chan job, n), shardKey: shardKey}
+	for i := range p.shards {
+		p.shards[i] = make(chan job, capacity)
+		p.wg.Add(1)
+		go func(jobs chan job) {
+			defer p.wg.Done()
+			ordered := engine.NewOrderedOutput(capacity+1, out)
+			defer ordered.Close()
+			for j := range jobs {
+				j.pi.ProcessOrdered(true, filterOnly, j.r, ordered)
+			}
+		}(p.shards[i])
+	}
+	return p
+}
+
+// submit queues record r for evaluation by interpreter pi on the worker of its shard.
+func (p *workerPool) submit(pi *engine.PolicyInterpreter, r *engine.Record) {
+	p.shards[p.shardOf(r)] <- job{pi: pi, r: r}
+}
+
+// shardOf returns the shard of record r, by hashing its container ID or process OID.
+func (p *workerPool) shardOf(r *engine.Record) int {
+	var h uint64
+	switch p.shardKey {
+	case engine.OIDShardKey:
+		h = uint64(r.GetInt(sfgo.PROC_OID_HPID_INT, sfgo.SYSFLOW_SRC))*0x9e3779b97f4a7c15 ^ uint64(r.GetInt(sfgo.PROC_OID_CREATETS_INT, sfgo.SYSFLOW_SRC))
+		h ^= h >> 29
+	default:
+		h = xxhash.Sum64String(r.GetStr(sfgo.CONT_ID_STR, sfgo.SYSFLOW_SRC))
+	}
+	return int(h % uint64(len(p.shards)))
+}
+
+// stop waits for the workers to evaluate and output all queued records, and stops them.
+func (p *workerPool) stop() {
+	for _, c := range p.shards {
+		close(c)
+	}
+	p.wg.Wait()
+}
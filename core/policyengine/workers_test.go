@@ -0,0 +1,123 @@
+//
+// Copyright (C) 2020 IBM Corporation.
+//
+// Authors:
+// Frederico Araujo <frederico.araujo@ibm.com>
+// Teryl Taylor <terylt@ibm.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+package policyengine
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/sysflow-telemetry/sf-apis/go/logger"
+	"github.com/sysflow-telemetry/sf-apis/go/sfgo"
+	"github.com/sysflow-telemetry/sf-processor/core/policyengine/engine"
+)
+
+func TestMain(m *testing.M) {
+	logger.InitLoggers(logger.TRACE)
+	os.Exit(m.Run())
+}
+
+// delayActuator completes asynchronously, after a delay set by the pid of records.
+type delayActuator struct{}
+
+func (delayActuator) Handle(rule engine.Rule, r *engine.Record) error {
+	return nil
+}
+
+func (delayActuator) HandleAsync(rule engine.Rule, r *engine.Record, done func()) {
+	time.AfterFunc(time.Duration(r.GetInt(sfgo.PROC_OID_HPID_INT, sfgo.SYSFLOW_SRC)%5)*time.Millisecond, done)
+}
+
+func newRecord(container string, pid int64, seq int64) *engine.Record {
+	fr := sfgo.FlatRecord{
+		Sources: []sfgo.Source{sfgo.SYSFLOW_SRC},
+		Ints:    [][]int64{make([]int64, sfgo.INT_ARRAY_SIZE)},
+		Strs:    [][]string{make([]string, sfgo.STR_ARRAY_SIZE)},
+	}
+	fr.Strs[0][sfgo.CONT_ID_STR] = container
+	fr.Strs[0][sfgo.PROC_EXE_STR] = "/bin/sh"
+	fr.Ints[0][sfgo.PROC_OID_HPID_INT] = pid
+	fr.Ints[0][sfgo.PROC_OID_CREATETS_INT] = 1000 + pid
+	fr.Ints[0][sfgo.TS_INT] = seq
+	return engine.NewRecord(fr, nil)
+}
+
+func TestShardOf(t *testing.T) {
+	for _, key := range []engine.ShardKey{engine.ContainerShardKey, engine.OIDShardKey} {
+		p := newWorkerPool(4, 1, key, false, func(r *engine.Record) {})
+		shards := make(map[int]bool)
+		for i := int64(0); i < 64; i++ {
+			c := string(rune('a' + i%16))
+			s := p.shardOf(newRecord(c, i, 0))
+			shards[s] = true
+			if key == engine.ContainerShardKey {
+				// records of the same container are assigned to the same shard, whatever their process
+				assert.Equal(t, s, p.shardOf(newRecord(c, i+100, 1)), key.String())
+			} else {
+				// records of the same process are assigned to the same shard, whatever their container
+				assert.Equal(t, s, p.shardOf(newRecord(c+"x", i, 1)), key.String())
+			}
+		}
+		assert.Greater(t, len(shards), 1, key.String())
+		p.stop()
+	}
+}
+
+func TestWorkerPoolOrder(t *testing.T) {
+	dir, err := ioutil.TempDir("", "policies")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	engine.RegisterAction("delay", func(conf engine.Config) (engine.Actuator, error) {
+		return delayActuator{}, nil
+	})
+	path := filepath.Join(dir, "a.yaml")
+	assert.NoError(t, ioutil.WriteFile(path, []byte("- rule: Delay\n  desc: delay\n  condition: sf.proc.exe = /bin/sh\n  action: [delay]\n  priority: low\n"), 0644))
+	pi := engine.NewPolicyInterpreter(engine.Config{})
+	assert.NoError(t, pi.Compile(path))
+
+	var mutex sync.Mutex
+	out := make(map[string][]int64)
+	n := 0
+	p := newWorkerPool(3, 2, engine.ContainerShardKey, false, func(r *engine.Record) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		c := r.GetStr(sfgo.CONT_ID_STR, sfgo.SYSFLOW_SRC)
+		out[c] = append(out[c], r.GetInt(sfgo.TS_INT, sfgo.SYSFLOW_SRC))
+		n++
+	})
+	containers := []string{"a", "b", "c", "d", "e"}
+	for i := int64(0); i < 200; i++ {
+		p.submit(pi, newRecord(containers[i%int64(len(containers))], i*7, i))
+	}
+	// stop returns once all queued records are output
+	p.stop()
+	assert.Equal(t, 200, n)
+	for _, c := range containers {
+		seq := out[c]
+		assert.Len(t, seq, 40, c)
+		for i := 1; i < len(seq); i++ {
+			assert.Less(t, seq[i-1], seq[i], c)
+		}
+	}
+}
@@ -20,6 +20,7 @@
 package main
 
 import (
+	"encoding/json"
 	"flag"
 	"fmt"
 	"os"
@@ -29,9 +30,13 @@ import (
 	"runtime/trace"
 	"syscall"
 
+	"github.com/sysflow-telemetry/sf-apis/go/ioutils"
 	"github.com/sysflow-telemetry/sf-apis/go/logger"
 	"github.com/sysflow-telemetry/sf-apis/go/plugins"
 	"github.com/sysflow-telemetry/sf-apis/go/sfgo"
+	"github.com/sysflow-telemetry/sf-processor/core/policyengine/actions"
+	"github.com/sysflow-telemetry/sf-processor/core/policyengine/engine"
+	"github.com/sysflow-telemetry/sf-processor/core/policyengine/policytest"
 	"github.com/sysflow-telemetry/sf-processor/driver/manifest"
 	"github.com/sysflow-telemetry/sf-processor/driver/pipeline"
 )
@@ -52,6 +57,55 @@ func initSigTerm() {
 
 func main() { os.Exit(run()) }
 
+func runPolicyTests(path string, format string) int {
+	reportFormat, err := policytest.ParseReportFormat(format)
+	if err != nil {
+		logger.Error.Println("Invalid test report format: ", err)
+		return 1
+	}
+	tcs, err := policytest.LoadTestCases(path)
+	if err != nil {
+		logger.Error.Println("Unable to load policy test cases: ", err)
+		return 1
+	}
+	results := policytest.RunAll(tcs, engine.Config{Version: manifest.Version})
+	if err := policytest.WriteReport(os.Stdout, reportFormat, results); err != nil {
+		logger.Error.Println("Unable to write policy test report: ", err)
+		return 1
+	}
+	for _, r := range results {
+		if !r.Passed() {
+			return 1
+		}
+	}
+	return 0
+}
+
+func lintPolicies(path string) int {
+	paths, err := ioutils.ListFilePaths(path, ".yaml")
+	if err != nil {
+		logger.Error.Println("Unable to list policy files: ", err)
+		return 1
+	}
+	findings, err := engine.NewPolicyInterpreter(engine.Config{Version: manifest.Version}).Lint(paths...)
+	if err != nil {
+		logger.Error.Println("Unable to lint policies: ", err)
+		return 1
+	}
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(findings); err != nil {
+		logger.Error.Println("Unable to write lint findings: ", err)
+		return 1
+	}
+	for _, f := range findings {
+		if f.Severity == engine.LintError {
+			return 1
+		}
+	}
+	return 0
+}
+
 func run() int {
 
 	// setup interruption handler
@@ -67,11 +121,16 @@ func run() int {
 	driverDir := flag.String("driverdir", pipeline.DriverDir, "Dynamic driver directory")
 	pluginDir := flag.String("plugdir", pipeline.PluginDir, "Dynamic plugins directory")
 	test := flag.Bool("test", false, "Test pipeline configuration")
+	policyTest := flag.String("policytest", "", "Run policy test cases in `dir` and output a test report")
+	testReport := flag.String("testreport", "tap", "Policy test report format {tap|junit}")
+	lint := flag.String("lint", "", "Check policies in `path` and output findings in JSON")
 	version := flag.Bool("version", false, "Output version information")
 
 	flag.Usage = func() {
 		fmt.Println(`Usage: sfprocessor [-version
 		   |-test [-log <value>] [-config <value>] [-driverdir <value>] [-plugdir <value>]]
+		   |-policytest <dir> [-testreport <value>] [-log <value>]
+		   |-lint <path> [-log <value>]
 		   |[-driver <value>] [-log <value>] [-config <value>] [-driverdir <value>] [-plugdir <value>] [-cpuprofile <value>] [-memprofile <value>] [-traceprofile <value>] path]`)
 		fmt.Println()
 		fmt.Println("Positional arguments:")
@@ -84,7 +143,7 @@ func run() int {
 
 	// parse args and validate positional args
 	flag.Parse()
-	if !*version && !*test && flag.NArg() < 1 {
+	if !*version && !*test && *policyTest == "" && *lint == "" && flag.NArg() < 1 {
 		flag.Usage()
 		return 1
 	}
@@ -101,6 +160,19 @@ func run() int {
 	// initialize logger
 	logger.InitLoggers(logger.GetLogLevelFromValue(*logLevel))
 
+	// register the exec rule action, which encodes alerts with the exporter, for all modes
+	engine.RegisterAction(actions.Exec, actions.NewExecActuator)
+
+	// run policy test cases and exit
+	if *policyTest != "" {
+		return runPolicyTests(*policyTest, *testReport)
+	}
+
+	// check policies and exit
+	if *lint != "" {
+		return lintPolicies(*lint)
+	}
+
 	// CPU profiling
 	if *cpuprofile != "" {
 		f, err := os.Create(*cpuprofile)
@@ -31,6 +31,7 @@ import (
 	"github.com/sysflow-telemetry/sf-apis/go/ioutils"
 	"github.com/sysflow-telemetry/sf-apis/go/logger"
 	"github.com/sysflow-telemetry/sf-apis/go/plugins"
+	"github.com/sysflow-telemetry/sf-processor/core/enricher"
 	"github.com/sysflow-telemetry/sf-processor/core/exporter"
 	"github.com/sysflow-telemetry/sf-processor/core/policyengine"
 	"github.com/sysflow-telemetry/sf-processor/core/processor"
@@ -64,6 +65,7 @@ func NewPluginCache(conf string) *PluginCache {
 // initializes plugin cache.
 func (p *PluginCache) init() {
 	(&processor.SysFlowProcessor{}).Register(p)
+	(&enricher.Enricher{}).Register(p)
 	(&policyengine.PolicyEngine{}).Register(p)
 	(&exporter.Exporter{}).Register(p)
 	(&sysflow.FileDriver{}).Register(p)